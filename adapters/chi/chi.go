@@ -0,0 +1,37 @@
+// Package chi mounts a spec-ui handler on a chi router.
+//
+//	r := chi.NewRouter()
+//	chiadapter.Register(r, specui.NewHandler(
+//		specui.WithSpecFile("openapi.yaml"),
+//		swaggeruiemb.WithUI(),
+//	))
+package chi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	specui "github.com/oaswrap/spec-ui"
+)
+
+// Register mounts the GET routes of h on r, see specui.Handler.Routes. With
+// the default paths, they are:
+//
+//   - /docs, the documentation
+//   - /docs/openapi.json, the specification
+//   - /docs/_assets/*, the embedded assets, in embed mode
+//   - /docs/changelog, the changelog page, when a previous specification is
+//     configured
+//   - /docs/v, the list of versions, and /docs/v/*, the specification
+//     and pages of each version, when a store is configured
+//
+// The route paths are absolute, so r should not be mounted under a prefix.
+func Register(r chi.Router, h *specui.Handler) {
+	for _, route := range h.Routes() {
+		path := route.Path
+		if route.Prefix {
+			path += "/*"
+		}
+		r.Method(http.MethodGet, path, route.Handler)
+	}
+}
@@ -0,0 +1,36 @@
+package chi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	specui "github.com/oaswrap/spec-ui"
+	chiadapter "github.com/oaswrap/spec-ui/adapters/chi"
+	"github.com/oaswrap/spec-ui/internal/adaptertest"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	adaptertest.Routes(t, adaptertest.Recorder(func(h *specui.Handler) http.Handler {
+		r := chi.NewRouter()
+		chiadapter.Register(r, h)
+		return r
+	}))
+}
+
+func TestRegisterAlongsideRoutes(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/ping", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("pong")) })
+	chiadapter.Register(r, specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS), swaggerui.WithUI()))
+
+	for path, body := range map[string]string{"/ping": "pong", "/docs": "swagger-ui"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Contains(t, rec.Body.String(), body, path)
+	}
+}
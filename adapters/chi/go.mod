@@ -0,0 +1,21 @@
+module github.com/oaswrap/spec-ui/adapters/chi
+
+go 1.20
+
+require (
+	github.com/go-chi/chi/v5 v5.2.2
+	github.com/oaswrap/spec-ui v0.0.0-20261019115541-9d867cd7ca3d
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace directive builds against the spec-ui module of this repository
+// in development. It is ignored when this module is a dependency, which
+// gets the version of spec-ui required above.
+replace github.com/oaswrap/spec-ui => ../..
@@ -0,0 +1,41 @@
+// Package echo mounts a spec-ui handler on an echo router.
+//
+//	e := echo.New()
+//	echoadapter.Register(e, specui.NewHandler(
+//		specui.WithSpecFile("openapi.yaml"),
+//		swaggeruiemb.WithUI(),
+//	))
+package echo
+
+import (
+	"github.com/labstack/echo/v4"
+	specui "github.com/oaswrap/spec-ui"
+)
+
+// Router is implemented by *echo.Echo and *echo.Group.
+type Router interface {
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// Register mounts the GET routes of h on r, see specui.Handler.Routes. With
+// the default paths, they are:
+//
+//   - /docs, the documentation
+//   - /docs/openapi.json, the specification
+//   - /docs/_assets/*, the embedded assets, in embed mode
+//   - /docs/changelog, the changelog page, when a previous specification is
+//     configured
+//   - /docs/v, the list of versions, and /docs/v/*, the specification
+//     and pages of each version, when a store is configured
+//
+// The route paths are absolute, so r should be the router or a group without
+// a prefix.
+func Register(r Router, h *specui.Handler) {
+	for _, route := range h.Routes() {
+		path := route.Path
+		if route.Prefix {
+			path += "/*"
+		}
+		r.GET(path, echo.WrapHandler(route.Handler))
+	}
+}
@@ -0,0 +1,36 @@
+package echo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	specui "github.com/oaswrap/spec-ui"
+	echoadapter "github.com/oaswrap/spec-ui/adapters/echo"
+	"github.com/oaswrap/spec-ui/internal/adaptertest"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	adaptertest.Routes(t, adaptertest.Recorder(func(h *specui.Handler) http.Handler {
+		e := echo.New()
+		echoadapter.Register(e, h)
+		return e
+	}))
+}
+
+func TestRegisterAlongsideRoutes(t *testing.T) {
+	e := echo.New()
+	e.GET("/ping", func(c echo.Context) error { return c.String(http.StatusOK, "pong") })
+	echoadapter.Register(e, specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS), swaggerui.WithUI()))
+
+	for path, body := range map[string]string{"/ping": "pong", "/docs": "swagger-ui"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Contains(t, rec.Body.String(), body, path)
+	}
+}
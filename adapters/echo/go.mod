@@ -0,0 +1,30 @@
+module github.com/oaswrap/spec-ui/adapters/echo
+
+go 1.23.0
+
+require (
+	github.com/labstack/echo/v4 v4.13.4
+	github.com/oaswrap/spec-ui v0.0.0-20261019115541-9d867cd7ca3d
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/labstack/gommon v0.4.2 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	golang.org/x/crypto v0.38.0 // indirect
+	golang.org/x/net v0.40.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.25.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace directive builds against the spec-ui module of this repository
+// in development. It is ignored when this module is a dependency, which
+// gets the version of spec-ui required above.
+replace github.com/oaswrap/spec-ui => ../..
@@ -0,0 +1,108 @@
+// Package fasthttp serves a spec-ui handler as a fasthttp.RequestHandler.
+//
+// Responses are rendered once with the net/http handlers, see
+// specui.Handler.Content, and their bytes are then served to every request
+// without adapting requests and responses between fasthttp and net/http:
+//
+//	h := specui.NewHandler(
+//		specui.WithSpecFile("openapi.yaml"),
+//		swaggeruiemb.WithUI(),
+//	)
+//	log.Fatal(fasthttp.ListenAndServe(":3000", fasthttpadapter.NewHandler(h, nil)))
+package fasthttp
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+// NewHandler returns a fasthttp.RequestHandler serving the routes of h: the
+// documentation, the specification, the embedded assets, the changelog page
+// and the versions of the specification. Requests for other paths are passed
+// to next, or answered with 404 when next is nil.
+//
+// A specification that depends on the request, see specui.WithSpecVariant,
+// and the responses under the versions path, which change when versions are
+// published or removed, are served through the net/http handlers. The requests for the routes are
+// reported to the observer of h, see specui.WithObserver.
+func NewHandler(h *specui.Handler, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	spec := fasthttpadaptor.NewFastHTTPHandler(h.Spec())
+	var versions fasthttp.RequestHandler
+	if h.VersionsEnabled() {
+		versions = fasthttpadaptor.NewFastHTTPHandler(h.Versions())
+	}
+
+	return func(ctx *fasthttp.RequestCtx) {
+		path := string(ctx.Path())
+		c, ok := h.Content(path)
+		switch {
+		case !ok && path == h.SpecPath():
+			if !ctx.IsGet() && !ctx.IsHead() {
+				methodNotAllowed(ctx)
+				return
+			}
+			spec(ctx)
+			return
+		case !ok && versions != nil && (path == h.VersionsPath() || strings.HasPrefix(path, h.VersionsPath()+"/")):
+			if !ctx.IsGet() && !ctx.IsHead() {
+				methodNotAllowed(ctx)
+				return
+			}
+			versions(ctx)
+			return
+		case !ok && next != nil:
+			next(ctx)
+			return
+		case !ok:
+			ctx.Error(http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+
+		start := time.Now()
+		if ctx.IsGet() || ctx.IsHead() {
+			Serve(ctx, c)
+		} else {
+			methodNotAllowed(ctx)
+		}
+		var n int64
+		if !ctx.IsHead() {
+			n = int64(len(ctx.Response.Body()))
+		}
+		h.ObserveRequest(observe.Request{
+			Context:  ctx,
+			Route:    c.Kind,
+			Method:   string(ctx.Method()),
+			Path:     path,
+			Status:   ctx.Response.StatusCode(),
+			Bytes:    n,
+			Start:    start,
+			Duration: time.Since(start),
+		})
+	}
+}
+
+// Serve writes the content c as the response to ctx: 304 Not Modified when
+// the request has a matching If-None-Match header, and the gzip variant of c
+// when the request accepts it, see specui.Content.Negotiate. The body is
+// shared, not copied; fasthttp omits it for HEAD requests.
+func Serve(ctx *fasthttp.RequestCtx, c *specui.Content) {
+	c = c.Negotiate(string(ctx.Request.Header.Peek("If-None-Match")), string(ctx.Request.Header.Peek("Accept-Encoding")))
+	ctx.SetStatusCode(c.Status)
+	for key, values := range c.Header {
+		for _, v := range values {
+			ctx.Response.Header.Add(key, v)
+		}
+	}
+	ctx.Response.SetBodyRaw(c.Body)
+}
+
+func methodNotAllowed(ctx *fasthttp.RequestCtx) {
+	ctx.Response.Header.Set("Allow", "GET, HEAD")
+	ctx.Error(http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,251 @@
+package fasthttp_test
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	specui "github.com/oaswrap/spec-ui"
+	fasthttpadapter "github.com/oaswrap/spec-ui/adapters/fasthttp"
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func serve(handler fasthttp.RequestHandler, method, path, host string) *fasthttp.Response {
+	var req fasthttp.Request
+	req.Header.SetMethod(method)
+	req.SetRequestURI(path)
+	req.Header.SetHost(host)
+	var ctx fasthttp.RequestCtx
+	ctx.Init(&req, nil, nil)
+	handler(&ctx)
+	var resp fasthttp.Response
+	ctx.Response.CopyTo(&resp)
+	return &resp
+}
+
+func TestNewHandler(t *testing.T) {
+	previous := fstest.MapFS{"v1.yaml": {Data: []byte("openapi: 3.0.3\ninfo: {title: Pets, version: \"0.9\"}\npaths: {}\n")}}
+	next := func(ctx *fasthttp.RequestCtx) { ctx.SetBodyString("next") }
+
+	tests := []struct {
+		name         string
+		opts         []specui.Option
+		next         fasthttp.RequestHandler
+		method       string
+		path         string
+		status       int
+		contentType  string
+		cacheControl string
+		body         string
+	}{
+		{
+			name:        "when requesting the docs",
+			opts:        []specui.Option{swaggerui.WithUI()},
+			path:        "/docs",
+			status:      http.StatusOK,
+			contentType: "text/html",
+			body:        "swagger-ui",
+		},
+		{
+			name:         "when requesting the spec",
+			opts:         []specui.Option{swaggerui.WithUI()},
+			path:         "/docs/openapi.json",
+			status:       http.StatusOK,
+			contentType:  "application/json",
+			cacheControl: "public, max-age=3600, immutable",
+			body:         `"openapi"`,
+		},
+		{
+			name:        "when requesting an embedded asset",
+			opts:        []specui.Option{swaggeruiemb.WithUI()},
+			path:        "/docs/_assets/swagger-ui.css",
+			status:      http.StatusOK,
+			contentType: "text/css; charset=utf-8",
+			body:        ".swagger-ui",
+		},
+		{
+			name:   "when requesting an unknown asset",
+			opts:   []specui.Option{swaggeruiemb.WithUI()},
+			path:   "/docs/_assets/missing.js",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when requesting the changelog",
+			opts:   []specui.Option{specui.WithChangelog("v1.yaml", previous), swaggerui.WithUI()},
+			path:   "/docs/changelog",
+			status: http.StatusOK,
+			body:   "Changelog",
+		},
+		{
+			name:   "when listing the versions",
+			opts:   []specui.Option{specui.WithSpecStore(store.Dir{FS: previous}), swaggerui.WithUI()},
+			path:   "/docs/v",
+			status: http.StatusOK,
+			body:   `"versions":["v1"]`,
+		},
+		{
+			name:   "when requesting the spec of a version",
+			opts:   []specui.Option{specui.WithSpecStore(store.Dir{FS: previous}), swaggerui.WithUI()},
+			path:   "/docs/v/v1/openapi.json",
+			status: http.StatusOK,
+			body:   `"openapi": "3.0.3"`,
+		},
+		{
+			name:   "when the method is not allowed",
+			opts:   []specui.Option{swaggerui.WithUI()},
+			method: http.MethodPost,
+			path:   "/docs",
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "when the path is not a route",
+			opts:   []specui.Option{swaggerui.WithUI()},
+			path:   "/users",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when the path is not a route and next is set",
+			opts:   []specui.Option{swaggerui.WithUI()},
+			next:   next,
+			path:   "/users",
+			status: http.StatusOK,
+			body:   "next",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := specui.NewHandler(append(tt.opts, specui.WithSpecEmbedFS("petstore.json", &testdata.FS))...)
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			resp := serve(fasthttpadapter.NewHandler(h, tt.next), method, tt.path, "example.com")
+			assert.Equal(t, tt.status, resp.StatusCode())
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, string(resp.Header.ContentType()))
+			}
+			if tt.cacheControl != "" {
+				assert.Equal(t, tt.cacheControl, string(resp.Header.Peek("Cache-Control")))
+			}
+			assert.Contains(t, string(resp.Body()), tt.body)
+		})
+	}
+}
+
+func TestNewHandlerSpecVariant(t *testing.T) {
+	h := specui.NewHandler(
+		specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+		specui.WithSpecVariant(func(r *http.Request) string { return r.Host }),
+		swaggerui.WithUI(),
+	)
+	handler := fasthttpadapter.NewHandler(h, nil)
+
+	resp := serve(handler, http.MethodGet, h.SpecPath(), "eu.example.com")
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Contains(t, string(resp.Body()), `"openapi"`)
+
+	resp = serve(handler, http.MethodDelete, h.SpecPath(), "eu.example.com")
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode())
+}
+
+func TestNewHandlerNegotiation(t *testing.T) {
+	h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS), swaggerui.WithUI())
+	handler := fasthttpadapter.NewHandler(h, nil)
+	request := func(header, value string) *fasthttp.Response {
+		var req fasthttp.Request
+		req.SetRequestURI("/docs")
+		req.Header.Set(header, value)
+		var ctx fasthttp.RequestCtx
+		ctx.Init(&req, nil, nil)
+		handler(&ctx)
+		var resp fasthttp.Response
+		ctx.Response.CopyTo(&resp)
+		return &resp
+	}
+
+	plain := serve(handler, http.MethodGet, "/docs", "example.com")
+	require.Equal(t, http.StatusOK, plain.StatusCode())
+	etag := string(plain.Header.Peek("ETag"))
+	require.NotEmpty(t, etag)
+
+	t.Run("when the client has the page", func(t *testing.T) {
+		resp := request("If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode())
+		assert.Equal(t, etag, string(resp.Header.Peek("ETag")))
+		assert.Empty(t, resp.Body())
+	})
+	t.Run("when the client has another version of the page", func(t *testing.T) {
+		resp := request("If-None-Match", `"stale"`)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, plain.Body(), resp.Body())
+	})
+	t.Run("when the client accepts gzip", func(t *testing.T) {
+		resp := request("Accept-Encoding", "gzip, deflate")
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, "gzip", string(resp.Header.Peek("Content-Encoding")))
+		body, err := resp.BodyGunzip()
+		require.NoError(t, err)
+		assert.Equal(t, plain.Body(), body)
+	})
+	t.Run("when the client refuses gzip", func(t *testing.T) {
+		resp := request("Accept-Encoding", "gzip;q=0")
+		assert.Empty(t, resp.Header.Peek("Content-Encoding"))
+		assert.Equal(t, plain.Body(), resp.Body())
+	})
+}
+
+type recordObserver struct {
+	requests []observe.Request
+	loads    []observe.Load
+}
+
+func (o *recordObserver) ObserveRequest(e observe.Request) { o.requests = append(o.requests, e) }
+func (o *recordObserver) ObserveLoad(e observe.Load)       { o.loads = append(o.loads, e) }
+
+func TestNewHandlerObserver(t *testing.T) {
+	obs := &recordObserver{}
+	h := specui.NewHandler(
+		specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+		specui.WithObserver(obs),
+		swaggerui.WithUI(),
+	)
+	handler := fasthttpadapter.NewHandler(h, nil)
+
+	docs := serve(handler, http.MethodGet, "/docs", "example.com")
+	serve(handler, http.MethodHead, h.SpecPath(), "example.com")
+	serve(handler, http.MethodPost, "/docs", "example.com")
+	serve(handler, http.MethodGet, "/users", "example.com")
+
+	require.Len(t, obs.requests, 3, "requests for other paths are not reported")
+	for i, want := range []struct {
+		route  observe.RouteKind
+		method string
+		path   string
+		status int
+		bytes  int64
+	}{
+		{observe.Docs, http.MethodGet, "/docs", http.StatusOK, int64(len(docs.Body()))},
+		{observe.Spec, http.MethodHead, h.SpecPath(), http.StatusOK, 0},
+		{observe.Docs, http.MethodPost, "/docs", http.StatusMethodNotAllowed, int64(len(http.StatusText(http.StatusMethodNotAllowed)))},
+	} {
+		e := obs.requests[i]
+		assert.Equal(t, want.route, e.Route)
+		assert.Equal(t, "swaggerui", e.Provider)
+		assert.Equal(t, want.method, e.Method)
+		assert.Equal(t, want.path, e.Path)
+		assert.Equal(t, want.status, e.Status)
+		assert.Equal(t, want.bytes, e.Bytes)
+		assert.NotNil(t, e.Context)
+		assert.False(t, e.Start.IsZero())
+	}
+	require.Len(t, obs.loads, 1, "the specification is loaded once, when the content is rendered")
+	assert.NoError(t, obs.loads[0].Err)
+}
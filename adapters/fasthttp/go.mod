@@ -0,0 +1,24 @@
+module github.com/oaswrap/spec-ui/adapters/fasthttp
+
+go 1.20
+
+require (
+	github.com/oaswrap/spec-ui v0.0.0-20261019115541-9d867cd7ca3d
+	github.com/stretchr/testify v1.10.0
+	github.com/valyala/fasthttp v1.51.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.0.5 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/compress v1.17.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace directive builds against the spec-ui module of this repository
+// in development. It is ignored when this module is a dependency, which
+// gets the version of spec-ui required above.
+replace github.com/oaswrap/spec-ui => ../..
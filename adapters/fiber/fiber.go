@@ -0,0 +1,45 @@
+// Package fiber mounts a spec-ui handler on a fiber router.
+//
+//	app := fiber.New()
+//	fiberadapter.Register(app, specui.NewHandler(
+//		specui.WithSpecFile("openapi.yaml"),
+//		swaggeruiemb.WithUI(),
+//	))
+//
+// Routes are served by the fasthttp adapter, without adapting requests and
+// responses to net/http.
+package fiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+	specui "github.com/oaswrap/spec-ui"
+	fasthttpadapter "github.com/oaswrap/spec-ui/adapters/fasthttp"
+)
+
+// Register mounts the GET routes of h on r, see specui.Handler.Routes. With
+// the default paths, they are:
+//
+//   - /docs, the documentation
+//   - /docs/openapi.json, the specification
+//   - /docs/_assets/*, the embedded assets, in embed mode
+//   - /docs/changelog, the changelog page, when a previous specification is
+//     configured
+//   - /docs/v, the list of versions, and /docs/v/*, the specification
+//     and pages of each version, when a store is configured
+//
+// The route paths are absolute, so r should be the app or a group without a
+// prefix.
+func Register(r fiber.Router, h *specui.Handler) {
+	serve := fasthttpadapter.NewHandler(h, nil)
+	handler := func(c *fiber.Ctx) error {
+		serve(c.Context())
+		return nil
+	}
+	for _, route := range h.Routes() {
+		path := route.Path
+		if route.Prefix {
+			path += "/*"
+		}
+		r.Get(path, handler)
+	}
+}
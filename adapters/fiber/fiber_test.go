@@ -0,0 +1,43 @@
+package fiber_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	specui "github.com/oaswrap/spec-ui"
+	fiberadapter "github.com/oaswrap/spec-ui/adapters/fiber"
+	"github.com/oaswrap/spec-ui/internal/adaptertest"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	adaptertest.Routes(t, func(t *testing.T, h *specui.Handler, req *http.Request) *http.Response {
+		app := fiber.New()
+		fiberadapter.Register(app, h)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp
+	})
+}
+
+func TestRegisterAlongsideRoutes(t *testing.T) {
+	app := fiber.New()
+	app.Get("/ping", func(c *fiber.Ctx) error { return c.SendString("pong") })
+	fiberadapter.Register(app, specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS), swaggerui.WithUI()))
+
+	for path, want := range map[string]string{"/ping": "pong", "/docs": "swagger-ui"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+		assert.Contains(t, string(body), want, path)
+	}
+}
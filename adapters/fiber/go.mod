@@ -0,0 +1,36 @@
+module github.com/oaswrap/spec-ui/adapters/fiber
+
+go 1.20
+
+require (
+	github.com/gofiber/fiber/v2 v2.52.12
+	github.com/oaswrap/spec-ui v0.0.0-20261019115541-9d867cd7ca3d
+	github.com/oaswrap/spec-ui/adapters/fasthttp v0.0.0-20261019115619-72ff4d3811ce
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.1.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rivo/uniseg v0.2.0 // indirect
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasthttp v1.51.0 // indirect
+	github.com/valyala/tcplisten v1.0.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace directives build against the spec-ui and fasthttp adapter
+// modules of this repository in development. They are ignored when this
+// module is a dependency, which gets the versions required above.
+replace (
+	github.com/oaswrap/spec-ui => ../..
+	github.com/oaswrap/spec-ui/adapters/fasthttp => ../fasthttp
+)
@@ -0,0 +1,36 @@
+// Package gin mounts a spec-ui handler on a gin router.
+//
+//	r := gin.Default()
+//	ginadapter.Register(r, specui.NewHandler(
+//		specui.WithSpecFile("openapi.yaml"),
+//		swaggeruiemb.WithUI(),
+//	))
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	specui "github.com/oaswrap/spec-ui"
+)
+
+// Register mounts the GET routes of h on r, see specui.Handler.Routes. With
+// the default paths, they are:
+//
+//   - /docs, the documentation
+//   - /docs/openapi.json, the specification
+//   - /docs/_assets/*filepath, the embedded assets, in embed mode
+//   - /docs/changelog, the changelog page, when a previous specification is
+//     configured
+//   - /docs/v, the list of versions, and /docs/v/*filepath, the specification
+//     and pages of each version, when a store is configured
+//
+// The route paths are absolute, so r should be the engine or a group without
+// a prefix.
+func Register(r gin.IRouter, h *specui.Handler) {
+	for _, route := range h.Routes() {
+		path := route.Path
+		if route.Prefix {
+			path += "/*filepath"
+		}
+		r.GET(path, gin.WrapH(route.Handler))
+	}
+}
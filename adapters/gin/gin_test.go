@@ -0,0 +1,38 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	specui "github.com/oaswrap/spec-ui"
+	ginadapter "github.com/oaswrap/spec-ui/adapters/gin"
+	"github.com/oaswrap/spec-ui/internal/adaptertest"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adaptertest.Routes(t, adaptertest.Recorder(func(h *specui.Handler) http.Handler {
+		r := gin.New()
+		ginadapter.Register(r, h)
+		return r
+	}))
+}
+
+func TestRegisterAlongsideRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	ginadapter.Register(r, specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS), swaggerui.WithUI()))
+
+	for path, body := range map[string]string{"/ping": "pong", "/docs": "swagger-ui"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Contains(t, rec.Body.String(), body, path)
+	}
+}
@@ -0,0 +1,99 @@
+package specui
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"sync"
+
+	"github.com/oaswrap/spec-ui/diff"
+	"github.com/oaswrap/spec-ui/internal/errorhandler"
+	"github.com/oaswrap/spec-ui/internal/logger"
+	"github.com/oaswrap/spec-ui/problem"
+)
+
+var changelogTpl = template.Must(template.New("changelog").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{ .Title }} - Changelog</title>
+  <style>
+    body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; color: #1f2328; }
+    h1 { font-size: 1.5rem; }
+    h2 { font-size: 1.2rem; }
+    h2.breaking { color: #cf222e; }
+    li { margin: .5rem 0; }
+    code { background: #f6f8fa; padding: .1rem .3rem; border-radius: 4px; }
+  </style>
+</head>
+<body>
+  <h1>{{ .Title }} - Changelog</h1>
+  <p><a href="{{ .DocsPath }}">Back to the documentation</a></p>
+  {{ if not .Report.Changes }}<p>No changes since the previous version.</p>
+  {{ else }}{{ with .Report.Breaking }}
+  <h2 class="breaking">Breaking changes ({{ len . }})</h2>
+  <ul>
+    {{ range . }}<li><code>{{ .Operation }}</code>: {{ .Message }}</li>
+    {{ end }}
+  </ul>{{ end }}{{ with .Report.NonBreaking }}
+  <h2>Non-breaking changes ({{ len . }})</h2>
+  <ul>
+    {{ range . }}<li><code>{{ .Operation }}</code>: {{ .Message }}</li>
+    {{ end }}
+  </ul>{{ end }}{{ end }}
+</body>
+</html>
+`))
+
+// changelogPage serves a page listing the changes from the previous
+// specification. The page is rendered again when the specification changes,
+// and failures are answered with the error handler on each request, so that
+// the next one compares the specifications again.
+type changelogPage struct {
+	handler *Handler
+	mu      sync.Mutex
+	page    []byte
+	etag    string // ETag of the specification the page was rendered for
+}
+
+func (p *changelogPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cfg := p.handler.cfg
+	page, err := p.render(r)
+	if err != nil {
+		logger.Get(cfg).Error("failed to render changelog", "path", r.URL.Path, "error", err)
+		errorhandler.Get(cfg)(w, r, http.StatusInternalServerError, &problem.Error{Message: "failed to compare OpenAPI specifications", Err: err})
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
+
+// render returns the page for the current specification, rendering it when
+// it changed since the last successful render.
+func (p *changelogPage) render(r *http.Request) ([]byte, error) {
+	_, etag, err := p.handler.specHandler().Current(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.page != nil && p.etag == etag {
+		return p.page, nil
+	}
+
+	report, err := p.handler.Diff()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := changelogTpl.Execute(&buf, struct {
+		Title    string
+		DocsPath string
+		Report   *diff.Report
+	}{p.handler.cfg.Title, p.handler.cfg.DocsPath, report}); err != nil {
+		return nil, err
+	}
+	p.page, p.etag = buf.Bytes(), etag
+	return p.page, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// fetcher retrieves vendored files from a CDN URL template or from a local
+// directory mirroring it.
+type fetcher struct {
+	source string
+	client *http.Client
+}
+
+// location returns the URL or local path a file is fetched from.
+//
+// Package files are resolved with the source template, whose {package},
+// {version} and {file} placeholders follow the config.CDN conventions. When
+// the source is a local directory, package files are read from
+// "<dir>/<package>@<version>/<file>" and other files from "<dir>/<host>/<path>".
+func (f *fetcher) location(p Provider, file File) (string, error) {
+	if !f.local() {
+		if file.URL != "" {
+			return file.URL, nil
+		}
+		tpl := strings.NewReplacer("{package}", p.Package, "{version}", p.Version).Replace(f.source)
+		if !strings.Contains(tpl, "{file}") {
+			tpl = strings.TrimSuffix(tpl, "/") + "/{file}"
+		}
+		return strings.Replace(tpl, "{file}", path.Join(p.Dir, file.File), 1), nil
+	}
+
+	if file.URL != "" {
+		u, err := url.Parse(file.URL)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(f.source, u.Host, filepath.FromSlash(u.Path)), nil
+	}
+	return filepath.Join(f.source, p.Package+"@"+p.Version, filepath.FromSlash(path.Join(p.Dir, file.File))), nil
+}
+
+func (f *fetcher) fetch(ctx context.Context, p Provider, file File) ([]byte, error) {
+	loc, err := f.location(p, file)
+	if err != nil {
+		return nil, err
+	}
+	if f.local() {
+		return os.ReadFile(loc)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loc, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", loc, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (f *fetcher) local() bool {
+	return !strings.Contains(f.source, "://")
+}
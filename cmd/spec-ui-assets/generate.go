@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// constantsFile is the generated file holding the version pins and SRI
+// hashes, relative to the repository root.
+const constantsFile = "internal/constant/assets.go"
+
+var constantsTpl = template.Must(template.New("constants").Parse(`// Code generated by spec-ui-assets from the assets manifest. DO NOT EDIT.
+
+package constant
+
+const (
+{{- range .Providers }}
+	{{ .Name }}Package = {{ printf "%q" .Package }}
+	{{ .Name }}Version = {{ printf "%q" .Version }}
+	{{ .Name }}AssetsDir = {{ printf "%q" .Dir }}
+{{ end -}}
+)
+
+// Subresource Integrity hashes of the pinned provider assets, keyed by the
+// file path relative to the package assets directory. Files generated on the
+// fly by the CDN are left out, as their content is not guaranteed to be
+// stable.
+var (
+{{- range .Providers }}
+	{{ .Name }}Integrity = map[string]string{
+	{{- range .Integrity }}
+		{{ printf "%q" .File }}: {{ printf "%q" .Hash }},
+	{{- end }}
+	}
+{{ end -}}
+)
+`))
+
+type integrity struct {
+	File string
+	Hash string
+}
+
+// generate renders the constants file from the manifest and the vendored
+// files found under root.
+func generate(root string, m *Manifest) ([]byte, error) {
+	type provider struct {
+		Provider
+		Integrity []integrity
+	}
+
+	data := struct{ Providers []provider }{}
+	for _, p := range m.Providers {
+		gp := provider{Provider: p}
+		for _, f := range p.Files {
+			if f.URL != "" || f.Dynamic || f.Local {
+				continue
+			}
+			b, err := os.ReadFile(filepath.Join(root, p.Embed, filepath.FromSlash(f.File)))
+			if err != nil {
+				return nil, err
+			}
+			sum := sha512.Sum384(b)
+			gp.Integrity = append(gp.Integrity, integrity{
+				File: f.File,
+				Hash: "sha384-" + base64.StdEncoding.EncodeToString(sum[:]),
+			})
+		}
+		data.Providers = append(data.Providers, gp)
+	}
+
+	var buf bytes.Buffer
+	if err := constantsTpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,248 @@
+// Command spec-ui-assets vendors the provider assets embedded by the *emb
+// packages.
+//
+// Usage:
+//
+//	spec-ui-assets download [flags]
+//	spec-ui-assets verify [flags]
+//
+// The download command fetches every file listed in the manifest, checks its
+// SHA-256, writes it to the provider's embed directory and regenerates the
+// version pins and SRI hashes in internal/constant. The files are fetched into
+// a temporary directory and moved into place only once all of them are
+// checked, so that a failure leaves the embedded assets as they were. Files
+// marked local, such as self-hosted fonts, are kept in the repository and only
+// checked. The verify command checks the embedded files and the generated
+// constants against the manifest, and fails on files of the embed directories
+// that the manifest does not list.
+//
+// Flags:
+//
+//	-manifest string  path to the assets manifest (default "assets.json")
+//	-root string      repository root the manifest paths are relative to (default ".")
+//	-source string    CDN URL template or local directory to fetch package files from
+//	-update           record the SHA-256 of fetched files in the manifest instead of checking them
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/oaswrap/spec-ui/internal/constant"
+)
+
+var errUsage = errors.New("usage: spec-ui-assets download|verify [flags]")
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "spec-ui-assets:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(out)
+	manifestPath := flags.String("manifest", "assets.json", "path to the assets manifest")
+	root := flags.String("root", ".", "repository root the manifest paths are relative to")
+	source := flags.String("source", constant.CDNTemplate, "CDN URL template or local directory to fetch package files from")
+	update := flags.Bool("update", false, "record the SHA-256 of fetched files in the manifest instead of checking them")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	m, err := readManifest(*manifestPath)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "download":
+		f := &fetcher{source: *source, client: &http.Client{Timeout: time.Minute}}
+		if err := download(ctx, f, *root, m, *update, out); err != nil {
+			return err
+		}
+		if *update {
+			return writeManifest(*manifestPath, m)
+		}
+		return nil
+	case "verify":
+		return verify(*root, m, out)
+	default:
+		return errUsage
+	}
+}
+
+// download fetches the files of m into a temporary directory of root and
+// checks them, then moves them into the embed directories with the
+// regenerated constants file. A failure leaves the embedded assets as they
+// were.
+func download(ctx context.Context, f *fetcher, root string, m *Manifest, update bool, out io.Writer) error {
+	staging, err := os.MkdirTemp(root, ".spec-ui-assets-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	var staged []string
+	for i := range m.Providers {
+		p := &m.Providers[i]
+		for j := range p.Files {
+			file := &p.Files[j]
+			name := filepath.Join(p.Embed, filepath.FromSlash(file.File))
+			var b []byte
+			var err error
+			if file.Local {
+				b, err = os.ReadFile(filepath.Join(root, name))
+			} else {
+				b, err = f.fetch(ctx, *p, *file)
+			}
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", p.Name, file.File, err)
+			}
+
+			sum := sha256Hex(b)
+			switch {
+			case update:
+				file.SHA256 = sum
+			case file.SHA256 != sum:
+				return fmt.Errorf("%s %s: SHA-256 mismatch: got %s, want %s", p.Name, file.File, sum, file.SHA256)
+			}
+			if file.Local {
+				continue
+			}
+
+			if err := writeFile(filepath.Join(staging, name), b); err != nil {
+				return err
+			}
+			staged = append(staged, name)
+		}
+	}
+
+	// The constants are generated from the staged files, as local files get
+	// no SRI hash.
+	src, err := generate(staging, m)
+	if err != nil {
+		return err
+	}
+	name := filepath.FromSlash(constantsFile)
+	if err := writeFile(filepath.Join(staging, name), src); err != nil {
+		return err
+	}
+	staged = append(staged, name)
+
+	for _, name := range staged {
+		dst := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(staging, name), dst); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "wrote %s\n", filepath.ToSlash(name))
+	}
+	return nil
+}
+
+func writeFile(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+func verify(root string, m *Manifest, out io.Writer) error {
+	var errs []error
+	for _, p := range m.Providers {
+		unlisted, err := unlistedFiles(root, p)
+		if err != nil {
+			return err
+		}
+		for _, name := range unlisted {
+			errs = append(errs, fmt.Errorf("%s: not listed in the manifest", name))
+		}
+		for _, file := range p.Files {
+			name := filepath.ToSlash(filepath.Join(p.Embed, file.File))
+			b, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if sum := sha256Hex(b); sum != file.SHA256 {
+				errs = append(errs, fmt.Errorf("%s: SHA-256 mismatch: got %s, want %s", name, sum, file.SHA256))
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		src, err := generate(root, m)
+		if err != nil {
+			return err
+		}
+		current, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(constantsFile)))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(src, current) {
+			errs = append(errs, fmt.Errorf("%s is out of date, run spec-ui-assets download", constantsFile))
+		}
+	}
+
+	for _, err := range errs {
+		fmt.Fprintln(out, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d asset check(s) failed", len(errs))
+	}
+	fmt.Fprintln(out, "all assets match the manifest")
+	return nil
+}
+
+// unlistedFiles returns the files of the embed directory of p that the
+// manifest does not list, as slash-separated paths relative to root.
+func unlistedFiles(root string, p Provider) ([]string, error) {
+	listed := make(map[string]bool, len(p.Files))
+	for _, file := range p.Files {
+		listed[file.File] = true
+	}
+	dir := filepath.Join(root, filepath.FromSlash(p.Embed))
+	var unlisted []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if !listed[filepath.ToSlash(rel)] {
+			unlisted = append(unlisted, filepath.ToSlash(filepath.Join(p.Embed, rel)))
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		// Missing files are reported by name.
+		return nil, nil
+	}
+	return unlisted, err
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `{
+  "providers": [
+    {
+      "name": "Demo",
+      "package": "@demo/ui",
+      "version": "1.2.3",
+      "dir": "dist",
+      "embed": "demoemb/assets",
+      "files": [
+        {"file": "app.js", "sha256": "%APP%"},
+        {"file": "app.css", "dynamic": true, "sha256": "%CSS%"},
+        {"file": "favicon.png", "url": "%FAVICON%", "sha256": "%ICON%"},
+        {"file": "fonts/font.woff2", "local": true, "sha256": "%FONT%"}
+      ]
+    }
+  ]
+}`
+
+var testFiles = map[string]string{
+	"/@demo/ui@1.2.3/dist/app.js":  "console.log('demo')",
+	"/@demo/ui@1.2.3/dist/app.css": "body{}",
+	"/favicon.png":                 "png",
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := testFiles[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeTestManifest(t *testing.T, root, favicon string, hashes map[string]string) string {
+	t.Helper()
+	content := strings.NewReplacer(
+		"%APP%", hashes["app.js"],
+		"%CSS%", hashes["app.css"],
+		"%ICON%", hashes["favicon.png"],
+		"%FONT%", hashes["font.woff2"],
+		"%FAVICON%", favicon,
+	).Replace(testManifest)
+
+	// Local files are kept in the repository.
+	font := filepath.Join(root, "demoemb", "assets", "fonts", "font.woff2")
+	require.NoError(t, os.MkdirAll(filepath.Dir(font), 0o755))
+	require.NoError(t, os.WriteFile(font, []byte("woff2"), 0o644))
+
+	path := filepath.Join(root, "assets.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestDownloadAndVerify(t *testing.T) {
+	srv := newTestServer(t)
+	root := t.TempDir()
+	manifest := writeTestManifest(t, root, srv.URL+"/favicon.png", nil)
+	source := srv.URL + "/{package}@{version}/{file}"
+
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"download", "-manifest", manifest, "-root", root, "-source", source}, &out)
+	require.Error(t, err, "hashes are checked unless -update is set")
+	assert.Contains(t, err.Error(), "SHA-256 mismatch")
+
+	out.Reset()
+	err = run(context.Background(), []string{"download", "-update", "-manifest", manifest, "-root", root, "-source", source}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "wrote demoemb/assets/app.js")
+	assert.Contains(t, out.String(), "wrote internal/constant/assets.go")
+
+	b, err := os.ReadFile(filepath.Join(root, "demoemb", "assets", "favicon.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(b))
+
+	m, err := readManifest(manifest)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex([]byte("console.log('demo')")), m.Providers[0].Files[0].SHA256)
+
+	constants, err := os.ReadFile(filepath.Join(root, "internal", "constant", "assets.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(constants), `DemoVersion   = "1.2.3"`)
+	assert.Contains(t, string(constants), `"app.js": "sha384-`)
+	assert.NotContains(t, string(constants), `"app.css"`)
+	assert.NotContains(t, string(constants), `"favicon.png"`)
+	assert.NotContains(t, string(constants), `"fonts/font.woff2"`)
+	assert.Equal(t, sha256Hex([]byte("woff2")), m.Providers[0].Files[3].SHA256, "local files are checksummed")
+
+	out.Reset()
+	err = run(context.Background(), []string{"download", "-manifest", manifest, "-root", root, "-source", source}, &out)
+	require.NoError(t, err)
+
+	out.Reset()
+	require.NoError(t, run(context.Background(), []string{"verify", "-manifest", manifest, "-root", root}, &out))
+	assert.Contains(t, out.String(), "all assets match the manifest")
+
+	t.Run("modified file", func(t *testing.T) {
+		path := filepath.Join(root, "demoemb", "assets", "app.js")
+		require.NoError(t, os.WriteFile(path, []byte("tampered"), 0o644))
+		t.Cleanup(func() { _ = os.WriteFile(path, []byte("console.log('demo')"), 0o644) })
+
+		out.Reset()
+		assert.Error(t, run(context.Background(), []string{"verify", "-manifest", manifest, "-root", root}, &out))
+		assert.Contains(t, out.String(), "demoemb/assets/app.js: SHA-256 mismatch")
+	})
+	t.Run("unlisted file", func(t *testing.T) {
+		path := filepath.Join(root, "demoemb", "assets", "fonts", "extra.woff2")
+		require.NoError(t, os.WriteFile(path, []byte("woff2"), 0o644))
+		t.Cleanup(func() { _ = os.Remove(path) })
+
+		out.Reset()
+		assert.Error(t, run(context.Background(), []string{"verify", "-manifest", manifest, "-root", root}, &out))
+		assert.Contains(t, out.String(), "demoemb/assets/fonts/extra.woff2: not listed in the manifest")
+	})
+	t.Run("modified local file", func(t *testing.T) {
+		path := filepath.Join(root, "demoemb", "assets", "fonts", "font.woff2")
+		require.NoError(t, os.WriteFile(path, []byte("tampered"), 0o644))
+		t.Cleanup(func() { _ = os.WriteFile(path, []byte("woff2"), 0o644) })
+
+		out.Reset()
+		assert.Error(t, run(context.Background(), []string{"verify", "-manifest", manifest, "-root", root}, &out))
+		assert.Contains(t, out.String(), "demoemb/assets/fonts/font.woff2: SHA-256 mismatch")
+	})
+	t.Run("failed download", func(t *testing.T) {
+		testFiles["/@demo/ui@1.2.3/dist/app.js"] = "console.log('next')"
+		delete(testFiles, "/favicon.png")
+		t.Cleanup(func() {
+			testFiles["/@demo/ui@1.2.3/dist/app.js"] = "console.log('demo')"
+			testFiles["/favicon.png"] = "png"
+		})
+
+		out.Reset()
+		err := run(context.Background(), []string{"download", "-update", "-manifest", manifest, "-root", root, "-source", source}, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Demo favicon.png")
+		assert.NotContains(t, out.String(), "wrote")
+
+		b, err := os.ReadFile(filepath.Join(root, "demoemb", "assets", "app.js"))
+		require.NoError(t, err)
+		assert.Equal(t, "console.log('demo')", string(b), "fetched files are not moved into place")
+		entries, err := os.ReadDir(root)
+		require.NoError(t, err)
+		for _, e := range entries {
+			assert.False(t, strings.HasPrefix(e.Name(), ".spec-ui-assets-"), "the temporary directory is removed")
+		}
+
+		out.Reset()
+		require.NoError(t, run(context.Background(), []string{"verify", "-manifest", manifest, "-root", root}, &out))
+	})
+	t.Run("stale constants", func(t *testing.T) {
+		path := filepath.Join(root, "internal", "constant", "assets.go")
+		require.NoError(t, os.WriteFile(path, []byte("package constant\n"), 0o644))
+		t.Cleanup(func() { _ = os.WriteFile(path, constants, 0o644) })
+
+		out.Reset()
+		assert.Error(t, run(context.Background(), []string{"verify", "-manifest", manifest, "-root", root}, &out))
+		assert.Contains(t, out.String(), "internal/constant/assets.go is out of date")
+	})
+}
+
+func TestDownloadFromDirectory(t *testing.T) {
+	mirror := t.TempDir()
+	for name, content := range map[string]string{
+		"@demo/ui@1.2.3/dist/app.js":  "console.log('demo')",
+		"@demo/ui@1.2.3/dist/app.css": "body{}",
+		"example.com/favicon.png":     "png",
+	} {
+		path := filepath.Join(mirror, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	root := t.TempDir()
+	manifest := writeTestManifest(t, root, "https://example.com/favicon.png", map[string]string{
+		"app.js":      sha256Hex([]byte("console.log('demo')")),
+		"app.css":     sha256Hex([]byte("body{}")),
+		"favicon.png": sha256Hex([]byte("png")),
+		"font.woff2":  sha256Hex([]byte("woff2")),
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, run(context.Background(), []string{"download", "-manifest", manifest, "-root", root, "-source", mirror}, &out))
+	require.NoError(t, run(context.Background(), []string{"verify", "-manifest", manifest, "-root", root}, &out))
+}
+
+func TestRunErrors(t *testing.T) {
+	var out bytes.Buffer
+	assert.ErrorIs(t, run(context.Background(), nil, &out), errUsage)
+	assert.Error(t, run(context.Background(), []string{"verify", "-manifest", filepath.Join(t.TempDir(), "missing.json")}, &out))
+
+	root := t.TempDir()
+	manifest := writeTestManifest(t, root, "https://example.com/favicon.png", nil)
+	assert.ErrorIs(t, run(context.Background(), []string{"unknown", "-manifest", manifest}, &out), errUsage)
+	assert.Error(t, run(context.Background(), []string{"download", "-manifest", manifest, "-root", root, "-source", t.TempDir()}, &out))
+}
+
+func TestRepositoryAssets(t *testing.T) {
+	m, err := readManifest("../../assets.json")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.NoError(t, verify("../..", m, &out), out.String())
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+)
+
+// Manifest lists the provider files embedded by the *emb packages.
+type Manifest struct {
+	Providers []Provider `json:"providers"`
+}
+
+// Provider describes the npm package a provider's assets are vendored from.
+type Provider struct {
+	Name    string `json:"name"`    // Prefix of the generated constants, e.g. "SwaggerUI"
+	Package string `json:"package"` // npm package name
+	Version string `json:"version"` // Pinned version
+	Dir     string `json:"dir"`     // Directory holding the asset files within the package
+	Embed   string `json:"embed"`   // Directory the files are written to, relative to the repository root
+	Files   []File `json:"files"`
+}
+
+// File is a vendored asset file.
+type File struct {
+	File    string `json:"file"`              // Path relative to the package assets directory and to Embed
+	URL     string `json:"url,omitempty"`     // Absolute URL for files that are not part of the package
+	Dynamic bool   `json:"dynamic,omitempty"` // Generated on the fly by the CDN, so no SRI hash is published
+	Local   bool   `json:"local,omitempty"`   // Kept in the repository instead of downloaded, such as self-hosted fonts
+	SHA256  string `json:"sha256"`            // Hex-encoded SHA-256 of the file content
+}
+
+func readManifest(path string) (*Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func writeManifest(path string, m *Manifest) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
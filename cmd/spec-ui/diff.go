@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oaswrap/spec-ui/diff"
+)
+
+type diffOptions struct {
+	Format         diff.Format // Output format
+	FailOnBreaking bool        // Fail when a change is breaking
+}
+
+func diffFiles(args []string, out io.Writer) error {
+	var opts diffOptions
+	var format string
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.StringVar(&format, "format", "text", "output format: text, markdown or json")
+	flags.BoolVar(&opts.FailOnBreaking, "fail-on-breaking", false, "fail when a change breaks clients of the old file")
+	files, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 2 {
+		return errors.New("usage: spec-ui diff [flags] old new")
+	}
+	opts.Format = diff.Format(format)
+
+	old, err := os.ReadFile(files[0])
+	if err != nil {
+		return err
+	}
+	new, err := os.ReadFile(files[1])
+	if err != nil {
+		return err
+	}
+	report, err := diff.Compare(old, new)
+	if err != nil {
+		return err
+	}
+
+	if err := diff.Write(out, opts.Format, report); err != nil {
+		return err
+	}
+	if n := len(report.Breaking()); n > 0 && opts.FailOnBreaking {
+		return fmt.Errorf("%d breaking %s", n, plural(n, "change", "changes"))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "v1.yaml")
+	require.NoError(t, os.WriteFile(old, []byte("openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    get:\n      responses: {200: {description: OK}}\n"), 0o644))
+	new := writeSpec(t, dir, "v2.json", "Pets")
+
+	t.Run("when listing the changes", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, run(context.Background(), []string{"diff", old, new}, &out))
+		assert.Equal(t, "Breaking changes (1):\n  /pets: path removed\n", out.String())
+	})
+	t.Run("when writing Markdown", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, run(context.Background(), []string{"diff", "-format", "markdown", new, old}, &out))
+		assert.Equal(t, "# API Changes\n\n## Non-breaking changes\n\n- `/pets`: path added\n", out.String())
+	})
+	t.Run("when failing on breaking changes", func(t *testing.T) {
+		err := run(context.Background(), []string{"diff", old, new, "-fail-on-breaking"}, io.Discard)
+		assert.EqualError(t, err, "1 breaking change")
+
+		require.NoError(t, run(context.Background(), []string{"diff", "-fail-on-breaking", new, old}, io.Discard))
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{name: "when a file is missing", args: []string{"diff", old}, err: "usage: spec-ui diff [flags] old new"},
+		{name: "when the file does not exist", args: []string{"diff", old, "missing.yaml"}, err: "missing.yaml"},
+		{name: "when the format is unknown", args: []string{"diff", "-format", "html", old, new}, err: `unknown diff output format "html"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(context.Background(), tt.args, io.Discard)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
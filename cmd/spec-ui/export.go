@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+)
+
+type exportOptions struct {
+	UI       string // UI provider name
+	Embed    bool   // Copy the UI assets into the output directory
+	Title    string // Documentation title
+	Inline   bool   // Embed the specification in the docs page
+	Validate bool   // Fail when the specification is invalid
+	Out      string // Output directory
+	HTML     string // Output file of a single-file export
+}
+
+func export(args []string, out io.Writer) error {
+	var opts exportOptions
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.StringVar(&opts.UI, "ui", "swaggerui", "UI provider: swaggerui, stoplight, redoc, scalar or rapidoc")
+	flags.BoolVar(&opts.Embed, "embed", false, "copy the UI assets into the output directory; the pages load nothing from other origins")
+	flags.StringVar(&opts.Title, "title", "", "documentation title")
+	flags.BoolVar(&opts.Inline, "inline-spec", false, "embed the specification in the docs page instead of loading it from its URL")
+	flags.BoolVar(&opts.Validate, "validate", false, "fail when the specification does not conform to its schema")
+	flags.StringVar(&opts.Out, "out", "site", "output directory")
+	flags.StringVar(&opts.HTML, "html", "", "write a single self-contained HTML file instead of a directory; implies -embed")
+	files, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errUsage
+	}
+
+	if opts.HTML != "" {
+		if len(files) != 1 {
+			return errors.New("-html exports a single file")
+		}
+		return exportHTML(files[0], opts, out)
+	}
+	if len(files) == 1 {
+		return exportFile(files[0], opts.Out, opts.Title, opts, out)
+	}
+
+	used := make(map[string]bool)
+	entries := make([]indexEntry, 0, len(files))
+	for _, file := range files {
+		slug := uniqueSlug(used, file)
+		title := opts.Title
+		if title == "" {
+			title = baseName(file)
+		}
+		if err := exportFile(file, filepath.Join(opts.Out, slug), title, opts, out); err != nil {
+			return err
+		}
+		entries = append(entries, indexEntry{URL: slug + "/", Title: title, File: filepath.Base(file)})
+	}
+
+	var buf bytes.Buffer
+	if err := indexTpl.Execute(&buf, entries); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(opts.Out, "index.html"), buf.Bytes(), 0o644)
+}
+
+func exportFile(file, dir, title string, opts exportOptions, out io.Writer) error {
+	if _, err := statFile(file); err != nil {
+		return err
+	}
+
+	specOpts, err := docOptions(file, title, opts.UI, opts.Embed, opts.Inline)
+	if err != nil {
+		return err
+	}
+	specOpts = append(specOpts, specui.WithSpecPath("/docs/openapi"+specExt(file)))
+	if opts.Validate {
+		specOpts = append(specOpts, specui.WithValidation(config.ValidationFail))
+	}
+
+	if err := specui.NewHandler(specOpts...).Export(dir); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	fmt.Fprintf(out, "%s: %s\n", file, filepath.Join(dir, "index.html"))
+	return nil
+}
+
+func exportHTML(file string, opts exportOptions, out io.Writer) error {
+	if _, err := statFile(file); err != nil {
+		return err
+	}
+
+	specOpts, err := docOptions(file, opts.Title, opts.UI, true, true)
+	if err != nil {
+		return err
+	}
+
+	if opts.Validate {
+		specOpts = append(specOpts, specui.WithValidation(config.ValidationFail))
+	}
+
+	var buf bytes.Buffer
+	if err := specui.NewHandler(specOpts...).ExportHTML(&buf); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if err := os.WriteFile(opts.HTML, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s: %s\n", file, opts.HTML)
+	return nil
+}
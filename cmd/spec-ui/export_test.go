@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	t.Run("when exporting a single file", func(t *testing.T) {
+		file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+		out := t.TempDir()
+
+		var log bytes.Buffer
+		require.NoError(t, run(context.Background(), []string{"export", file, "--ui", "redoc", "--embed", "--out", out}, &log))
+		assert.Contains(t, log.String(), filepath.Join(out, "index.html"))
+
+		srv := httptest.NewServer(http.FileServer(http.Dir(out)))
+		defer srv.Close()
+
+		resp, body := get(t, srv, "/")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, "./_assets/redoc.standalone.js")
+
+		resp, body = get(t, srv, "/openapi.json")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, `"title": "Pets"`)
+
+		resp, _ = get(t, srv, "/_assets/redoc.standalone.js")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("when exporting multiple files", func(t *testing.T) {
+		dir := t.TempDir()
+		orders := writeSpec(t, dir, "orders.yaml", "Orders")
+		users := writeSpec(t, dir, "users.json", "Users")
+		out := t.TempDir()
+
+		require.NoError(t, run(context.Background(), []string{"export", "-out", out, orders, users}, &bytes.Buffer{}))
+
+		srv := httptest.NewServer(http.FileServer(http.Dir(out)))
+		defer srv.Close()
+
+		_, body := get(t, srv, "/")
+		assert.Contains(t, body, `href="orders/"`)
+		assert.Contains(t, body, `href="users/"`)
+
+		_, body = get(t, srv, "/users/")
+		assert.Contains(t, body, "<title>users - Swagger UI</title>")
+
+		resp, body := get(t, srv, "/users/openapi.yaml")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, "title: Users")
+	})
+	t.Run("when exporting a single HTML file", func(t *testing.T) {
+		file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+		html := filepath.Join(t.TempDir(), "pets.html")
+
+		require.NoError(t, run(context.Background(), []string{"export", file, "--ui", "scalar", "--html", html}, &bytes.Buffer{}))
+
+		b, err := os.ReadFile(html)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `config.content = {"openapi":"3.0.3","info":{"title":"Pets"`)
+	})
+	t.Run("when exporting several files to a single HTML file", func(t *testing.T) {
+		file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+		err := run(context.Background(), []string{"export", "-html", "out.html", file, file}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "-html exports a single file")
+	})
+	t.Run("when validating an invalid file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "openapi.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("openapi: 3.0.3\ninfo:\n  title: Pets\npaths: {}\n"), 0o644))
+
+		err := run(context.Background(), []string{"export", file, "-validate", "-out", t.TempDir()}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, `/info (line 3): missing required property "version"`)
+		err = run(context.Background(), []string{"export", file, "-validate", "-html", filepath.Join(t.TempDir(), "pets.html")}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, `missing required property "version"`)
+		assert.NoError(t, run(context.Background(), []string{"export", file, "-out", t.TempDir()}, &bytes.Buffer{}))
+	})
+	t.Run("when the file does not exist", func(t *testing.T) {
+		err := run(context.Background(), []string{"export", "missing.yaml", "-out", t.TempDir()}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "missing.yaml")
+	})
+	t.Run("when no file is given", func(t *testing.T) {
+		assert.ErrorIs(t, run(context.Background(), []string{"export"}, &bytes.Buffer{}), errUsage)
+	})
+}
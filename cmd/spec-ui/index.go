@@ -0,0 +1,27 @@
+package main
+
+import "html/template"
+
+// indexEntry links to the docs of one file on the index page listing them.
+type indexEntry struct {
+	URL   string
+	Title string
+	File  string
+}
+
+var indexTpl = template.Must(template.New("index").Parse(`<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>API Documentation</title>
+</head>
+<body>
+  <h1>API Documentation</h1>
+  <ul>
+    {{- range . }}
+    <li><a href="{{ .URL }}">{{ .Title }}</a> ({{ .File }})</li>
+    {{- end }}
+  </ul>
+</body>
+</html>
+`))
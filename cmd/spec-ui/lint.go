@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/lint"
+)
+
+type lintOptions struct {
+	Format lint.Format   // Output format
+	FailOn lint.Severity // Lowest severity that makes the command fail
+	Rules  ruleFlags     // Severity overrides by rule name
+}
+
+// ruleFlags collects repeated -rule name=severity flags.
+type ruleFlags map[string]lint.Severity
+
+func (f ruleFlags) String() string {
+	pairs := make([]string, 0, len(f))
+	for name, s := range f {
+		pairs = append(pairs, name+"="+string(s))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f ruleFlags) Set(value string) error {
+	name, severity, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("expected name=severity, got %q", value)
+	}
+	s, err := lint.ParseSeverity(severity)
+	if err != nil {
+		return err
+	}
+	f[name] = s
+	return nil
+}
+
+func lintFiles(args []string, out io.Writer) error {
+	opts := lintOptions{Rules: ruleFlags{}}
+	var format, failOn string
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.StringVar(&format, "format", "text", "output format: text, json or sarif")
+	flags.StringVar(&failOn, "fail-on", "error", "lowest severity that fails the command: info, warning, error or off")
+	flags.Var(opts.Rules, "rule", "set the severity of a rule, e.g. operation-tags=error or path-kebab-case=off; repeatable")
+	files, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errUsage
+	}
+	opts.Format = lint.Format(format)
+	if opts.FailOn, err = lint.ParseSeverity(failOn); err != nil {
+		return err
+	}
+
+	reports := make([]*lint.Report, 0, len(files))
+	failed := 0
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		report, err := lint.Lint(b, lint.Config{File: file, Severity: opts.Rules})
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		reports = append(reports, report)
+		if opts.FailOn != lint.SeverityOff {
+			failed += report.Count(opts.FailOn)
+		}
+	}
+
+	if err := lint.Write(out, opts.Format, reports...); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d %s with severity %s or higher", failed, plural(failed, "finding", "findings"), opts.FailOn)
+	}
+	return nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+var bannerTpl = template.Must(template.New("banner").Parse(`<details id="spec-ui-lint" style="position:fixed;bottom:0;left:0;right:0;z-index:2147483647;max-height:40vh;overflow:auto;margin:0;padding:.5rem 1rem;background:#fff8c5;border-top:1px solid #d4a72c;color:#1f2328;font:14px/1.5 system-ui,sans-serif">
+<summary style="cursor:pointer;font-weight:600">{{ len .Findings }} lint {{ if eq (len .Findings) 1 }}finding{{ else }}findings{{ end }} in {{ .File }}</summary>
+<ul style="margin:.5rem 0;padding-left:1.5rem">
+{{ range .Findings }}<li>{{ .Severity }}: line {{ .Line }}: {{ .Message }} <code>{{ .Rule }}</code></li>
+{{ end }}</ul>
+</details>
+`))
+
+// bannerHTML renders the findings of report as a panel shown over the docs
+// page, or returns nil when there are none.
+func bannerHTML(report *lint.Report) []byte {
+	if report == nil || len(report.Findings) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := bannerTpl.Execute(&buf, report); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	file := writeSpec(t, dir, "openapi.yaml", "Pets")
+
+	t.Run("when findings are warnings", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, run(context.Background(), []string{"lint", file}, &out))
+		assert.Equal(t, file+":3:3: warning: /info: info has no description (info-description)\n", out.String())
+	})
+	t.Run("when failing on warnings", func(t *testing.T) {
+		err := run(context.Background(), []string{"lint", "-fail-on", "warning", file}, io.Discard)
+		assert.EqualError(t, err, "1 finding with severity warning or higher")
+	})
+	t.Run("when overriding severities", func(t *testing.T) {
+		err := run(context.Background(), []string{"lint", file, "-rule", "info-description=error"}, io.Discard)
+		assert.ErrorContains(t, err, "1 finding with severity error or higher")
+
+		var out bytes.Buffer
+		require.NoError(t, run(context.Background(), []string{"lint", file, "-rule", "info-description=off", "-fail-on", "info"}, &out))
+		assert.Empty(t, out.String())
+	})
+	t.Run("when writing SARIF", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, run(context.Background(), []string{"lint", "-format", "sarif", file, file}, &out))
+		var log struct {
+			Runs []struct {
+				Results []json.RawMessage `json:"results"`
+			} `json:"runs"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		require.Len(t, log.Runs, 1)
+		assert.Len(t, log.Runs[0].Results, 2)
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{name: "when no file is given", args: []string{"lint"}, err: errUsage.Error()},
+		{name: "when the file does not exist", args: []string{"lint", "missing.yaml"}, err: "missing.yaml"},
+		{name: "when the format is unknown", args: []string{"lint", "-format", "xml", file}, err: `unknown lint output format "xml"`},
+		{name: "when the severity is unknown", args: []string{"lint", "-fail-on", "fatal", file}, err: `unknown severity "fatal"`},
+		{name: "when a rule flag is invalid", args: []string{"lint", "-rule", "operation-tags", file}, err: "expected name=severity"},
+		{name: "when a rule is unknown", args: []string{"lint", "-rule", "tags=off", file}, err: `unknown lint rule "tags"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, run(context.Background(), tt.args, io.Discard), tt.err)
+		})
+	}
+}
+
+func TestServeLint(t *testing.T) {
+	file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+	s, err := newSite([]string{file}, serveOptions{UI: "redoc", Lint: true})
+	require.NoError(t, err)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	resp, body := get(t, srv, "/docs")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `1 lint finding in openapi.yaml`)
+	assert.Contains(t, body, "info has no description")
+
+	content := "openapi: 3.0.3\ninfo:\n  title: Pets\n  version: 1.0.0\n  description: Pets.\npaths: {}\n"
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	require.NoError(t, s.load(s.docs[0]))
+	_, body = get(t, srv, "/docs")
+	assert.NotContains(t, body, "spec-ui-lint", "the banner is hidden once the findings are fixed")
+}
@@ -0,0 +1,110 @@
+// Command spec-ui previews OpenAPI specification files in the browser.
+//
+// Usage:
+//
+//	spec-ui serve [flags] file...
+//	spec-ui export [flags] file...
+//	spec-ui lint [flags] file...
+//	spec-ui diff [flags] old new
+//
+// The serve command renders each file with the selected UI provider and
+// reloads open pages when a file changes. With a single file the docs are
+// served at /docs; with several files each one is served under a prefix
+// derived from its name, e.g. /orders/docs, and / lists them.
+//
+// The export command writes the same pages to a directory that any file
+// server can publish, see specui.Handler.Export. With several files each one
+// is exported to a subdirectory and index.html lists them. With -html, a
+// single file is exported as one HTML page, see specui.Handler.ExportHTML.
+//
+// Flags may be given before or after the files. The serve command accepts:
+//
+//	-ui string         UI provider: swaggerui, stoplight, redoc, scalar or rapidoc (default "swaggerui")
+//	-host string       host to listen on (default "localhost")
+//	-port int          port to listen on (default 8080)
+//	-embed             serve the UI assets from embedded files; no other origin is contacted
+//	-title string      documentation title
+//	-inline-spec       embed the specification in the docs page instead of loading it from its URL
+//	-validate          validate the specification and show the problems found instead of the UI
+//	-lint              show lint findings in a panel over the docs page
+//	-reload            reload open pages when a file changes (default true)
+//	-interval duration how often files are checked for changes (default 500ms)
+//
+// The export command accepts -ui, -embed, -title and -inline-spec, and:
+//
+//	-validate          fail when the specification does not conform to its schema
+//	-out string        output directory (default "site")
+//	-html string       write a single self-contained HTML file instead of a directory; implies -embed
+//
+// The lint command checks the files against the style rules of package lint
+// and fails when a finding has the -fail-on severity or higher. It accepts:
+//
+//	-format string     output format: text, json or sarif (default "text")
+//	-fail-on string    lowest severity that fails the command: info, warning, error or off (default "error")
+//	-rule name=level   set the severity of a rule, e.g. operation-tags=error or path-kebab-case=off; repeatable
+//
+// The diff command compares two versions of a file and lists the changes,
+// breaking ones first, see package diff. It accepts:
+//
+//	-format string     output format: text, markdown or json (default "text")
+//	-fail-on-breaking  fail when a change breaks clients of the old file
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var errUsage = errors.New("usage: spec-ui serve|export|lint|diff [flags] file...")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "spec-ui:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "serve":
+		return serve(ctx, args[1:], out)
+	case "export":
+		return export(args[1:], out)
+	case "lint":
+		return lintFiles(args[1:], out)
+	case "diff":
+		return diffFiles(args[1:], out)
+	default:
+		return errUsage
+	}
+}
+
+// parseArgs parses flags interleaved with positional arguments, so that
+// "spec-ui serve openapi.yaml -ui scalar" works as expected.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
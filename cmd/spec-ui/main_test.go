@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSpec(t *testing.T, dir, name, title string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	content := "openapi: 3.0.3\ninfo:\n  title: " + title + "\n  version: 1.0.0\npaths: {}\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func get(t *testing.T, srv *httptest.Server, path string) (*http.Response, string) {
+	t.Helper()
+	resp, err := srv.Client().Get(srv.URL + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(b)
+}
+
+func defaultOptions() serveOptions {
+	return serveOptions{UI: "swaggerui", Reload: true}
+}
+
+func TestServe(t *testing.T) {
+	file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+
+	tests := []struct {
+		name string
+		opts serveOptions
+	}{
+		{name: "when using the CDN", opts: defaultOptions()},
+		{name: "when embedding assets", opts: serveOptions{UI: "scalar", Embed: true, Reload: true}},
+		{name: "when reload is disabled", opts: serveOptions{UI: "redoc"}},
+		{name: "when inlining the specification", opts: serveOptions{UI: "stoplight", Inline: true, Reload: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSite([]string{file}, tt.opts)
+			require.NoError(t, err)
+			srv := httptest.NewServer(s)
+			defer srv.Close()
+
+			resp, body := get(t, srv, "/")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "/docs", resp.Request.URL.Path)
+			if tt.opts.Reload {
+				assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+			}
+			assert.Equal(t, tt.opts.Reload, strings.Contains(body, "EventSource"))
+			assert.Equal(t, tt.opts.Inline, strings.Contains(body, `"title":"Pets"`))
+			if tt.opts.Embed {
+				assert.Empty(t, asset.ExternalURLs("/docs", []byte(body)))
+			}
+
+			resp, body = get(t, srv, "/docs/openapi.yaml")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Contains(t, body, "title: Pets")
+
+			resp, _ = get(t, srv, "/missing")
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+	}
+}
+
+func TestServeValidation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("openapi: 3.0.3\ninfo:\n  title: Pets\npaths: {}\n"), 0o644))
+	s, err := newSite([]string{file}, serveOptions{UI: "redoc", Validate: true, Reload: true})
+	require.NoError(t, err)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	resp, body := get(t, srv, "/docs")
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Contains(t, body, "missing required property &#34;version&#34;")
+	assert.Contains(t, body, "EventSource", "the page reloads once the file is fixed")
+
+	writeSpec(t, filepath.Dir(file), "openapi.yaml", "Pets")
+	require.NoError(t, s.load(s.docs[0]))
+	resp, _ = get(t, srv, "/docs")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServeEmbeddedAssets(t *testing.T) {
+	file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+	s, err := newSite([]string{file}, serveOptions{UI: "redoc", Embed: true})
+	require.NoError(t, err)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	d := s.docs[0]
+	resp, _ := get(t, srv, d.handler.AssetsPath()+"/fonts/fonts.css")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServeMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	orders := writeSpec(t, dir, "Orders API.yaml", "Orders")
+	users := writeSpec(t, dir, "users.json", "Users")
+	users2 := writeSpec(t, t.TempDir(), "users.json", "Users v2")
+
+	s, err := newSite([]string{orders, users, users2}, defaultOptions())
+	require.NoError(t, err)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	_, body := get(t, srv, "/")
+	assert.Contains(t, body, `href="/orders-api/docs"`)
+	assert.Contains(t, body, `href="/users/docs"`)
+	assert.Contains(t, body, `href="/users-2/docs"`)
+
+	resp, body := get(t, srv, "/users-2/docs/openapi.json")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Contains(t, body, "Users v2")
+
+	_, body = get(t, srv, "/orders-api/docs")
+	assert.Contains(t, body, "<title>Orders API - Swagger UI</title>")
+	assert.Contains(t, body, "/orders-api/docs/openapi.yaml")
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/users/docs", nil)
+	require.NoError(t, err)
+	resp, err = srv.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	file := writeSpec(t, dir, "openapi.yaml", "Before")
+
+	s, err := newSite([]string{file}, defaultOptions())
+	require.NoError(t, err)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	_, body := get(t, srv, "/docs/openapi.yaml")
+	assert.Contains(t, body, "Before")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+eventsPath, nil)
+	require.NoError(t, err)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := bufio.NewReader(resp.Body)
+	line, err := events.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": connected\n", line)
+
+	writeSpec(t, dir, "openapi.yaml", "After, with a longer title")
+	s.poll()
+
+	var event bytes.Buffer
+	for !strings.HasSuffix(event.String(), "\n\n") {
+		line, err := events.ReadString('\n')
+		require.NoError(t, err)
+		event.WriteString(line)
+	}
+	assert.Equal(t, "\nevent: reload\ndata: /docs\n\n", event.String())
+
+	_, body = get(t, srv, "/docs/openapi.yaml")
+	assert.Contains(t, body, "After")
+}
+
+func TestRunErrors(t *testing.T) {
+	file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{name: "when no command is given", args: nil, err: errUsage.Error()},
+		{name: "when the command is unknown", args: []string{"preview", file}, err: errUsage.Error()},
+		{name: "when no file is given", args: []string{"serve", "-ui", "redoc"}, err: errUsage.Error()},
+		{name: "when the file does not exist", args: []string{"serve", "missing.yaml"}, err: "missing.yaml"},
+		{name: "when the UI is unknown", args: []string{"serve", file, "--ui", "elements"}, err: `unknown UI "elements"`},
+		{name: "when the port is invalid", args: []string{"serve", file, "--port", "-1"}, err: "invalid port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(context.Background(), tt.args, io.Discard)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestRunServe(t *testing.T) {
+	file := writeSpec(t, t.TempDir(), "openapi.yaml", "Pets")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var out bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, []string{"serve", file, "--port", "0", "--ui", "rapidoc", "--embed"}, &out)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// eventsPath is the server-sent events stream announcing file changes.
+const eventsPath = "/_spec-ui/events"
+
+var reloadTpl = template.Must(template.New("reload").Parse(`<script>
+new EventSource({{ .Events }}).addEventListener("reload", function (e) {
+  if (e.data === {{ .Docs }}) location.reload();
+});
+</script>
+`))
+
+// withReload injects a script into the page rendered by next that reloads it
+// when the file behind docsPath changes.
+func withReload(next http.Handler, docsPath string) http.Handler {
+	var script bytes.Buffer
+	if err := reloadTpl.Execute(&script, map[string]string{"Events": eventsPath, "Docs": docsPath}); err != nil {
+		panic(err)
+	}
+	return inject(next, script.Bytes())
+}
+
+// inject inserts snippet before the closing body tag of the page rendered by
+// next. The page is not cached, so that injected content stays current.
+func inject(next http.Handler, snippet []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The page is rewritten, so it is requested uncompressed and its
+		// validators do not apply to the result.
+		r = r.Clone(r.Context())
+		r.Header.Del("Accept-Encoding")
+		r.Header.Del("If-None-Match")
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+
+		body := rec.Body.Bytes()
+		if i := bytes.LastIndex(body, []byte("</body>")); i >= 0 {
+			body = append(body[:i:i], append(snippet, body[i:]...)...)
+		} else {
+			body = append(body, snippet...)
+		}
+
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.Header().Del("ETag")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(body)
+	})
+}
+
+// watch polls the files of s until ctx is done.
+func (s *site) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.poll()
+		}
+	}
+}
+
+// poll reloads the files that changed since the last poll and notifies the
+// pages showing them.
+func (s *site) poll() {
+	for _, d := range s.docs {
+		st, err := statFile(d.file)
+		if err != nil || st == d.stamp {
+			continue
+		}
+		d.stamp = st
+
+		if err := s.load(d); err != nil {
+			s.logger.Error("failed to reload OpenAPI specification", "file", d.file, "error", err)
+			continue
+		}
+		s.logger.Info("reloaded OpenAPI specification", "file", d.file)
+		s.events.publish(d.docsPath)
+	}
+}
+
+// broker fans out change events to the connected pages.
+type broker struct {
+	mu      sync.Mutex
+	clients map[chan string]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{clients: make(map[chan string]struct{})}
+}
+
+func (b *broker) subscribe() chan string {
+	ch := make(chan string, 8)
+	b.mu.Lock()
+	b.clients[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broker) unsubscribe(ch chan string) {
+	b.mu.Lock()
+	delete(b.clients, ch)
+	b.mu.Unlock()
+}
+
+func (b *broker) publish(docsPath string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.clients {
+		select {
+		case ch <- docsPath:
+		default: // The client is not keeping up; it reloads on a later event.
+		}
+	}
+}
+
+func (b *broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := b.subscribe()
+	defer b.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case docsPath := <-ch:
+			fmt.Fprintf(w, "event: reload\ndata: %s\n\n", docsPath)
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/logger"
+	"github.com/oaswrap/spec-ui/lint"
+)
+
+type serveOptions struct {
+	UI       string        // UI provider name
+	Host     string        // Host to listen on
+	Port     int           // Port to listen on
+	Embed    bool          // Serve the UI assets from embedded files
+	Title    string        // Documentation title
+	Inline   bool          // Embed the specification in the docs page
+	Validate bool          // Show validation problems instead of the UI
+	Lint     bool          // Show lint findings over the docs page
+	Reload   bool          // Reload open pages when a file changes
+	Interval time.Duration // How often files are checked for changes
+}
+
+func serve(ctx context.Context, args []string, out io.Writer) error {
+	var opts serveOptions
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.StringVar(&opts.UI, "ui", "swaggerui", "UI provider: swaggerui, stoplight, redoc, scalar or rapidoc")
+	flags.StringVar(&opts.Host, "host", "localhost", "host to listen on")
+	flags.IntVar(&opts.Port, "port", 8080, "port to listen on")
+	flags.BoolVar(&opts.Embed, "embed", false, "serve the UI assets from embedded files; no other origin is contacted")
+	flags.StringVar(&opts.Title, "title", "", "documentation title")
+	flags.BoolVar(&opts.Inline, "inline-spec", false, "embed the specification in the docs page instead of loading it from its URL")
+	flags.BoolVar(&opts.Validate, "validate", false, "validate the specification and show the problems found instead of the UI")
+	flags.BoolVar(&opts.Lint, "lint", false, "show lint findings in a panel over the docs page")
+	flags.BoolVar(&opts.Reload, "reload", true, "reload open pages when a file changes")
+	flags.DurationVar(&opts.Interval, "interval", 500*time.Millisecond, "how often files are checked for changes")
+	files, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errUsage
+	}
+
+	s, err := newSite(files, opts)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)))
+	if err != nil {
+		return err
+	}
+	base := "http://" + ln.Addr().String()
+	for _, d := range s.docs {
+		fmt.Fprintf(out, "%s: %s%s\n", d.file, base, d.docsPath)
+	}
+
+	if opts.Reload {
+		go s.watch(ctx, opts.Interval)
+	}
+
+	srv := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		// Requests share ctx so that open reload streams end on shutdown.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// site serves the documentation of one or more specification files.
+type site struct {
+	opts   serveOptions
+	docs   []*doc
+	events *broker
+	logger config.Logger
+}
+
+// doc serves the documentation of a single specification file.
+type doc struct {
+	file     string
+	title    string
+	docsPath string
+	specPath string
+
+	mu      sync.RWMutex
+	handler *specui.Handler
+	spec    http.Handler
+	banner  []byte
+	stamp   stamp
+}
+
+func newSite(files []string, opts serveOptions) (*site, error) {
+	s := &site{opts: opts, events: newBroker(), logger: &logger.Std{Verbose: true}}
+
+	prefixes := make(map[string]bool)
+	for _, file := range files {
+		st, err := statFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		d := &doc{file: file, title: opts.Title, stamp: st}
+		var prefix string
+		if len(files) > 1 {
+			prefix = "/" + uniqueSlug(prefixes, file)
+			if d.title == "" {
+				d.title = baseName(file)
+			}
+		}
+		d.docsPath = prefix + "/docs"
+		d.specPath = d.docsPath + "/openapi" + specExt(file)
+
+		if err := s.load(d); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		s.docs = append(s.docs, d)
+	}
+	return s, nil
+}
+
+// load creates the specui handler of d. Handlers cache the specification, so
+// a new one is created whenever the file changes.
+func (s *site) load(d *doc) error {
+	opts, err := docOptions(d.file, d.title, s.opts.UI, s.opts.Embed, s.opts.Inline)
+	if err != nil {
+		return err
+	}
+	opts = append(opts,
+		specui.WithDocsPath(d.docsPath),
+		specui.WithSpecPath(d.specPath),
+		specui.WithAssetsPath(d.docsPath+"/_assets"),
+		specui.WithCacheAge(0),
+		specui.WithLogger(s.logger),
+	)
+	if s.opts.Validate {
+		opts = append(opts, specui.WithValidation(config.ValidationPage))
+	}
+
+	h := specui.NewHandler(opts...)
+	if err := h.CheckConfig(); err != nil {
+		return err
+	}
+	if err := h.LoadSpec(context.Background()); err != nil {
+		return err
+	}
+
+	var banner []byte
+	if s.opts.Lint {
+		report, err := h.Lint(lint.Config{File: filepath.Base(d.file)})
+		if err != nil {
+			return err
+		}
+		banner = bannerHTML(report)
+	}
+
+	d.mu.Lock()
+	d.handler, d.spec, d.banner = h, h.Spec(), banner
+	d.mu.Unlock()
+	return nil
+}
+
+// docOptions returns the options shared by the serve and export commands.
+func docOptions(file, title, uiName string, embed, inline bool) ([]specui.Option, error) {
+	ui, err := uiOption(uiName, embed)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []specui.Option{specui.WithSpecFile(file), ui}
+	if title != "" {
+		opts = append(opts, specui.WithTitle(title))
+	}
+	if embed {
+		opts = append(opts, specui.WithOffline())
+	}
+	if inline {
+		opts = append(opts, specui.WithInlineSpec())
+	}
+	return opts, nil
+}
+
+func (s *site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch {
+	case r.URL.Path == eventsPath && s.opts.Reload:
+		s.events.ServeHTTP(w, r)
+		return
+	case r.URL.Path == "/" && len(s.docs) == 1:
+		http.Redirect(w, r, s.docs[0].docsPath, http.StatusFound)
+		return
+	case r.URL.Path == "/":
+		s.serveIndex(w)
+		return
+	}
+
+	for _, d := range s.docs {
+		if d.serve(w, r, s.opts.Reload) {
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// serve handles r when it targets d and reports whether it did.
+func (d *doc) serve(w http.ResponseWriter, r *http.Request, reload bool) bool {
+	d.mu.RLock()
+	h, spec, banner := d.handler, d.spec, d.banner
+	d.mu.RUnlock()
+
+	switch p := r.URL.Path; {
+	case p == d.docsPath:
+		docs := h.Docs()
+		if banner != nil {
+			docs = inject(docs, banner)
+		}
+		if reload {
+			docs = withReload(docs, d.docsPath)
+		}
+		docs.ServeHTTP(w, r)
+	case p == d.specPath:
+		spec.ServeHTTP(w, r)
+	case h.AssetsEnabled() && strings.HasPrefix(p, h.AssetsPath()+"/"):
+		h.Assets().ServeHTTP(w, r)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *site) serveIndex(w http.ResponseWriter) {
+	entries := make([]indexEntry, 0, len(s.docs))
+	for _, d := range s.docs {
+		entries = append(entries, indexEntry{URL: d.docsPath, Title: d.title, File: d.file})
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := indexTpl.Execute(w, entries); err != nil {
+		s.logger.Error("failed to render index", "error", err)
+	}
+}
+
+// uniqueSlug returns a URL path segment derived from the name of file that is
+// not used yet, and records it in used.
+func uniqueSlug(used map[string]bool, file string) string {
+	slug := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, baseName(file)), "-")
+	if slug == "" {
+		slug = "spec"
+	}
+
+	candidate := slug
+	for i := 2; used[candidate]; i++ {
+		candidate = slug + "-" + strconv.Itoa(i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func baseName(file string) string {
+	return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+}
+
+func specExt(file string) string {
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		return ".json"
+	}
+	return ".yaml"
+}
+
+// stamp identifies a version of a file.
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(file string) (stamp, error) {
+	fi, err := os.Stat(file)
+	if err != nil {
+		return stamp{}, err
+	}
+	if fi.IsDir() {
+		return stamp{}, fmt.Errorf("%s is a directory", file)
+	}
+	return stamp{modTime: fi.ModTime(), size: fi.Size()}, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/rapidoc"
+	"github.com/oaswrap/spec-ui/rapidocemb"
+	"github.com/oaswrap/spec-ui/redoc"
+	"github.com/oaswrap/spec-ui/redocemb"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/scalaremb"
+	"github.com/oaswrap/spec-ui/stoplight"
+	"github.com/oaswrap/spec-ui/stoplightemb"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+)
+
+// provider selects the CDN or embedded variant of a UI provider package.
+type provider struct {
+	cdn func() specui.Option
+	emb func() specui.Option
+}
+
+var providers = map[string]provider{
+	"swaggerui": {
+		cdn: func() specui.Option { return swaggerui.WithUI() },
+		emb: func() specui.Option { return swaggeruiemb.WithUI() },
+	},
+	"stoplight": {
+		cdn: func() specui.Option { return stoplight.WithUI() },
+		emb: func() specui.Option { return stoplightemb.WithUI() },
+	},
+	"redoc": {
+		cdn: func() specui.Option { return redoc.WithUI() },
+		emb: func() specui.Option { return redocemb.WithUI() },
+	},
+	"scalar": {
+		cdn: func() specui.Option { return scalar.WithUI() },
+		emb: func() specui.Option { return scalaremb.WithUI() },
+	},
+	"rapidoc": {
+		cdn: func() specui.Option { return rapidoc.WithUI() },
+		emb: func() specui.Option { return rapidocemb.WithUI() },
+	},
+}
+
+// uiOption returns the option selecting the named UI provider.
+func uiOption(name string, embed bool) (specui.Option, error) {
+	p, ok := providers[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(providers))
+		for name := range providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown UI %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	if embed {
+		return p.emb(), nil
+	}
+	return p.cdn(), nil
+}
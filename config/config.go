@@ -1,9 +1,16 @@
 package config
 
 import (
+	"context"
 	"embed"
 	"io/fs"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/oaswrap/spec-ui/redact"
+	"github.com/oaswrap/spec-ui/transform"
 )
 
 type Provider uint8
@@ -16,24 +23,165 @@ const (
 	ProviderRapiDoc
 )
 
+// String returns the name of the provider, as accepted by the -ui flag of the
+// spec-ui command.
+func (p Provider) String() string {
+	switch p {
+	case ProviderSwaggerUI:
+		return "swaggerui"
+	case ProviderStoplightElements:
+		return "stoplight"
+	case ProviderReDoc:
+		return "redoc"
+	case ProviderScalar:
+		return "scalar"
+	case ProviderRapiDoc:
+		return "rapidoc"
+	}
+	return "provider(" + strconv.Itoa(int(p)) + ")"
+}
+
+// Logger logs the events of the handler as a message followed by key-value
+// attributes. *slog.Logger implements it on Go 1.21 and later.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// ErrorHandler writes the response to r for an error of the handler with the
+// given HTTP status code. err may wrap details that should not be shown to
+// clients, see problem.Error.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, status int, err error)
+
+// Validation selects how the OpenAPI specification is validated when it is loaded.
+type Validation uint8
+
+const (
+	ValidationOff  Validation = iota // Do not validate the specification
+	ValidationWarn                   // Log the problems found and serve the specification
+	ValidationFail                   // Fail Handler.CheckConfig and serve an error instead of the specification
+	ValidationPage                   // Show the problems found on the docs page instead of the UI
+)
+
 // SpecGenerator is an interface for types that can generate OpenAPI specifications.
 type SpecGenerator interface {
 	MarshalYAML() ([]byte, error)
 	MarshalJSON() ([]byte, error)
 }
 
+// SpecSource loads the OpenAPI specification, see package source. Load
+// returns the document, its format ("json" or "yaml") and its OpenAPI or
+// Swagger version. The handler calls it on the first use of the
+// specification, and again after a failure that may not happen again.
+type SpecSource interface {
+	Load(ctx context.Context) (b []byte, format, version string, err error)
+}
+
+// SpecStore holds the published versions of the OpenAPI specification, see
+// package store. List returns the versions in the order they are offered to
+// readers, newest first, and Latest the version served on the spec path. Get
+// and Latest return an error wrapping store.ErrNotFound when there is no such
+// version.
+type SpecStore interface {
+	List(ctx context.Context) ([]string, error)
+	Get(ctx context.Context, version string) ([]byte, error)
+	Latest(ctx context.Context) (string, error)
+}
+
+// CDN configures where provider assets are loaded from when they are not embedded.
+type CDN struct {
+	// Template maps a provider package, version and file to a URL, e.g.
+	// "https://unpkg.com/{package}@{version}/{file}". When {file} is omitted
+	// the file path is appended to the template. Defaults to jsDelivr.
+	Template string
+	// AllowInsecure allows asset URLs that are not absolute https URLs.
+	AllowInsecure bool
+}
+
+// ProviderCDN overrides where a single provider loads its assets from.
+type ProviderCDN struct {
+	BaseURL string // Base URL holding the provider asset files, takes precedence over CDN.Template
+	Version string // Provider version, defaults to the version pinned by this module
+
+	// Integrity holds Subresource Integrity hashes keyed by asset file, e.g.
+	// {"redoc.standalone.js": "sha384-..."}. The hashes shipped for the pinned
+	// version are used when it is nil and Version is not changed.
+	Integrity map[string]string
+}
+
+// Changelog configures the page listing the changes from a previous version
+// of the OpenAPI specification, see package diff.
+type Changelog struct {
+	Path     string // Path to the changelog page, defaults to "/docs/changelog"
+	SpecFile string // Path to the previous specification file
+	SpecFS   fs.FS  // Filesystem holding SpecFile, the OS filesystem when nil
+}
+
+// Versions configures the pages of the versions of the OpenAPI specification
+// held by a store, see WithSpecStore.
+type Versions struct {
+	Path    string        // Path prefix of the version pages, defaults to "/docs/v"
+	Store   SpecStore     // Store of the published versions
+	Refresh time.Duration // How often the latest version is loaded again from Store, never when zero
+	Current string        // Version shown by the docs page, empty for the latest
+
+	// LatestDocsPath is the path to the docs page of the latest version,
+	// set with Current on the configuration of the pages of the versions.
+	LatestDocsPath string
+}
+
 // SpecUI holds the configuration for the OpenAPI UI.
 type SpecUI struct {
-	Title         string        // Title of the OpenAPI UI
-	CacheAge      int           // Cache age for the OpenAPI specification, defaults is 1 hour
-	DocsPath      string        // Path to the OpenAPI UI documentation, defaults are "/docs"
-	SpecPath      string        // Path to the OpenAPI specification, defaults are "/docs/openapi.json"
-	SpecFile      string        // Path to the OpenAPI specification file
-	SpecIOFS      fs.FS         // Filesystem for the OpenAPI specification
-	SpecEmbedFS   *embed.FS     // Embedded file system for the OpenAPI specification
-	SpecGenerator SpecGenerator // OpenAPI specification generator
-	AssetsPath    string        // Path to embedded assets, defaults to "/docs/_assets"
-	EmbedAssets   bool          // True when local UI assets are served from embedded files
+	Title          string     // Title of the OpenAPI UI
+	CacheAge       int        // Cache age for the OpenAPI specification, defaults is 1 hour
+	DocsPath       string     // Path to the OpenAPI UI documentation, defaults are "/docs"
+	SpecPath       string     // Path to the OpenAPI specification, defaults are "/docs/openapi.json"
+	SpecSource     SpecSource // Source the OpenAPI specification is loaded from
+	AssetsPath     string     // Path to embedded assets, defaults to "/docs/_assets"
+	EmbedAssets    bool       // True when local UI assets are served from embedded files
+	AssetsFS       fs.FS      // Embedded UI assets, set by the provider *emb packages
+	CDN            CDN        // CDN settings used when assets are not embedded
+	Offline        bool       // Reject configurations that make the docs page load resources from other origins
+	InlineAssets   bool       // Inline the embedded UI assets in the docs page instead of linking them
+	InlineSpec     bool       // Embed the OpenAPI specification in the docs page instead of loading it from SpecPath
+	Validation     Validation // What to do when the OpenAPI specification does not conform to its schema
+	ConvertSwagger bool       // Convert Swagger 2.0 specifications to OpenAPI 3.0 before serving them
+	Changelog      Changelog  // Changelog page comparing the specification with a previous version
+	Versions       Versions   // Pages of the versions of the specification held by a store
+
+	DowngradeOpenAPI31 bool       // Rewrite OpenAPI 3.1 specifications as OpenAPI 3.0 before serving them
+	DowngradeProviders []Provider // Providers the downgrade applies to, all of them when empty
+
+	Redaction      *redact.Config               // Internal-only content removed from the specification before it is served
+	SpecTransforms []transform.Transformer      // Transformers applied to the specification before it is served
+	SpecVariant    func(r *http.Request) string // Key of the request variant the specification is transformed for
+
+	Logger       Logger           // Logger of load failures, validation results and serve errors, see WithLogger
+	Observer     observe.Observer // Observer of served requests and specification loads, see WithObserver
+	ErrorHandler ErrorHandler     // Writer of error responses, problem.Write when nil
+
+	// SpecFile is the path to the OpenAPI specification file, read from
+	// SpecEmbedFS or SpecIOFS when one is set.
+	//
+	// Deprecated: Use SpecSource, e.g. source.File. NewHandler maps SpecFile,
+	// SpecIOFS, SpecEmbedFS and SpecGenerator to a source when SpecSource is
+	// nil: the generator first, then SpecFile in SpecEmbedFS, in SpecIOFS or
+	// on the OS filesystem.
+	SpecFile string
+	// SpecIOFS is the filesystem holding SpecFile.
+	//
+	// Deprecated: Use SpecSource with source.FS.
+	SpecIOFS fs.FS
+	// SpecEmbedFS is the embedded filesystem holding SpecFile.
+	//
+	// Deprecated: Use SpecSource with source.FS.
+	SpecEmbedFS *embed.FS
+	// SpecGenerator generates the OpenAPI specification.
+	//
+	// Deprecated: Use SpecSource with source.Generator.
+	SpecGenerator SpecGenerator
 
 	Provider          Provider           // Provider type
 	SwaggerUI         *SwaggerUI         // Swagger UI configuration
@@ -50,6 +198,12 @@ type SpecUI struct {
 	// AssetsHandlerFactory is set by With<Provider> options only when embedded
 	// assets are enabled for the selected provider.
 	AssetsHandlerFactory func(*SpecUI) http.Handler
+
+	// SpecLoader is set by NewHandler to the loader of the specification it
+	// serves, which returns the specification and an ETag that changes with
+	// it. Docs pages inlining the specification use it, so that they show the
+	// specification served at SpecPath, refreshed along with it.
+	SpecLoader func(ctx context.Context) (schema []byte, etag string, err error)
 }
 
 type SwaggerLayout string
@@ -69,6 +223,8 @@ type SwaggerUI struct {
 	// UIConfig specifies additional SwaggerUIBundle config object properties.
 	// See https://swagger.io/docs/open-source-tools/swagger-ui/usage/configuration/ for available options.
 	UIConfig map[string]string
+
+	CDN ProviderCDN // CDN overrides for the Swagger UI assets
 }
 
 type ElementLayout string
@@ -97,13 +253,15 @@ type StoplightElements struct {
 	Layout         ElementLayout // Layout type, e.g. "sidebar" or "responsive".
 	Router         ElementRouter // Router type.
 	Logo           string        // Logo URL to an image that displays as a small square logo next to the title, above the table of contents.
+	CDN            ProviderCDN   // CDN overrides for the Stoplight Elements assets
 }
 
 // ReDoc holds the configuration for the ReDoc.
 type ReDoc struct {
-	HideSearch          bool // Hide the search bar.
-	HideDownloadButtons bool // Hides the "Download" button for saving the API definition source file.
-	HideSchemaTitles    bool // Hides the schema titles in the documentation.
+	HideSearch          bool        // Hide the search bar.
+	HideDownloadButtons bool        // Hides the "Download" button for saving the API definition source file.
+	HideSchemaTitles    bool        // Hides the schema titles in the documentation.
+	CDN                 ProviderCDN // CDN overrides for the ReDoc assets
 }
 
 type ScalarLayout string
@@ -125,6 +283,7 @@ type Scalar struct {
 	DarkMode              bool         // Enable dark mode
 	Layout                ScalarLayout // Layout type e.g. "modern" or "classic"
 	Theme                 string       // Theme name, see https://guides.scalar.com/scalar/scalar-api-references/themes for available themes
+	CDN                   ProviderCDN  // CDN overrides for the Scalar assets
 }
 
 type RapiDocLayout string
@@ -171,4 +330,5 @@ type RapiDoc struct {
 	HideAdvancedSearch bool               // Hide the advanced search bar
 	HideTryIt          bool               // Hide the "Try" feature
 	Logo               string             // Logo URL
+	CDN                ProviderCDN        // CDN overrides for the RapiDoc assets
 }
@@ -0,0 +1,149 @@
+package specui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/oaswrap/spec-ui/internal/page"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/observe"
+)
+
+// Content is a response of the handler rendered ahead of requests, for
+// servers that do not use net/http, such as fasthttp. Its fields are shared
+// between requests and must not be modified.
+type Content struct {
+	Status int               // HTTP status code
+	Header http.Header       // Response headers, such as Content-Type and Cache-Control
+	Body   []byte            // Response body
+	Kind   observe.RouteKind // Kind of the route serving the content, see ObserveRequest
+	Gzip   *Content          // Response to clients accepting gzip, nil when the route does not compress it
+}
+
+// Negotiate returns the response to a request with the If-None-Match and
+// Accept-Encoding headers given: 304 Not Modified when the client already has
+// the content, see its ETag header, Gzip when the client accepts it, or c.
+func (c *Content) Negotiate(ifNoneMatch, acceptEncoding string) *Content {
+	if etag := c.Header.Get("ETag"); etag != "" && ifNoneMatch != "" && page.Matches(ifNoneMatch, etag) {
+		header := http.Header{}
+		for _, key := range []string{"Cache-Control", "ETag", "Vary"} {
+			if values := c.Header.Values(key); len(values) > 0 {
+				header[key] = values
+			}
+		}
+		return &Content{Status: http.StatusNotModified, Header: header, Kind: c.Kind}
+	}
+	if c.Gzip != nil && page.AcceptsGzip(acceptEncoding) {
+		return c.Gzip
+	}
+	return c
+}
+
+// contentCache holds the rendered responses of the handler by path.
+type contentCache struct {
+	mu      sync.Mutex
+	content map[string]*Content
+}
+
+// Content returns the response of the handler to a GET request for path. The
+// response is rendered with the net/http handlers on the first call, so it
+// carries the same headers, and cached; error responses for paths under the
+// embedded assets prefix, and server error responses, are rendered on each
+// call. ok is false when path is not one of the routes of the handler, or
+// when the response depends on the request or changes over time, as the
+// specification does when WithSpecVariant is configured or when its source is
+// refreshed, see source.Refresher, along with the docs page inlining it, and
+// as the responses under VersionsPath do: the list of the versions changes
+// when versions are published, and the pages of a version are served as long
+// as the store lists it.
+//
+// Responses that vary with Accept-Encoding are rendered a second time for
+// clients accepting gzip, see Content.Negotiate.
+//
+// Rendering is not reported to the observer configured with WithObserver:
+// servers report the requests they answer with the content, see
+// ObserveRequest.
+func (h *Handler) Content(path string) (c *Content, ok bool) {
+	switch {
+	case path == h.SpecPath() && (h.cfg.SpecVariant != nil || spec.Refreshes(h.cfg.SpecSource)):
+		return nil, false
+	case path == h.DocsPath() && h.cfg.InlineSpec && spec.Refreshes(h.cfg.SpecSource):
+		return nil, false
+	case h.isVersionsPath(path):
+		return nil, false
+	}
+	route, ok := h.route(path)
+	if !ok {
+		return nil, false
+	}
+
+	h.contents.mu.Lock()
+	c = h.contents.content[path]
+	h.contents.mu.Unlock()
+	if c != nil {
+		return c, true
+	}
+
+	c = render(route, path, "")
+	if c.Status == http.StatusOK && varies(c.Header, "Accept-Encoding") {
+		if gz := render(route, path, "gzip"); gz.Header.Get("Content-Encoding") == "gzip" {
+			c.Gzip = gz
+		}
+	}
+	switch {
+	case route.Prefix && c.Status != http.StatusOK:
+		// Unknown asset paths are not cached, since clients choose them.
+		return c, true
+	case c.Status >= http.StatusInternalServerError:
+		// The specification could not be loaded, or the docs page rendered,
+		// this time; it may be next time.
+		return c, true
+	}
+
+	h.contents.mu.Lock()
+	defer h.contents.mu.Unlock()
+	if cached := h.contents.content[path]; cached != nil {
+		return cached, true
+	}
+	if h.contents.content == nil {
+		h.contents.content = make(map[string]*Content)
+	}
+	h.contents.content[path] = c
+	return c, true
+}
+
+// render returns the response of route to a GET request for path, sent with
+// the Accept-Encoding header acceptEncoding when it is not empty.
+func render(route Route, path, acceptEncoding string) *Content {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	route.Handler.ServeHTTP(rec, req)
+	return &Content{Status: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes(), Kind: route.Kind}
+}
+
+// varies reports whether the Vary header of a response lists key.
+func varies(header http.Header, key string) bool {
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// route returns the route serving path.
+func (h *Handler) route(path string) (Route, bool) {
+	for _, r := range h.routes() {
+		if path == r.Path || r.Prefix && strings.HasPrefix(path, r.Path+"/") {
+			return r, true
+		}
+	}
+	return Route{}, false
+}
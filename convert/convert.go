@@ -0,0 +1,309 @@
+// Package convert converts Swagger 2.0 and OpenAPI 3.1 documents to OpenAPI
+// 3.0, for renderers that support it best.
+//
+// The conversion of Swagger 2.0 documents moves definitions, parameters, responses and security
+// definitions to components, builds servers from host, basePath and schemes,
+// and turns body and form parameters into request bodies using the consumes
+// and produces media types. Schemas are kept as written, with their
+// references rewritten and the Swagger extensions that OpenAPI 3.0 replaced,
+// such as x-nullable, converted. OpenAPI 3.1 documents keep their structure;
+// their schemas are rewritten with the OpenAPI 3.0 keywords, such as nullable
+// for type arrays that include "null".
+//
+// Constructs that have no OpenAPI 3.0 equivalent are dropped and reported as
+// warnings. Warnings are validate.Error values locating the construct in the
+// Swagger document, so that they are reported with validation problems.
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/transform"
+	"github.com/oaswrap/spec-ui/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the OpenAPI version of converted documents.
+const Version = "3.0.3"
+
+// ErrNotSwagger2 is returned when a document is not a Swagger 2.0 document.
+var ErrNotSwagger2 = errors.New("not a Swagger 2.0 document")
+
+// Result is a converted document.
+type Result struct {
+	Document []byte          // OpenAPI 3.0 document in YAML
+	Warnings validate.Errors // Constructs that could not be converted
+}
+
+// IsSwagger2 reports whether b is a Swagger 2.0 document in JSON or YAML.
+func IsSwagger2(b []byte) bool {
+	root, err := parse(b)
+	return err == nil && isSwagger2(root)
+}
+
+// Swagger2 converts a Swagger 2.0 document in JSON or YAML to OpenAPI 3.0. It
+// returns ErrNotSwagger2 when b is another document.
+func Swagger2(b []byte) (*Result, error) {
+	root, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if !isSwagger2(root) {
+		return nil, ErrNotSwagger2
+	}
+
+	c := newConverter(root)
+	return c.encode(c.document())
+}
+
+// TransformSwagger2 is a transform.Transformer that converts Swagger 2.0
+// documents to OpenAPI 3.0 and leaves other documents unchanged.
+func TransformSwagger2(doc *transform.Document, _ string) error {
+	if !isSwagger2(doc.Root) {
+		return nil
+	}
+	c := newConverter(doc.Root)
+	doc.Root = c.document()
+	doc.Warnings = append(doc.Warnings, c.warnings...)
+	return nil
+}
+
+// encode returns the result made of the converted root node doc.
+func (c *converter) encode(doc *yaml.Node) (*Result, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return &Result{Document: buf.Bytes(), Warnings: c.warnings}, nil
+}
+
+func parse(b []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, errors.New("failed to parse OpenAPI specification: document is empty")
+	}
+	return doc.Content[0], nil
+}
+
+func isSwagger2(root *yaml.Node) bool {
+	if root.Kind != yaml.MappingNode {
+		return false
+	}
+	v := get(root, "swagger")
+	return v != nil && v.Kind == yaml.ScalarNode && v.Value == "2.0"
+}
+
+type converter struct {
+	root     *yaml.Node
+	consumes []string // Global request media types
+	produces []string // Global response media types
+	warnings validate.Errors
+}
+
+func newConverter(root *yaml.Node) *converter {
+	return &converter{
+		root:     root,
+		consumes: strs(get(root, "consumes")),
+		produces: strs(get(root, "produces")),
+	}
+}
+
+func (c *converter) warn(n *yaml.Node, ptr, format string, args ...any) {
+	e := validate.Error{Pointer: ptr, Message: fmt.Sprintf(format, args...)}
+	if n != nil {
+		e.Line, e.Column = n.Line, n.Column
+	}
+	c.warnings = append(c.warnings, e)
+}
+
+// document returns the converted root node.
+func (c *converter) document() *yaml.Node {
+	out := mapping()
+	set(out, "openapi", str(Version))
+	if info := get(c.root, "info"); info != nil {
+		set(out, "info", clone(info))
+	}
+	if servers := c.servers(); servers != nil {
+		set(out, "servers", servers)
+	}
+
+	for i := 0; i+1 < len(c.root.Content); i += 2 {
+		key, value := c.root.Content[i].Value, c.root.Content[i+1]
+		switch key {
+		case "swagger", "info", "host", "basePath", "schemes", "consumes", "produces",
+			"definitions", "parameters", "responses", "securityDefinitions":
+		case "paths":
+			set(out, "paths", c.paths(value))
+			if components := c.components(); components != nil {
+				set(out, "components", components)
+			}
+		default:
+			set(out, key, clone(value))
+		}
+	}
+	if get(out, "paths") == nil {
+		if components := c.components(); components != nil {
+			set(out, "components", components)
+		}
+	}
+	return out
+}
+
+// servers builds the server list from host, basePath and schemes.
+func (c *converter) servers() *yaml.Node {
+	host, basePath := get(c.root, "host"), get(c.root, "basePath")
+	if host == nil && basePath == nil {
+		return nil
+	}
+	base := ""
+	if basePath != nil {
+		base = strings.TrimSuffix(basePath.Value, "/")
+	}
+
+	var urls []string
+	switch schemes := strs(get(c.root, "schemes")); {
+	case host == nil:
+		urls = []string{base}
+	case len(schemes) == 0:
+		// A protocol-relative URL uses the scheme the document is served with.
+		urls = []string{"//" + host.Value + base}
+	default:
+		for _, scheme := range schemes {
+			urls = append(urls, scheme+"://"+host.Value+base)
+		}
+	}
+
+	servers := sequence()
+	for _, u := range urls {
+		if u == "" {
+			u = "/"
+		}
+		server := mapping()
+		set(server, "url", str(u))
+		servers.Content = append(servers.Content, server)
+	}
+	return servers
+}
+
+// components moves the reusable objects of the document to components.
+func (c *converter) components() *yaml.Node {
+	out := mapping()
+	if defs := get(c.root, "definitions"); defs != nil {
+		schemas := mapping()
+		eachPair(defs, func(name string, schema *yaml.Node) {
+			set(schemas, name, c.schema(schema, pointer("definitions", name)))
+		})
+		set(out, "schemas", schemas)
+	}
+	if responses := get(c.root, "responses"); responses != nil {
+		converted := mapping()
+		eachPair(responses, func(name string, r *yaml.Node) {
+			set(converted, name, c.response(r, pointer("responses", name), c.produces))
+		})
+		set(out, "responses", converted)
+	}
+	if params := get(c.root, "parameters"); params != nil {
+		converted, bodies := mapping(), mapping()
+		eachPair(params, func(name string, p *yaml.Node) {
+			ptr := pointer("parameters", name)
+			switch in(p) {
+			case "body":
+				set(bodies, name, c.requestBody(p, ptr, c.consumes))
+			case "formData":
+				// Form parameters are merged into the request body of the
+				// operations that reference them.
+			default:
+				set(converted, name, c.parameter(p, ptr))
+			}
+		})
+		if len(converted.Content) > 0 {
+			set(out, "parameters", converted)
+		}
+		if len(bodies.Content) > 0 {
+			set(out, "requestBodies", bodies)
+		}
+	}
+	if defs := get(c.root, "securityDefinitions"); defs != nil {
+		schemes := mapping()
+		eachPair(defs, func(name string, s *yaml.Node) {
+			set(schemes, name, c.securityScheme(s, pointer("securityDefinitions", name)))
+		})
+		set(out, "securitySchemes", schemes)
+	}
+	if len(out.Content) == 0 {
+		return nil
+	}
+	return out
+}
+
+// securityScheme converts a security definition.
+func (c *converter) securityScheme(s *yaml.Node, ptr string) *yaml.Node {
+	out := mapping()
+	switch typ := get(s, "type"); {
+	case typ != nil && typ.Value == "basic":
+		set(out, "type", str("http"))
+		set(out, "scheme", str("basic"))
+	case typ != nil && typ.Value == "oauth2":
+		set(out, "type", str("oauth2"))
+		flow := mapping()
+		name := ""
+		switch f := get(s, "flow"); {
+		case f == nil:
+			c.warn(s, ptr, "oauth2 security definition has no flow")
+		case f.Value == "implicit", f.Value == "password":
+			name = f.Value
+		case f.Value == "application":
+			name = "clientCredentials"
+		case f.Value == "accessCode":
+			name = "authorizationCode"
+		default:
+			c.warn(f, ptr+"/flow", "unknown oauth2 flow %q", f.Value)
+		}
+		for _, key := range []string{"authorizationUrl", "tokenUrl"} {
+			if v := get(s, key); v != nil {
+				set(flow, key, clone(v))
+			}
+		}
+		scopes := get(s, "scopes")
+		if scopes == nil {
+			scopes = mapping()
+		}
+		set(flow, "scopes", clone(scopes))
+		flows := mapping()
+		if name != "" {
+			set(flows, name, flow)
+		}
+		set(out, "flows", flows)
+	case typ != nil:
+		set(out, "type", clone(typ))
+	}
+	eachPair(s, func(key string, v *yaml.Node) {
+		switch key {
+		case "type", "flow", "authorizationUrl", "tokenUrl", "scopes":
+		default:
+			set(out, key, clone(v))
+		}
+	})
+	return out
+}
+
+// pointer returns the JSON pointer made of tokens.
+func pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(escape(t))
+	}
+	return b.String()
+}
@@ -0,0 +1,155 @@
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/convert"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// lookup returns the value at the slash-separated path in doc.
+func lookup(t *testing.T, doc map[string]any, path string) any {
+	t.Helper()
+	var v any = doc
+	for _, key := range strings.Split(path, "/") {
+		switch node := v.(type) {
+		case map[string]any:
+			v = node[strings.ReplaceAll(key, "~1", "/")]
+		case []any:
+			i := int(key[0] - '0')
+			require.Less(t, i, len(node), path)
+			v = node[i]
+		default:
+			t.Fatalf("%s: no value at %q", path, key)
+		}
+	}
+	return v
+}
+
+func convertDoc(t *testing.T, src string) (map[string]any, validate.Errors) {
+	t.Helper()
+	result, err := convert.Swagger2([]byte(src))
+	require.NoError(t, err)
+
+	problems, err := validate.Document(result.Document)
+	require.NoError(t, err)
+	assert.Empty(t, problems, "converted documents are valid OpenAPI 3.0")
+
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(result.Document, &doc))
+	return doc, result.Warnings
+}
+
+func TestSwagger2(t *testing.T) {
+	b, err := testdata.FS.ReadFile("swagger.yaml")
+	require.NoError(t, err)
+	doc, warnings := convertDoc(t, string(b))
+	assert.Empty(t, warnings)
+
+	tests := []struct {
+		path string
+		want any
+	}{
+		{path: "openapi", want: "3.0.3"},
+		{path: "servers", want: []any{
+			map[string]any{"url": "https://petstore.swagger.io/v2"},
+			map[string]any{"url": "http://petstore.swagger.io/v2"},
+		}},
+		{path: "paths/~1pet/post/requestBody", want: map[string]any{"$ref": "#/components/requestBodies/PetBody"}},
+		{path: "components/requestBodies/PetBody/content/application~1json/schema/$ref", want: "#/components/schemas/Pet"},
+		{path: "paths/~1pet~1findByStatus/get/parameters/0/style", want: "form"},
+		{path: "paths/~1pet~1findByStatus/get/parameters/0/explode", want: true},
+		{path: "paths/~1pet~1findByStatus/get/parameters/0/schema/items/enum", want: []any{"available", "pending", "sold"}},
+		{path: "paths/~1pet~1findByStatus/get/parameters/1", want: map[string]any{"$ref": "#/components/parameters/Limit"}},
+		{path: "paths/~1pet~1findByStatus/get/responses/200/headers/X-Rate-Limit/schema", want: map[string]any{"type": "integer", "format": "int32"}},
+		{path: "paths/~1pet~1findByStatus/get/responses/200/content/application~1xml/schema/items/$ref", want: "#/components/schemas/Pet"},
+		{path: "paths/~1pet~1findByStatus/get/responses/200/content/application~1json/example/0/name", want: "doggie"},
+		{path: "paths/~1pet~1findByStatus/get/responses/400/$ref", want: "#/components/responses/BadRequest"},
+		{path: "paths/~1pet~1{petId}/parameters/0/schema/format", want: "int64"},
+		{path: "paths/~1pet~1{petId}/get/responses/200/content", want: map[string]any{
+			"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Pet"}},
+		}},
+		{path: "paths/~1pet~1{petId}/post/parameters", want: nil},
+		{path: "paths/~1pet~1{petId}/post/requestBody/required", want: true},
+		{path: "paths/~1pet~1{petId}/post/requestBody/content/application~1x-www-form-urlencoded/schema/required", want: []any{"status"}},
+		{path: "paths/~1pet~1{petId}~1uploadImage/post/requestBody/content/multipart~1form-data/schema/properties/file", want: map[string]any{"type": "string", "format": "binary"}},
+		{path: "components/schemas/Pet/discriminator", want: map[string]any{"propertyName": "petType"}},
+		{path: "components/schemas/Pet/properties/nickname", want: map[string]any{"type": "string", "nullable": true}},
+		{path: "components/securitySchemes/basic", want: map[string]any{"type": "http", "scheme": "basic"}},
+		{path: "components/securitySchemes/petstore_auth/flows/implicit/authorizationUrl", want: "https://petstore.swagger.io/oauth/authorize"},
+		{path: "components/securitySchemes/api_key/in", want: "header"},
+	}
+	for _, tt := range tests {
+		t.Run("when converting "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, lookup(t, doc, tt.path))
+		})
+	}
+}
+
+func TestSwagger2Servers(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want any
+	}{
+		{name: "when there is no host", doc: "basePath: /v1\n", want: []any{map[string]any{"url": "/v1"}}},
+		{name: "when there are no schemes", doc: "host: api.example.com\n", want: []any{map[string]any{"url": "//api.example.com"}}},
+		{name: "when the base path is the root", doc: "basePath: /\n", want: []any{map[string]any{"url": "/"}}},
+		{name: "when there is neither", doc: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, _ := convertDoc(t, "swagger: \"2.0\"\ninfo: {title: T, version: \"1\"}\n"+tt.doc+"paths: {}\n")
+			assert.Equal(t, tt.want, doc["servers"])
+		})
+	}
+}
+
+func TestSwagger2Warnings(t *testing.T) {
+	src := `swagger: "2.0"
+info: {title: T, version: "1"}
+paths:
+  /items:
+    get:
+      schemes: [wss]
+      parameters:
+        - {name: ids, in: header, type: array, items: {type: string}, collectionFormat: pipes}
+        - {name: tags, in: query, type: array, items: {type: string}, collectionFormat: tsv}
+      responses: {200: {description: OK}}
+    post:
+      consumes: [application/x-www-form-urlencoded]
+      parameters:
+        - {name: file, in: formData, type: file}
+      responses: {200: {description: OK}}
+securityDefinitions:
+  oauth: {type: oauth2, flow: device, tokenUrl: "https://example.com/token"}
+`
+	_, warnings := convertDoc(t, src)
+	assert.Equal(t, validate.Errors{
+		{Pointer: "/paths/~1items/get/schemes", Line: 6, Column: 16, Message: "operation schemes are not converted: OpenAPI 3.0 servers apply to the whole URL"},
+		{Pointer: "/paths/~1items/get/parameters/0/collectionFormat", Line: 8, Column: 89, Message: `collectionFormat "pipes" of header parameter "ids" has no OpenAPI 3.0 equivalent`},
+		{Pointer: "/paths/~1items/get/parameters/1/collectionFormat", Line: 9, Column: 89, Message: `collectionFormat "tsv" of query parameter "tags" has no OpenAPI 3.0 equivalent`},
+		{Pointer: "/paths/~1items/post/parameters/0", Line: 14, Column: 11, Message: "file parameters require multipart/form-data, which the operation does not consume"},
+		{Pointer: "/securityDefinitions/oauth/flow", Line: 17, Column: 31, Message: `unknown oauth2 flow "device"`},
+	}, warnings)
+}
+
+func TestSwagger2JSON(t *testing.T) {
+	doc, _ := convertDoc(t, `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/a": {"get": {"responses": {"200": {"description": "OK", "schema": {"type": "string"}}}}}}}`)
+	assert.Equal(t, map[string]any{"type": "string"}, lookup(t, doc, "paths/~1a/get/responses/200/content/application~1json/schema"))
+}
+
+func TestSwagger2Errors(t *testing.T) {
+	_, err := convert.Swagger2([]byte("openapi: 3.0.3\n"))
+	assert.ErrorIs(t, err, convert.ErrNotSwagger2)
+	_, err = convert.Swagger2([]byte("swagger: [2.0"))
+	assert.Error(t, err)
+
+	assert.True(t, convert.IsSwagger2([]byte(`{"swagger": "2.0"}`)))
+	assert.False(t, convert.IsSwagger2([]byte("openapi: 3.1.0")))
+}
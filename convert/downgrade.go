@@ -0,0 +1,317 @@
+package convert
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/transform"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotOpenAPI31 is returned when a document is not an OpenAPI 3.1 document.
+var ErrNotOpenAPI31 = errors.New("not an OpenAPI 3.1 document")
+
+// unsupportedKeywords are the JSON Schema keywords of OpenAPI 3.1 schemas that
+// OpenAPI 3.0 has no equivalent for.
+var unsupportedKeywords = []string{
+	"$id", "$schema", "$anchor", "$dynamicAnchor", "$dynamicRef", "$defs", "$comment", "$vocabulary",
+	"if", "then", "else", "dependentSchemas", "dependentRequired", "prefixItems", "contains",
+	"minContains", "maxContains", "patternProperties", "propertyNames", "unevaluatedItems",
+	"unevaluatedProperties", "contentSchema",
+}
+
+// IsOpenAPI31 reports whether b is an OpenAPI 3.1 document in JSON or YAML.
+func IsOpenAPI31(b []byte) bool {
+	root, err := parse(b)
+	return err == nil && isOpenAPI31(root)
+}
+
+// OpenAPI31 rewrites an OpenAPI 3.1 document in JSON or YAML as OpenAPI 3.0,
+// for renderers that do not support 3.1 schemas. Type arrays with "null"
+// become nullable, const becomes a single-value enum and examples become
+// example. It returns ErrNotOpenAPI31 when b is another document.
+func OpenAPI31(b []byte) (*Result, error) {
+	root, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if !isOpenAPI31(root) {
+		return nil, ErrNotOpenAPI31
+	}
+
+	c := &converter{root: root}
+	return c.encode(c.downgrade())
+}
+
+// TransformOpenAPI31 is a transform.Transformer that rewrites OpenAPI 3.1
+// documents as OpenAPI 3.0 and leaves other documents unchanged.
+func TransformOpenAPI31(doc *transform.Document, _ string) error {
+	if !isOpenAPI31(doc.Root) {
+		return nil
+	}
+	c := &converter{root: doc.Root}
+	doc.Root = c.downgrade()
+	doc.Warnings = append(doc.Warnings, c.warnings...)
+	return nil
+}
+
+func isOpenAPI31(root *yaml.Node) bool {
+	if root.Kind != yaml.MappingNode {
+		return false
+	}
+	v := get(root, "openapi")
+	return v != nil && v.Kind == yaml.ScalarNode && (v.Value == "3.1" || strings.HasPrefix(v.Value, "3.1."))
+}
+
+// downgrade returns a copy of the root node rewritten for OpenAPI 3.0.
+func (c *converter) downgrade() *yaml.Node {
+	out := clone(c.root)
+	set(out, "openapi", str(Version))
+	c.drop(out, "", "webhooks", "jsonSchemaDialect")
+	if info := get(out, "info"); info != nil {
+		c.drop(info, "/info", "summary")
+		if license := get(info, "license"); license != nil {
+			c.drop(license, "/info/license", "identifier")
+		}
+	}
+	if get(out, "paths") == nil {
+		set(out, "paths", mapping())
+	}
+
+	eachPair(get(out, "paths"), func(path string, item *yaml.Node) {
+		c.downgradePathItem(item, pointer("paths", path))
+	})
+
+	components := get(out, "components")
+	c.drop(components, "/components", "pathItems")
+	c.downgradeMap(get(components, "schemas"), "/components/schemas", func(n *yaml.Node, ptr string) *yaml.Node {
+		return c.downgradeSchema(n, ptr)
+	})
+	c.downgradeMap(get(components, "parameters"), "/components/parameters", c.downgradeParameter)
+	c.downgradeMap(get(components, "headers"), "/components/headers", c.downgradeParameter)
+	c.downgradeMap(get(components, "requestBodies"), "/components/requestBodies", c.downgradeBody)
+	c.downgradeMap(get(components, "responses"), "/components/responses", c.downgradeResponse)
+	c.downgradeMap(get(components, "callbacks"), "/components/callbacks", c.downgradeCallback)
+	return out
+}
+
+// downgradeMap replaces each value of the mapping m with fn applied to it.
+func (c *converter) downgradeMap(m *yaml.Node, ptr string, fn func(n *yaml.Node, ptr string) *yaml.Node) {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		m.Content[i+1] = fn(m.Content[i+1], ptr+"/"+escape(m.Content[i].Value))
+	}
+}
+
+func (c *converter) downgradePathItem(item *yaml.Node, ptr string) {
+	c.downgradeList(get(item, "parameters"), ptr+"/parameters")
+	for _, method := range append(methods, "trace") {
+		op := get(item, method)
+		if op == nil {
+			continue
+		}
+		opPtr := ptr + "/" + method
+		c.downgradeList(get(op, "parameters"), opPtr+"/parameters")
+		if body := get(op, "requestBody"); body != nil {
+			set(op, "requestBody", c.downgradeBody(body, opPtr+"/requestBody"))
+		}
+		if responses := get(op, "responses"); responses != nil {
+			c.downgradeMap(responses, opPtr+"/responses", c.downgradeResponse)
+		} else {
+			c.warn(op, opPtr, "operation has no responses, which OpenAPI 3.0 requires")
+		}
+		c.downgradeMap(get(op, "callbacks"), opPtr+"/callbacks", c.downgradeCallback)
+	}
+}
+
+func (c *converter) downgradeList(list *yaml.Node, ptr string) {
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, p := range list.Content {
+		list.Content[i] = c.downgradeParameter(p, ptr+"/"+itoa(i))
+	}
+}
+
+func (c *converter) downgradeCallback(callback *yaml.Node, ptr string) *yaml.Node {
+	eachPair(callback, func(expression string, item *yaml.Node) {
+		c.downgradePathItem(item, ptr+"/"+escape(expression))
+	})
+	return callback
+}
+
+// downgradeParameter rewrites the schema and content of a parameter or header.
+func (c *converter) downgradeParameter(p *yaml.Node, ptr string) *yaml.Node {
+	if schema := get(p, "schema"); schema != nil {
+		set(p, "schema", c.downgradeSchema(schema, ptr+"/schema"))
+	}
+	c.downgradeContent(get(p, "content"), ptr+"/content")
+	return p
+}
+
+func (c *converter) downgradeBody(body *yaml.Node, ptr string) *yaml.Node {
+	c.downgradeContent(get(body, "content"), ptr+"/content")
+	return body
+}
+
+func (c *converter) downgradeResponse(r *yaml.Node, ptr string) *yaml.Node {
+	c.downgradeMap(get(r, "headers"), ptr+"/headers", c.downgradeParameter)
+	c.downgradeContent(get(r, "content"), ptr+"/content")
+	return r
+}
+
+func (c *converter) downgradeContent(content *yaml.Node, ptr string) {
+	eachPair(content, func(mediaType string, media *yaml.Node) {
+		if schema := get(media, "schema"); schema != nil {
+			set(media, "schema", c.downgradeSchema(schema, ptr+"/"+escape(mediaType)+"/schema"))
+		}
+	})
+}
+
+// downgradeSchema rewrites a schema and the schemas it contains.
+func (c *converter) downgradeSchema(s *yaml.Node, ptr string) *yaml.Node {
+	if s.Kind == yaml.ScalarNode && s.Tag == "!!bool" {
+		// true accepts any value and false none.
+		out := mapping()
+		if s.Value == "false" {
+			set(out, "not", mapping())
+		}
+		return out
+	}
+	if s.Kind != yaml.MappingNode {
+		return s
+	}
+
+	c.drop(s, ptr, unsupportedKeywords...)
+	c.downgradeType(s, ptr)
+
+	if v := get(s, "const"); v != nil {
+		enum := sequence()
+		enum.Content = append(enum.Content, v)
+		replace(s, "const", "enum", enum)
+	}
+	if v := get(s, "examples"); v != nil && v.Kind == yaml.SequenceNode {
+		if len(v.Content) > 1 {
+			c.warn(v, ptr+"/examples", "only the first of %d examples is kept", len(v.Content))
+		}
+		if len(v.Content) == 0 {
+			remove(s, "examples")
+		} else {
+			replace(s, "examples", "example", v.Content[0])
+		}
+	}
+	for _, bound := range []struct{ exclusive, inclusive string }{
+		{"exclusiveMinimum", "minimum"},
+		{"exclusiveMaximum", "maximum"},
+	} {
+		v := get(s, bound.exclusive)
+		if v == nil || v.Tag == "!!bool" {
+			continue
+		}
+		if get(s, bound.inclusive) != nil {
+			c.warn(v, ptr+"/"+bound.exclusive, "%s and %s cannot be combined in OpenAPI 3.0", bound.exclusive, bound.inclusive)
+			remove(s, bound.exclusive)
+			continue
+		}
+		replace(s, bound.exclusive, bound.inclusive, v)
+		set(s, bound.exclusive, boolean(true))
+	}
+	if v := get(s, "contentEncoding"); v != nil {
+		if v.Value == "base64" && get(s, "format") == nil {
+			set(s, "format", str("byte"))
+		} else if v.Value != "base64" {
+			c.warn(v, ptr+"/contentEncoding", "content encoding %q is not supported in OpenAPI 3.0", v.Value)
+		}
+		remove(s, "contentEncoding")
+	}
+	if v := get(s, "contentMediaType"); v != nil {
+		if get(s, "format") == nil {
+			set(s, "format", str("binary"))
+		}
+		remove(s, "contentMediaType")
+	}
+
+	// Keywords next to a reference are ignored in OpenAPI 3.0.
+	if ref := get(s, "$ref"); ref != nil && len(s.Content) > 2 {
+		wrapped := mapping()
+		set(wrapped, "$ref", ref)
+		remove(s, "$ref")
+		allOf := sequence()
+		allOf.Content = append(allOf.Content, wrapped)
+		if existing := get(s, "allOf"); existing != nil {
+			allOf.Content = append(allOf.Content, existing.Content...)
+		}
+		set(s, "allOf", allOf)
+	}
+
+	for i := 0; i+1 < len(s.Content); i += 2 {
+		key, v := s.Content[i].Value, s.Content[i+1]
+		switch key {
+		case "properties":
+			c.downgradeMap(v, ptr+"/properties", func(n *yaml.Node, ptr string) *yaml.Node {
+				return c.downgradeSchema(n, ptr)
+			})
+		case "items", "additionalProperties", "not":
+			if key == "additionalProperties" && v.Tag == "!!bool" {
+				continue
+			}
+			s.Content[i+1] = c.downgradeSchema(v, ptr+"/"+key)
+		case "allOf", "anyOf", "oneOf":
+			for j, item := range v.Content {
+				v.Content[j] = c.downgradeSchema(item, ptr+"/"+key+"/"+itoa(j))
+			}
+		}
+	}
+	return s
+}
+
+// downgradeType turns a list of types into a single type, or into anyOf when
+// it holds several, and "null" into nullable.
+func (c *converter) downgradeType(s *yaml.Node, ptr string) {
+	typ := get(s, "type")
+	if typ == nil || typ.Kind != yaml.SequenceNode {
+		return
+	}
+
+	var types []*yaml.Node
+	nullable := false
+	for _, t := range typ.Content {
+		if t.Value == "null" {
+			nullable = true
+			continue
+		}
+		types = append(types, t)
+	}
+
+	switch len(types) {
+	case 0:
+		c.warn(typ, ptr+"/type", "a schema that only allows null has no OpenAPI 3.0 equivalent")
+		remove(s, "type")
+	case 1:
+		set(s, "type", types[0])
+	default:
+		anyOf := sequence()
+		for _, t := range types {
+			alt := mapping()
+			set(alt, "type", t)
+			anyOf.Content = append(anyOf.Content, alt)
+		}
+		remove(s, "type")
+		set(s, "anyOf", anyOf)
+	}
+	if nullable {
+		set(s, "nullable", boolean(true))
+	}
+}
+
+// drop removes keys from the mapping m and reports each one found.
+func (c *converter) drop(m *yaml.Node, ptr string, keys ...string) {
+	for _, key := range keys {
+		if v := get(m, key); v != nil {
+			c.warn(v, ptr+"/"+escape(key), "%s is not supported in OpenAPI 3.0", key)
+			remove(m, key)
+		}
+	}
+}
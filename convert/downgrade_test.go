@@ -0,0 +1,180 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/convert"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func downgradeDoc(t *testing.T, src string) (map[string]any, validate.Errors) {
+	t.Helper()
+	result, err := convert.OpenAPI31([]byte(src))
+	require.NoError(t, err)
+
+	problems, err := validate.Document(result.Document)
+	require.NoError(t, err)
+	assert.Empty(t, problems, "downgraded documents are valid OpenAPI 3.0")
+
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(result.Document, &doc))
+	return doc, result.Warnings
+}
+
+func TestOpenAPI31(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   map[string]any
+	}{
+		{
+			name:   "when a type list includes null",
+			schema: `{type: [string, "null"], examples: [a]}`,
+			want:   map[string]any{"type": "string", "nullable": true, "example": "a"},
+		},
+		{
+			name:   "when a type list has several types",
+			schema: `{type: [string, integer]}`,
+			want:   map[string]any{"anyOf": []any{map[string]any{"type": "string"}, map[string]any{"type": "integer"}}},
+		},
+		{
+			name:   "when a schema is a constant",
+			schema: `{type: string, const: pet}`,
+			want:   map[string]any{"type": "string", "enum": []any{"pet"}},
+		},
+		{
+			name:   "when bounds are exclusive",
+			schema: `{type: number, exclusiveMinimum: 0, exclusiveMaximum: 10}`,
+			want:   map[string]any{"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": true},
+		},
+		{
+			name:   "when a reference has siblings",
+			schema: `{$ref: "#/components/schemas/Pet", description: The pet}`,
+			want:   map[string]any{"description": "The pet", "allOf": []any{map[string]any{"$ref": "#/components/schemas/Pet"}}},
+		},
+		{
+			name:   "when content is encoded",
+			schema: `{type: object, properties: {photo: {type: string, contentMediaType: image/png}, data: {type: string, contentEncoding: base64}}}`,
+			want: map[string]any{"type": "object", "properties": map[string]any{
+				"photo": map[string]any{"type": "string", "format": "binary"},
+				"data":  map[string]any{"type": "string", "format": "byte"},
+			}},
+		},
+		{
+			name:   "when schemas are nested",
+			schema: `{type: array, items: {type: [object, "null"], additionalProperties: {const: 1}, allOf: [{type: [integer]}]}}`,
+			want: map[string]any{"type": "array", "items": map[string]any{
+				"type": "object", "nullable": true,
+				"additionalProperties": map[string]any{"enum": []any{1}},
+				"allOf":                []any{map[string]any{"type": "integer"}},
+			}},
+		},
+		{
+			name:   "when a schema is a boolean",
+			schema: `{properties: {any: true, none: false}}`,
+			want:   map[string]any{"properties": map[string]any{"any": map[string]any{}, "none": map[string]any{"not": map[string]any{}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, warnings := downgradeDoc(t, "openapi: 3.1.0\ninfo: {title: T, version: \"1\"}\ncomponents:\n  schemas:\n    Pet: {type: object}\n    Value: "+tt.schema+"\n")
+			assert.Empty(t, warnings)
+			assert.Equal(t, "3.0.3", doc["openapi"])
+			assert.Equal(t, map[string]any{}, doc["paths"], "paths are required in OpenAPI 3.0")
+			assert.Equal(t, tt.want, lookup(t, doc, "components/schemas/Value"))
+		})
+	}
+}
+
+func TestOpenAPI31Locations(t *testing.T) {
+	doc, warnings := downgradeDoc(t, `{
+  "openapi": "3.1.0",
+  "info": {"title": "T", "version": "1"},
+  "paths": {
+    "/pets/{id}": {
+      "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": ["string", "null"]}}],
+      "post": {
+        "requestBody": {"content": {"application/json": {"schema": {"const": 1}}}},
+        "responses": {"200": {
+          "description": "OK",
+          "headers": {"X-Next": {"schema": {"type": ["integer", "null"]}}},
+          "content": {"application/json": {"schema": {"examples": [1]}}}
+        }},
+        "callbacks": {"done": {"{$request.body#/url}": {"post": {
+          "requestBody": {"content": {"application/json": {"schema": {"type": ["object", "null"]}}}},
+          "responses": {"200": {"description": "OK"}}
+        }}}}
+      }
+    }
+  },
+  "components": {
+    "parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": ["integer", "null"]}}},
+    "requestBodies": {"Pet": {"content": {"application/json": {"schema": {"const": "pet"}}}}}
+  }
+}`)
+	assert.Empty(t, warnings)
+	tests := []struct {
+		path string
+		want any
+	}{
+		{path: "paths/~1pets~1{id}/parameters/0/schema/nullable", want: true},
+		{path: "paths/~1pets~1{id}/post/requestBody/content/application~1json/schema/enum", want: []any{1}},
+		{path: "paths/~1pets~1{id}/post/responses/200/headers/X-Next/schema/type", want: "integer"},
+		{path: "paths/~1pets~1{id}/post/responses/200/content/application~1json/schema/example", want: 1},
+		{path: "paths/~1pets~1{id}/post/callbacks/done/{$request.body#~1url}/post/requestBody/content/application~1json/schema/nullable", want: true},
+		{path: "components/parameters/Limit/schema/nullable", want: true},
+		{path: "components/requestBodies/Pet/content/application~1json/schema/enum", want: []any{"pet"}},
+	}
+	for _, tt := range tests {
+		t.Run("when downgrading "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, lookup(t, doc, tt.path))
+		})
+	}
+}
+
+func TestOpenAPI31Warnings(t *testing.T) {
+	src := `openapi: 3.1.0
+info:
+  title: T
+  summary: Lossy
+  version: "1"
+  license: {name: MIT, identifier: MIT}
+webhooks:
+  newPet: {}
+paths: {}
+components:
+  schemas:
+    Pet:
+      $id: pet
+      type: ["null"]
+      examples: [1, 2]
+      minimum: 1
+      exclusiveMinimum: 2
+      prefixItems: [{type: string}]
+`
+	_, warnings := downgradeDoc(t, src)
+	assert.Equal(t, validate.Errors{
+		{Pointer: "/webhooks", Line: 8, Column: 3, Message: "webhooks is not supported in OpenAPI 3.0"},
+		{Pointer: "/info/summary", Line: 4, Column: 12, Message: "summary is not supported in OpenAPI 3.0"},
+		{Pointer: "/info/license/identifier", Line: 6, Column: 36, Message: "identifier is not supported in OpenAPI 3.0"},
+		{Pointer: "/components/schemas/Pet/$id", Line: 13, Column: 12, Message: "$id is not supported in OpenAPI 3.0"},
+		{Pointer: "/components/schemas/Pet/prefixItems", Line: 18, Column: 20, Message: "prefixItems is not supported in OpenAPI 3.0"},
+		{Pointer: "/components/schemas/Pet/type", Line: 14, Column: 13, Message: "a schema that only allows null has no OpenAPI 3.0 equivalent"},
+		{Pointer: "/components/schemas/Pet/examples", Line: 15, Column: 17, Message: "only the first of 2 examples is kept"},
+		{Pointer: "/components/schemas/Pet/exclusiveMinimum", Line: 17, Column: 25, Message: "exclusiveMinimum and minimum cannot be combined in OpenAPI 3.0"},
+	}, warnings)
+}
+
+func TestOpenAPI31Errors(t *testing.T) {
+	_, err := convert.OpenAPI31([]byte("openapi: 3.0.3\n"))
+	assert.ErrorIs(t, err, convert.ErrNotOpenAPI31)
+	_, err = convert.OpenAPI31([]byte("openapi: [3.1"))
+	assert.Error(t, err)
+
+	assert.True(t, convert.IsOpenAPI31([]byte(`{"openapi": "3.1.1"}`)))
+	assert.False(t, convert.IsOpenAPI31([]byte("openapi: 3.10.0")))
+	assert.False(t, convert.IsOpenAPI31([]byte(`swagger: "2.0"`)))
+}
@@ -0,0 +1,427 @@
+package convert
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// paths converts the path items of the document.
+func (c *converter) paths(paths *yaml.Node) *yaml.Node {
+	out := mapping()
+	eachPair(paths, func(path string, item *yaml.Node) {
+		if !strings.HasPrefix(path, "/") {
+			set(out, path, clone(item))
+			return
+		}
+		set(out, path, c.pathItem(item, pointer("paths", path)))
+	})
+	return out
+}
+
+func (c *converter) pathItem(item *yaml.Node, ptr string) *yaml.Node {
+	out := mapping()
+	var shared []param
+	if params := get(item, "parameters"); params != nil {
+		shared = c.params(params, ptr+"/parameters")
+	}
+
+	eachPair(item, func(key string, v *yaml.Node) {
+		switch {
+		case key == "$ref":
+			set(out, key, c.ref(v))
+		case key == "parameters":
+			if converted := c.plainParams(shared); converted != nil {
+				set(out, key, converted)
+			}
+		case isMethod(key):
+			set(out, key, c.operation(v, ptr+"/"+key, shared))
+		default:
+			set(out, key, clone(v))
+		}
+	})
+	return out
+}
+
+// operation converts an operation. shared holds the parameters of its path
+// item; body and form parameters among them apply to the operation unless it
+// overrides them.
+func (c *converter) operation(op *yaml.Node, ptr string, shared []param) *yaml.Node {
+	consumes, produces := c.consumes, c.produces
+	if v := get(op, "consumes"); v != nil {
+		consumes = strs(v)
+	}
+	if v := get(op, "produces"); v != nil {
+		produces = strs(v)
+	}
+
+	var own []param
+	if params := get(op, "parameters"); params != nil {
+		own = c.params(params, ptr+"/parameters")
+	}
+	params := mergeParams(shared, own)
+
+	out := mapping()
+	eachPair(op, func(key string, v *yaml.Node) {
+		switch key {
+		case "consumes", "produces":
+		case "schemes":
+			c.warn(v, ptr+"/schemes", "operation schemes are not converted: OpenAPI 3.0 servers apply to the whole URL")
+		case "parameters":
+			// Plain parameters of the path item stay on the path item.
+			if converted := c.plainParams(own); converted != nil {
+				set(out, key, converted)
+			}
+			if body := c.body(params, consumes); body != nil {
+				set(out, "requestBody", body)
+			}
+		case "responses":
+			responses := mapping()
+			eachPair(v, func(code string, r *yaml.Node) {
+				set(responses, code, c.response(r, ptr+"/responses/"+escape(code), produces))
+			})
+			set(out, key, responses)
+		default:
+			set(out, key, clone(v))
+		}
+	})
+	// Body parameters of the path item apply even without own parameters.
+	if get(op, "parameters") == nil {
+		if body := c.body(params, consumes); body != nil {
+			set(out, "requestBody", body)
+		}
+	}
+	return out
+}
+
+// param is a parameter of an operation. node is the parameter itself, with
+// references to global parameters resolved; ref is the reference, if any.
+type param struct {
+	node *yaml.Node
+	ref  *yaml.Node
+	ptr  string
+}
+
+func (p param) key() string {
+	return in(p.node) + " " + value(get(p.node, "name"))
+}
+
+// params resolves the references of a parameter list.
+func (c *converter) params(list *yaml.Node, ptr string) []param {
+	var out []param
+	for i, p := range list.Content {
+		p = deref(p)
+		item := param{node: p, ptr: ptr + "/" + itoa(i)}
+		if ref := get(p, "$ref"); ref != nil {
+			item.ref = ref
+			if strings.HasPrefix(ref.Value, "#/parameters/") {
+				name := unescape(strings.TrimPrefix(ref.Value, "#/parameters/"))
+				if target := get(get(c.root, "parameters"), name); target != nil {
+					item.node = target
+					item.ptr = pointer("parameters", name)
+				}
+			}
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// mergeParams returns the parameters of a path item that an operation does
+// not override, followed by those of the operation.
+func mergeParams(shared, own []param) []param {
+	overridden := make(map[string]bool, len(own))
+	for _, p := range own {
+		overridden[p.key()] = true
+	}
+	var out []param
+	for _, p := range shared {
+		if !overridden[p.key()] && !(in(p.node) == "body" && hasBody(own)) {
+			out = append(out, p)
+		}
+	}
+	return append(out, own...)
+}
+
+func hasBody(params []param) bool {
+	for _, p := range params {
+		if in(p.node) == "body" {
+			return true
+		}
+	}
+	return false
+}
+
+// plainParams converts the parameters that are not sent in the body.
+func (c *converter) plainParams(params []param) *yaml.Node {
+	out := sequence()
+	for _, p := range params {
+		switch in(p.node) {
+		case "body", "formData":
+			continue
+		}
+		if p.ref != nil {
+			ref := mapping()
+			set(ref, "$ref", c.ref(p.ref))
+			out.Content = append(out.Content, ref)
+			continue
+		}
+		out.Content = append(out.Content, c.parameter(p.node, p.ptr))
+	}
+	if len(out.Content) == 0 {
+		return nil
+	}
+	return out
+}
+
+// body returns the request body made of the body or form parameters.
+func (c *converter) body(params []param, consumes []string) *yaml.Node {
+	var form []param
+	for _, p := range params {
+		switch in(p.node) {
+		case "body":
+			if p.ref != nil && strings.HasPrefix(p.ref.Value, "#/parameters/") {
+				ref := mapping()
+				set(ref, "$ref", c.ref(p.ref))
+				return ref
+			}
+			return c.requestBody(p.node, p.ptr, consumes)
+		case "formData":
+			form = append(form, p)
+		}
+	}
+	if len(form) == 0 {
+		return nil
+	}
+	return c.formBody(form, consumes)
+}
+
+// requestBody converts a body parameter.
+func (c *converter) requestBody(p *yaml.Node, ptr string, consumes []string) *yaml.Node {
+	out := mapping()
+	if v := get(p, "description"); v != nil {
+		set(out, "description", clone(v))
+	}
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+	content := mapping()
+	for _, mediaType := range consumes {
+		media := mapping()
+		if schema := get(p, "schema"); schema != nil {
+			set(media, "schema", c.schema(schema, ptr+"/schema"))
+		}
+		set(content, mediaType, media)
+	}
+	set(out, "content", content)
+	if v := get(p, "required"); v != nil {
+		set(out, "required", clone(v))
+	}
+	copyExtensions(out, p)
+	return out
+}
+
+// formBody converts form parameters to a request body with an object schema.
+func (c *converter) formBody(form []param, consumes []string) *yaml.Node {
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+
+	schema, properties, required := mapping(), mapping(), sequence()
+	set(schema, "type", str("object"))
+	hasFile := false
+	for _, p := range form {
+		name := value(get(p.node, "name"))
+		prop := c.paramSchema(p.node, p.ptr)
+		if v := get(p.node, "description"); v != nil {
+			set(prop, "description", clone(v))
+		}
+		if typ := get(p.node, "type"); typ != nil && typ.Value == "file" {
+			hasFile = true
+		}
+		if format := get(p.node, "collectionFormat"); format != nil && format.Value != "multi" {
+			c.warn(format, p.ptr+"/collectionFormat", "collectionFormat %q of form parameter %q is not converted", format.Value, name)
+		}
+		set(properties, name, prop)
+		if v := get(p.node, "required"); v != nil && v.Value == "true" {
+			required.Content = append(required.Content, str(name))
+		}
+	}
+	set(schema, "properties", properties)
+	if len(required.Content) > 0 {
+		set(schema, "required", required)
+	}
+
+	switch {
+	case len(mediaTypes) > 0:
+	case hasFile:
+		mediaTypes = []string{"multipart/form-data"}
+	default:
+		mediaTypes = []string{"application/x-www-form-urlencoded"}
+	}
+	if hasFile && !contains(mediaTypes, "multipart/form-data") {
+		c.warn(form[0].node, form[0].ptr, "file parameters require multipart/form-data, which the operation does not consume")
+	}
+
+	out, content := mapping(), mapping()
+	for _, mediaType := range mediaTypes {
+		media := mapping()
+		set(media, "schema", schema)
+		set(content, mediaType, media)
+	}
+	set(out, "content", content)
+	if len(required.Content) > 0 {
+		set(out, "required", boolean(true))
+	}
+	return out
+}
+
+// parameter converts a query, header or path parameter.
+func (c *converter) parameter(p *yaml.Node, ptr string) *yaml.Node {
+	if ref := get(p, "$ref"); ref != nil {
+		out := mapping()
+		set(out, "$ref", c.ref(ref))
+		return out
+	}
+
+	out := mapping()
+	location := in(p)
+	for _, key := range []string{"name", "in", "description", "required"} {
+		if v := get(p, key); v != nil {
+			set(out, key, clone(v))
+		}
+	}
+	if v := get(p, "allowEmptyValue"); v != nil && location == "query" {
+		set(out, "allowEmptyValue", clone(v))
+	}
+
+	if typ := get(p, "type"); typ != nil && typ.Value == "array" {
+		format := "csv"
+		formatNode := get(p, "collectionFormat")
+		if formatNode != nil {
+			format = formatNode.Value
+		}
+		switch {
+		case format == "csv" && location == "query":
+			set(out, "style", str("form"))
+			set(out, "explode", boolean(false))
+		case format == "csv":
+		case format == "multi" && location == "query":
+			set(out, "style", str("form"))
+			set(out, "explode", boolean(true))
+		case format == "ssv" && location == "query":
+			set(out, "style", str("spaceDelimited"))
+		case format == "pipes" && location == "query":
+			set(out, "style", str("pipeDelimited"))
+		default:
+			c.warn(formatNode, ptr+"/collectionFormat", "collectionFormat %q of %s parameter %q has no OpenAPI 3.0 equivalent", format, location, value(get(p, "name")))
+		}
+	}
+
+	set(out, "schema", c.paramSchema(p, ptr))
+	copyExtensions(out, p)
+	return out
+}
+
+// schemaKeys are the parameter and header fields that describe their value.
+var schemaKeys = []string{
+	"type", "format", "items", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+	"maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum", "multipleOf",
+}
+
+// paramSchema returns the schema of a parameter, header or items object.
+func (c *converter) paramSchema(p *yaml.Node, ptr string) *yaml.Node {
+	out := mapping()
+	for _, key := range schemaKeys {
+		v := get(p, key)
+		switch {
+		case v == nil:
+		case key == "type" && v.Value == "file":
+			set(out, "type", str("string"))
+			set(out, "format", str("binary"))
+		case key == "format" && get(out, "format") != nil:
+		case key == "items":
+			items := c.paramSchema(deref(v), ptr+"/items")
+			if format := get(v, "collectionFormat"); format != nil {
+				c.warn(format, ptr+"/items/collectionFormat", "collectionFormat of nested items is not converted")
+			}
+			set(out, key, items)
+		default:
+			set(out, key, clone(v))
+		}
+	}
+	if v := get(p, "x-nullable"); v != nil {
+		set(out, "nullable", clone(v))
+	}
+	return out
+}
+
+// response converts a response.
+func (c *converter) response(r *yaml.Node, ptr string, produces []string) *yaml.Node {
+	r = deref(r)
+	out := mapping()
+	if ref := get(r, "$ref"); ref != nil {
+		set(out, "$ref", c.ref(ref))
+		return out
+	}
+
+	description := get(r, "description")
+	if description == nil {
+		description = str("")
+	}
+	set(out, "description", clone(description))
+
+	if headers := get(r, "headers"); headers != nil {
+		converted := mapping()
+		eachPair(headers, func(name string, h *yaml.Node) {
+			header := mapping()
+			if v := get(h, "description"); v != nil {
+				set(header, "description", clone(v))
+			}
+			set(header, "schema", c.paramSchema(h, ptr+"/headers/"+escape(name)))
+			copyExtensions(header, h)
+			set(converted, name, header)
+		})
+		set(out, "headers", converted)
+	}
+
+	schema, examples := get(r, "schema"), get(r, "examples")
+	if schema != nil || examples != nil {
+		mediaTypes := produces
+		if len(mediaTypes) == 0 {
+			mediaTypes = []string{"application/json"}
+		}
+		content := mapping()
+		for _, mediaType := range mediaTypes {
+			media := mapping()
+			if schema != nil {
+				set(media, "schema", c.schema(schema, ptr+"/schema"))
+			}
+			set(content, mediaType, media)
+		}
+		eachPair(examples, func(mediaType string, example *yaml.Node) {
+			media := get(content, mediaType)
+			if media == nil {
+				media = mapping()
+				set(content, mediaType, media)
+			}
+			set(media, "example", clone(example))
+		})
+		set(out, "content", content)
+	}
+	copyExtensions(out, r)
+	return out
+}
+
+func in(p *yaml.Node) string {
+	return value(get(p, "in"))
+}
+
+func isMethod(key string) bool {
+	return contains(methods, key)
+}
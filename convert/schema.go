@@ -0,0 +1,233 @@
+package convert
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// refPrefixes maps the prefixes of Swagger references to OpenAPI 3.0 ones.
+var refPrefixes = []struct{ from, to string }{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+// schema converts a schema object and the schemas it contains.
+func (c *converter) schema(s *yaml.Node, ptr string) *yaml.Node {
+	s = deref(s)
+	if s.Kind != yaml.MappingNode {
+		return clone(s)
+	}
+
+	out := mapping()
+	eachPair(s, func(key string, v *yaml.Node) {
+		switch key {
+		case "$ref":
+			set(out, key, c.ref(v))
+		case "type":
+			if v.Value == "file" {
+				set(out, key, str("string"))
+				if get(s, "format") == nil {
+					set(out, "format", str("binary"))
+				}
+				return
+			}
+			set(out, key, clone(v))
+		case "x-nullable":
+			set(out, "nullable", clone(v))
+		case "discriminator":
+			if v.Kind == yaml.ScalarNode {
+				d := mapping()
+				set(d, "propertyName", clone(v))
+				v = d
+			}
+			set(out, key, clone(v))
+		case "properties":
+			properties := mapping()
+			eachPair(v, func(name string, p *yaml.Node) {
+				set(properties, name, c.schema(p, ptr+"/properties/"+escape(name)))
+			})
+			set(out, key, properties)
+		case "items", "additionalProperties", "not":
+			set(out, key, c.schema(v, ptr+"/"+key))
+		case "allOf", "anyOf", "oneOf":
+			list := sequence()
+			for i, item := range deref(v).Content {
+				list.Content = append(list.Content, c.schema(item, ptr+"/"+key+"/"+itoa(i)))
+			}
+			set(out, key, list)
+		default:
+			set(out, key, clone(v))
+		}
+	})
+	return out
+}
+
+// ref returns a copy of a reference pointing to the converted location.
+// References to other documents keep their URL and have their fragment
+// rewritten.
+func (c *converter) ref(ref *yaml.Node) *yaml.Node {
+	out := clone(ref)
+	doc, fragment := "", ref.Value
+	if i := strings.IndexByte(ref.Value, '#'); i >= 0 {
+		doc, fragment = ref.Value[:i], ref.Value[i:]
+	}
+	for _, p := range refPrefixes {
+		if strings.HasPrefix(fragment, p.from) {
+			out.Value = doc + p.to + strings.TrimPrefix(fragment, p.from)
+			return out
+		}
+	}
+	if strings.HasPrefix(fragment, "#/parameters/") {
+		name := strings.TrimPrefix(fragment, "#/parameters/")
+		target := get(get(c.root, "parameters"), unescape(name))
+		prefix := "#/components/parameters/"
+		if doc == "" && in(target) == "body" {
+			prefix = "#/components/requestBodies/"
+		}
+		out.Value = doc + prefix + name
+	}
+	return out
+}
+
+// copyExtensions copies the specification extensions of from to out.
+func copyExtensions(out, from *yaml.Node) {
+	eachPair(from, func(key string, v *yaml.Node) {
+		if strings.HasPrefix(key, "x-") && key != "x-nullable" {
+			set(out, key, clone(v))
+		}
+	})
+}
+
+func mapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func sequence() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+func str(v string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+}
+
+func boolean(v bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+}
+
+// get returns the value of key in the mapping m, or nil.
+func get(m *yaml.Node, key string) *yaml.Node {
+	m = deref(m)
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return deref(m.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// set sets key to v in the mapping m, keeping the position of an existing key.
+func set(m *yaml.Node, key string, v *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = v
+			return
+		}
+	}
+	m.Content = append(m.Content, str(key), v)
+}
+
+// remove removes key from the mapping m.
+func remove(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// replace renames key to newKey in the mapping m and sets its value to v.
+func replace(m *yaml.Node, key, newKey string, v *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i], m.Content[i+1] = str(newKey), v
+			return
+		}
+	}
+}
+
+// eachPair calls fn for each key and value of the mapping m, in order.
+func eachPair(m *yaml.Node, fn func(key string, v *yaml.Node)) {
+	m = deref(m)
+	if m == nil || m.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		fn(m.Content[i].Value, deref(m.Content[i+1]))
+	}
+}
+
+// deref returns the node an alias points to.
+func deref(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
+
+// clone returns a deep copy of n with aliases expanded and without comments
+// or anchors. Positions are kept to locate warnings.
+func clone(n *yaml.Node) *yaml.Node {
+	n = deref(n)
+	out := &yaml.Node{Kind: n.Kind, Style: n.Style, Tag: n.Tag, Value: n.Value, Line: n.Line, Column: n.Column}
+	for _, child := range n.Content {
+		out.Content = append(out.Content, clone(child))
+	}
+	return out
+}
+
+// strs returns the strings of a sequence.
+func strs(n *yaml.Node) []string {
+	n = deref(n)
+	if n == nil {
+		return nil
+	}
+	out := []string{}
+	for _, item := range n.Content {
+		out = append(out, item.Value)
+	}
+	return out
+}
+
+func value(n *yaml.Node) string {
+	if n == nil {
+		return ""
+	}
+	return n.Value
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
+
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func unescape(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
@@ -0,0 +1,623 @@
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/source"
+)
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// maxDepth bounds the recursion into references and nested schemas.
+const maxDepth = 32
+
+var pathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+type object = map[string]interface{}
+
+// document is a decoded OpenAPI document.
+type document struct {
+	root    object
+	swagger bool
+}
+
+func parse(b []byte) (*document, error) {
+	if format, _ := source.Detect(b); format == source.FormatYAML {
+		var err error
+		if b, err = spec.ToJSON(b); err != nil {
+			return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+		}
+	}
+	var root object
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+	if root == nil {
+		return nil, errors.New("failed to parse OpenAPI specification: document is empty")
+	}
+	_, swagger := root["swagger"]
+	return &document{root: root, swagger: swagger}, nil
+}
+
+// resolve follows local "$ref" values and returns the referenced object, or
+// nil when v is not an object.
+func (d *document) resolve(v interface{}) object {
+	for i := 0; i < maxDepth; i++ {
+		m, ok := v.(object)
+		if !ok {
+			return nil
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return m
+		}
+		v = d.lookup(strings.TrimPrefix(ref, "#"))
+	}
+	return nil
+}
+
+// lookup returns the value at the JSON pointer ptr.
+func (d *document) lookup(ptr string) interface{} {
+	var v interface{} = d.root
+	if ptr == "" {
+		return v
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(ptr, "/"), "/") {
+		m, ok := v.(object)
+		if !ok {
+			return nil
+		}
+		v = m[strings.NewReplacer("~1", "/", "~0", "~").Replace(token)]
+	}
+	return v
+}
+
+// location is the JSON pointer of a compared value in both documents.
+type location struct {
+	old, new string
+}
+
+func (l location) join(tokens ...string) location {
+	p := pointer(tokens...)
+	return location{old: l.old + p, new: l.new + p}
+}
+
+// direction tells whether a schema describes data sent or received by clients.
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+type comparer struct {
+	old, new *document
+	changes  []Change
+	// seen holds the pairs of schemas being compared, to stop at cycles.
+	seen map[[2]uintptr]bool
+
+	path, method string
+}
+
+func (c *comparer) report(kind Kind, breaking bool, at location, format string, args ...interface{}) {
+	ptr := at.new
+	if kind == KindRemoved {
+		ptr = at.old
+	}
+	c.changes = append(c.changes, Change{
+		Kind:     kind,
+		Breaking: breaking,
+		Path:     c.path,
+		Method:   strings.ToUpper(c.method),
+		Pointer:  ptr,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// pathNames returns the path templates of d keyed by the template with the
+// parameter names removed, so that renaming a path parameter is not a change.
+func pathNames(d *document) map[string]string {
+	names := make(map[string]string)
+	paths, _ := d.root["paths"].(object)
+	for p := range paths {
+		if strings.HasPrefix(p, "/") {
+			names[pathParam.ReplaceAllString(p, "{}")] = p
+		}
+	}
+	return names
+}
+
+func (c *comparer) paths() {
+	oldNames, newNames := pathNames(c.old), pathNames(c.new)
+	oldPaths, _ := c.old.root["paths"].(object)
+	newPaths, _ := c.new.root["paths"].(object)
+
+	names := make(map[string]string, len(oldNames)+len(newNames))
+	for key, name := range oldNames {
+		names[key] = name
+	}
+	for key, name := range newNames {
+		names[key] = name
+	}
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return names[keys[i]] < names[keys[j]] })
+
+	for _, key := range keys {
+		oldName, inOld := oldNames[key]
+		newName, inNew := newNames[key]
+		at := location{old: pointer("paths", oldName), new: pointer("paths", newName)}
+		c.path, c.method = names[key], ""
+		switch {
+		case !inNew:
+			c.report(KindRemoved, true, at, "path removed")
+		case !inOld:
+			c.report(KindAdded, false, at, "path added")
+		default:
+			c.pathItem(c.old.resolve(oldPaths[oldName]), c.new.resolve(newPaths[newName]), at)
+		}
+	}
+}
+
+func (c *comparer) pathItem(oldItem, newItem object, at location) {
+	for _, m := range methods {
+		oldOp, newOp := c.old.resolve(oldItem[m]), c.new.resolve(newItem[m])
+		c.method = m
+		switch {
+		case oldOp == nil && newOp == nil:
+		case newOp == nil:
+			c.report(KindRemoved, true, at.join(m), "operation removed")
+		case oldOp == nil:
+			c.report(KindAdded, false, at.join(m), "operation added")
+		default:
+			c.operation(oldItem, newItem, oldOp, newOp, at)
+		}
+	}
+}
+
+func (c *comparer) operation(oldItem, newItem, oldOp, newOp object, itemAt location) {
+	at := itemAt.join(c.method)
+	if oldOp["deprecated"] != true && newOp["deprecated"] == true {
+		c.report(KindChanged, false, at.join("deprecated"), "operation deprecated")
+	}
+	c.parameters(
+		c.old.parameters(oldItem, oldOp, itemAt.old, at.old),
+		c.new.parameters(newItem, newOp, itemAt.new, at.new),
+	)
+	c.requestBody(c.old.requestBody(oldItem, oldOp, itemAt.old, at.old), c.new.requestBody(newItem, newOp, itemAt.new, at.new))
+	c.responses(oldOp, newOp, at)
+}
+
+// parameter is a resolved parameter and its JSON pointer.
+type parameter struct {
+	def object
+	ptr string
+}
+
+// parameters returns the non-body parameters of an operation keyed by
+// location and name, operation parameters overriding path item ones.
+func (d *document) parameters(item, op object, itemPtr, opPtr string) map[string]parameter {
+	params := make(map[string]parameter)
+	add := func(list interface{}, ptr string) {
+		items, _ := list.([]interface{})
+		for i, v := range items {
+			p := d.resolve(v)
+			if p == nil || p["in"] == "body" {
+				continue
+			}
+			in, _ := p["in"].(string)
+			name, _ := p["name"].(string)
+			if in == "header" {
+				name = strings.ToLower(name)
+			}
+			params[in+" "+name] = parameter{def: p, ptr: fmt.Sprintf("%s/parameters/%d", ptr, i)}
+		}
+	}
+	add(item["parameters"], itemPtr)
+	add(op["parameters"], opPtr)
+	return params
+}
+
+func (c *comparer) parameters(oldParams, newParams map[string]parameter) {
+	keys := make(map[string]interface{}, len(oldParams)+len(newParams))
+	for k := range oldParams {
+		keys[k] = nil
+	}
+	for k := range newParams {
+		keys[k] = nil
+	}
+
+	for _, key := range sortedKeys(keys) {
+		oldParam, inOld := oldParams[key]
+		newParam, inNew := newParams[key]
+		in, name, _ := strings.Cut(key, " ")
+		at := location{old: oldParam.ptr, new: newParam.ptr}
+		switch {
+		case !inNew:
+			c.report(KindRemoved, false, at, "%s parameter %q removed", in, name)
+		case !inOld:
+			required := newParam.def["required"] == true
+			c.report(KindAdded, required, at, "%s %s parameter %q added", optionality(required), in, name)
+		default:
+			oldRequired, newRequired := oldParam.def["required"] == true, newParam.def["required"] == true
+			if oldRequired != newRequired {
+				c.report(KindChanged, newRequired, at.join("required"), "%s parameter %q became %s", in, name, optionality(newRequired))
+			}
+			oldSchema, oldAt := paramSchema(oldParam)
+			newSchema, newAt := paramSchema(newParam)
+			if oldSchema != nil && newSchema != nil {
+				c.schema(oldSchema, newSchema, location{old: oldAt, new: newAt}, fmt.Sprintf("%s parameter %q", in, name), "", request, 0)
+			}
+		}
+	}
+}
+
+// paramSchema returns the schema of a parameter and its pointer; Swagger 2.0
+// parameters hold their schema keywords themselves.
+func paramSchema(p parameter) (interface{}, string) {
+	if s, ok := p.def["schema"]; ok {
+		return s, p.ptr + "/schema"
+	}
+	if _, ok := p.def["type"]; ok {
+		return p.def, p.ptr
+	}
+	return nil, ""
+}
+
+func optionality(required bool) string {
+	if required {
+		return "required"
+	}
+	return "optional"
+}
+
+// body is a request body: whether it is required, the schemas of its media
+// types and its JSON pointer.
+type body struct {
+	required bool
+	content  map[string]interface{}
+	ptr      string
+}
+
+func (d *document) requestBody(item, op object, itemPtr, opPtr string) *body {
+	if !d.swagger {
+		rb := d.resolve(op["requestBody"])
+		if rb == nil {
+			return nil
+		}
+		return &body{required: rb["required"] == true, content: d.content(rb), ptr: opPtr + "/requestBody"}
+	}
+
+	for _, list := range []struct {
+		params interface{}
+		ptr    string
+	}{{op["parameters"], opPtr}, {item["parameters"], itemPtr}} {
+		params, _ := list.params.([]interface{})
+		for i, v := range params {
+			if p := d.resolve(v); p != nil && p["in"] == "body" {
+				return &body{
+					required: p["required"] == true,
+					content:  d.mediaTypes(op, "consumes", p["schema"]),
+					ptr:      fmt.Sprintf("%s/parameters/%d", list.ptr, i),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// mediaTypes maps the media types a Swagger 2.0 operation consumes or
+// produces to schema.
+func (d *document) mediaTypes(op object, key string, schema interface{}) map[string]interface{} {
+	types, ok := op[key].([]interface{})
+	if !ok {
+		types, _ = d.root[key].([]interface{})
+	}
+	if len(types) == 0 {
+		types = []interface{}{"application/json"}
+	}
+	content := make(map[string]interface{}, len(types))
+	for _, t := range types {
+		if s, ok := t.(string); ok {
+			content[s] = schema
+		}
+	}
+	return content
+}
+
+// content returns the schemas of an OpenAPI 3 content map by media type.
+func (d *document) content(v object) map[string]interface{} {
+	content := make(map[string]interface{})
+	m, _ := v["content"].(object)
+	for mediaType, mt := range m {
+		if mt, ok := mt.(object); ok {
+			content[mediaType] = mt["schema"]
+		}
+	}
+	return content
+}
+
+func (c *comparer) requestBody(oldBody, newBody *body) {
+	switch {
+	case oldBody == nil && newBody == nil:
+	case newBody == nil:
+		c.report(KindRemoved, false, location{old: oldBody.ptr}, "request body removed")
+	case oldBody == nil:
+		c.report(KindAdded, newBody.required, location{new: newBody.ptr}, "%s request body added", optionality(newBody.required))
+	default:
+		at := location{old: oldBody.ptr, new: newBody.ptr}
+		if oldBody.required != newBody.required {
+			c.report(KindChanged, newBody.required, at, "request body became %s", optionality(newBody.required))
+		}
+		c.content(oldBody.content, newBody.content, at, "request body", request)
+	}
+}
+
+func (c *comparer) responses(oldOp, newOp object, opAt location) {
+	oldResponses, _ := oldOp["responses"].(object)
+	newResponses, _ := newOp["responses"].(object)
+	for _, code := range unionKeys(oldResponses, newResponses) {
+		if strings.HasPrefix(code, "x-") {
+			continue
+		}
+		oldResp, newResp := c.old.resolve(oldResponses[code]), c.new.resolve(newResponses[code])
+		at := opAt.join("responses", code)
+		switch {
+		case oldResp == nil && newResp == nil:
+		case newResp == nil:
+			c.report(KindRemoved, true, at, "response %s removed", code)
+		case oldResp == nil:
+			c.report(KindAdded, false, at, "response %s added", code)
+		default:
+			c.content(c.responseContent(c.old, oldOp, oldResp), c.responseContent(c.new, newOp, newResp), at, "response "+code, response)
+		}
+	}
+}
+
+func (c *comparer) responseContent(d *document, op, resp object) map[string]interface{} {
+	if d.swagger {
+		return d.mediaTypes(op, "produces", resp["schema"])
+	}
+	return d.content(resp)
+}
+
+// content compares the media types of a body and their schemas. Swagger 2.0
+// bodies have a single schema for all media types.
+func (c *comparer) content(oldContent, newContent map[string]interface{}, bodyAt location, where string, dir direction) {
+	for _, mediaType := range unionKeys(oldContent, newContent) {
+		oldSchema, inOld := oldContent[mediaType]
+		newSchema, inNew := newContent[mediaType]
+		at := location{old: c.mediaTypePointer(c.old, bodyAt.old, mediaType), new: c.mediaTypePointer(c.new, bodyAt.new, mediaType)}
+		switch {
+		case !inNew:
+			c.report(KindRemoved, true, at, "%s media type %q removed", where, mediaType)
+		case !inOld:
+			c.report(KindAdded, false, at, "%s media type %q added", where, mediaType)
+		case oldSchema != nil && newSchema != nil:
+			c.schema(oldSchema, newSchema, location{old: at.old + "/schema", new: at.new + "/schema"}, where, "", dir, 0)
+		}
+	}
+}
+
+func (c *comparer) mediaTypePointer(d *document, bodyPtr, mediaType string) string {
+	if d.swagger {
+		return bodyPtr
+	}
+	return bodyPtr + pointer("content", mediaType)
+}
+
+// schema compares two schemas. where describes the body or parameter holding
+// them and field the dotted property path within it, e.g. "owner.name".
+func (c *comparer) schema(oldV, newV interface{}, at location, where, field string, dir direction, depth int) {
+	oldR, newR := c.old.resolve(oldV), c.new.resolve(newV)
+	if oldR == nil || newR == nil || depth > maxDepth {
+		return
+	}
+	pair := [2]uintptr{reflect.ValueOf(oldR).Pointer(), reflect.ValueOf(newR).Pointer()}
+	if c.seen[pair] {
+		return
+	}
+	c.seen[pair] = true
+	defer delete(c.seen, pair)
+	oldS, newS := c.old.flatten(oldR, 0), c.new.flatten(newR, 0)
+
+	name := describe(where, field, "")
+	if oldType, newType := typeOf(oldS), typeOf(newS); oldType != "" && newType != "" && oldType != newType {
+		c.report(KindChanged, true, at.join("type"), "%s type changed from %s to %s", name, oldType, newType)
+		return
+	}
+	c.enum(oldS, newS, at, name, dir)
+
+	oldProps, _ := oldS["properties"].(object)
+	newProps, _ := newS["properties"].(object)
+	oldRequired, newRequired := requiredSet(oldS), requiredSet(newS)
+	for _, prop := range unionKeys(oldProps, newProps) {
+		oldProp, inOld := oldProps[prop]
+		newProp, inNew := newProps[prop]
+		propAt := at.join("properties", prop)
+		propField := prop
+		if field != "" {
+			propField = field + "." + prop
+		}
+		switch {
+		case !inNew:
+			c.report(KindRemoved, dir == response, propAt, "%s removed", describe(where, propField, ""))
+		case !inOld:
+			required := newRequired[prop]
+			c.report(KindAdded, dir == request && required, propAt, "%s added", describe(where, propField, optionality(required)+" "))
+		default:
+			if oldRequired[prop] != newRequired[prop] {
+				// New required request properties break clients that do not
+				// send them; optional response properties break clients that
+				// expect them.
+				required := newRequired[prop]
+				c.report(KindChanged, required == (dir == request), at.join("required"), "%s became %s", describe(where, propField, ""), optionality(required))
+			}
+			c.schema(oldProp, newProp, propAt, where, propField, dir, depth+1)
+		}
+	}
+
+	if oldItems, newItems := oldS["items"], newS["items"]; oldItems != nil && newItems != nil {
+		if field == "" {
+			c.schema(oldItems, newItems, at.join("items"), where+" items", "", dir, depth+1)
+		} else {
+			c.schema(oldItems, newItems, at.join("items"), where, field+"[]", dir, depth+1)
+		}
+	}
+}
+
+// describe names a value of a body or parameter, e.g.
+// `response 200 optional property "owner.name"`.
+func describe(where, field, qualifier string) string {
+	if field == "" {
+		return where
+	}
+	return fmt.Sprintf("%s %sproperty %q", where, qualifier, field)
+}
+
+// enum compares the allowed values of two schemas. Fewer allowed values break
+// requests; more allowed values break clients reading responses.
+func (c *comparer) enum(oldS, newS object, at location, name string, dir direction) {
+	oldEnum, oldOK := oldS["enum"].([]interface{})
+	newEnum, newOK := newS["enum"].([]interface{})
+	at = at.join("enum")
+	switch {
+	case !oldOK && !newOK:
+	case !newOK:
+		c.report(KindChanged, dir == response, at, "%s no longer restricts its values", name)
+	case !oldOK:
+		c.report(KindChanged, dir == request, at, "%s values restricted to %s", name, formatValues(newEnum))
+	default:
+		if removed := missing(oldEnum, newEnum); len(removed) > 0 {
+			c.report(KindChanged, dir == request, at, "%s values %s removed", name, formatValues(removed))
+		}
+		if added := missing(newEnum, oldEnum); len(added) > 0 {
+			c.report(KindChanged, dir == response, at, "%s values %s added", name, formatValues(added))
+		}
+	}
+}
+
+// flatten resolves a schema and merges the properties, required properties
+// and type of its allOf subschemas into it.
+func (d *document) flatten(v interface{}, depth int) object {
+	s := d.resolve(v)
+	allOf, ok := s["allOf"].([]interface{})
+	if !ok || depth > maxDepth {
+		return s
+	}
+
+	merged := make(object, len(s))
+	props := make(object)
+	var required []interface{}
+	merge := func(sub object) {
+		if p, ok := sub["properties"].(object); ok {
+			for k, v := range p {
+				props[k] = v
+			}
+		}
+		if r, ok := sub["required"].([]interface{}); ok {
+			required = append(required, r...)
+		}
+		if t, ok := sub["type"]; ok {
+			merged["type"] = t
+		}
+	}
+	for _, sub := range allOf {
+		if sub := d.flatten(sub, depth+1); sub != nil {
+			merge(sub)
+		}
+	}
+	for k, v := range s {
+		if k != "allOf" && k != "properties" && k != "required" {
+			merged[k] = v
+		}
+	}
+	merge(s)
+	if len(props) > 0 {
+		merged["properties"] = props
+	}
+	if len(required) > 0 {
+		merged["required"] = required
+	}
+	return merged
+}
+
+// typeOf returns the type of a schema, e.g. "string" or "integer|null".
+func typeOf(s object) string {
+	switch t := s["type"].(type) {
+	case string:
+		if s["nullable"] == true {
+			return t + "|null"
+		}
+		return t
+	case []interface{}:
+		names := make([]string, 0, len(t))
+		for _, v := range t {
+			if name, ok := v.(string); ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return strings.Join(names, "|")
+	}
+	return ""
+}
+
+func requiredSet(s object) map[string]bool {
+	set := make(map[string]bool)
+	list, _ := s["required"].([]interface{})
+	for _, v := range list {
+		if name, ok := v.(string); ok {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// missing returns the values of a that are not in b.
+func missing(a, b []interface{}) []interface{} {
+	var out []interface{}
+	for _, v := range a {
+		found := false
+		for _, w := range b {
+			if reflect.DeepEqual(v, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func formatValues(values []interface{}) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		b, _ := json.Marshal(v)
+		parts = append(parts, string(b))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// unionKeys returns the keys of a and b in order.
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make(map[string]interface{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = nil
+	}
+	for k := range b {
+		keys[k] = nil
+	}
+	return sortedKeys(keys)
+}
@@ -0,0 +1,112 @@
+// Package diff compares two versions of an OpenAPI document and classifies
+// the changes as breaking or non-breaking for clients.
+//
+// Paths, operations, parameters, request bodies and responses are compared,
+// including the schemas of request and response bodies. A change is breaking
+// when a client written against the old document may fail with the new one,
+// such as a removed operation, a new required parameter or a property that
+// responses no longer include. Swagger 2.0, OpenAPI 3.0 and 3.1 documents in
+// JSON or YAML are supported.
+package diff
+
+import (
+	"sort"
+	"strings"
+)
+
+// Kind is the kind of a change.
+type Kind string
+
+const (
+	KindAdded   Kind = "added"
+	KindRemoved Kind = "removed"
+	KindChanged Kind = "changed"
+)
+
+// Change is a difference between two documents.
+type Change struct {
+	Kind     Kind   `json:"kind"`             // Whether something was added, removed or changed
+	Breaking bool   `json:"breaking"`         // Whether clients of the old document may break
+	Path     string `json:"path"`             // Path template, e.g. "/pets/{id}"
+	Method   string `json:"method,omitempty"` // Upper-case method, empty for path changes
+	Pointer  string `json:"pointer"`          // JSON pointer to the change, in the old document for removals
+	Message  string `json:"message"`          // Description of the change
+}
+
+// Operation returns the method and path of the change, e.g. "GET /pets".
+func (c Change) Operation() string {
+	if c.Method == "" {
+		return c.Path
+	}
+	return c.Method + " " + c.Path
+}
+
+func (c Change) String() string {
+	return c.Operation() + ": " + c.Message
+}
+
+// Report lists the changes between two documents.
+type Report struct {
+	Changes []Change `json:"changes"` // Changes ordered by path and method
+}
+
+// Breaking returns the breaking changes.
+func (r *Report) Breaking() []Change {
+	return r.filter(true)
+}
+
+// NonBreaking returns the changes that do not break clients.
+func (r *Report) NonBreaking() []Change {
+	return r.filter(false)
+}
+
+// HasBreaking reports whether a change is breaking.
+func (r *Report) HasBreaking() bool {
+	return len(r.Breaking()) > 0
+}
+
+func (r *Report) filter(breaking bool) []Change {
+	changes := []Change{}
+	for _, c := range r.Changes {
+		if c.Breaking == breaking {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Compare returns the changes from the old to the new document.
+func Compare(old, new []byte) (*Report, error) {
+	oldDoc, err := parse(old)
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := parse(new)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &comparer{old: oldDoc, new: newDoc, seen: make(map[[2]uintptr]bool)}
+	c.paths()
+	return &Report{Changes: c.changes}, nil
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pointer returns the JSON pointer made of tokens.
+func pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return b.String()
+}
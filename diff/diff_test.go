@@ -0,0 +1,220 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/diff"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const base = `openapi: 3.0.3
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    get:
+      parameters:
+        - {name: fields, in: query, schema: {type: string, enum: [name, tag]}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Pet"}
+      responses:
+        "204": {description: Updated}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        tag: {type: string}
+        owner:
+          type: object
+          properties:
+            name: {type: string}
+        children: {type: array, items: {$ref: "#/components/schemas/Pet"}}
+`
+
+// change returns a document made from base with old replaced by new.
+func change(t *testing.T, old, new string) string {
+	t.Helper()
+	require.Contains(t, base, old)
+	return replaceOnce(base, old, new)
+}
+
+func replaceOnce(s, old, new string) string {
+	for i := 0; i+len(old) <= len(s); i++ {
+		if s[i:i+len(old)] == old {
+			return s[:i] + new + s[i+len(old):]
+		}
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []diff.Change
+	}{
+		{
+			name: "when the documents are equal",
+			new:  base,
+			want: nil,
+		},
+		{
+			name: "when a path parameter is renamed",
+			new:  replaceOnce(replaceOnce(base, "/pets/{id}", "/pets/{petId}"), "name: id,", "name: petId,"),
+			want: []diff.Change{
+				{Kind: diff.KindRemoved, Path: "/pets/{petId}", Method: "GET", Pointer: "/paths/~1pets~1{id}/parameters/0", Message: `path parameter "id" removed`},
+				{Kind: diff.KindAdded, Breaking: true, Path: "/pets/{petId}", Method: "GET", Pointer: "/paths/~1pets~1{petId}/parameters/0", Message: `required path parameter "petId" added`},
+				{Kind: diff.KindRemoved, Path: "/pets/{petId}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/parameters/0", Message: `path parameter "id" removed`},
+				{Kind: diff.KindAdded, Breaking: true, Path: "/pets/{petId}", Method: "PUT", Pointer: "/paths/~1pets~1{petId}/parameters/0", Message: `required path parameter "petId" added`},
+			},
+		},
+		{
+			name: "when paths and operations change",
+			new:  change(t, "    put:\n", "    delete:\n"),
+			want: []diff.Change{
+				{Kind: diff.KindRemoved, Breaking: true, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put", Message: "operation removed"},
+				{Kind: diff.KindAdded, Path: "/pets/{id}", Method: "DELETE", Pointer: "/paths/~1pets~1{id}/delete", Message: "operation added"},
+			},
+		},
+		{
+			name: "when a path is removed",
+			new:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1.0\"}\npaths:\n  /owners: {}\n",
+			want: []diff.Change{
+				{Kind: diff.KindAdded, Path: "/owners", Pointer: "/paths/~1owners", Message: "path added"},
+				{Kind: diff.KindRemoved, Breaking: true, Path: "/pets/{id}", Pointer: "/paths/~1pets~1{id}", Message: "path removed"},
+			},
+		},
+		{
+			name: "when parameters change",
+			new:  change(t, "{name: fields, in: query, schema: {type: string, enum: [name, tag]}}", "{name: fields, in: query, required: true, schema: {type: string, enum: [name]}}\n        - {name: limit, in: query, schema: {type: integer}}"),
+			want: []diff.Change{
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/get/parameters/0/required", Message: `query parameter "fields" became required`},
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/get/parameters/0/schema/enum", Message: `query parameter "fields" values "tag" removed`},
+				{Kind: diff.KindAdded, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/get/parameters/1", Message: `optional query parameter "limit" added`},
+			},
+		},
+		{
+			name: "when a parameter type changes",
+			new:  change(t, "{name: id, in: path, required: true, schema: {type: string}}", "{name: id, in: path, required: true, schema: {type: integer}}"),
+			want: []diff.Change{
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/parameters/0/schema/type", Message: `path parameter "id" type changed from string to integer`},
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/parameters/0/schema/type", Message: `path parameter "id" type changed from string to integer`},
+			},
+		},
+		{
+			name: "when schema properties change",
+			new:  change(t, "      required: [name]\n      properties:\n        name: {type: string}\n        tag: {type: string}\n", "      required: [name, tag]\n      properties:\n        tag: {type: string}\n        age: {type: integer}\n"),
+			want: []diff.Change{
+				{Kind: diff.KindAdded, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/age", Message: `response 200 optional property "age" added`},
+				{Kind: diff.KindRemoved, Breaking: true, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/name", Message: `response 200 property "name" removed`},
+				{Kind: diff.KindChanged, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/required", Message: `response 200 property "tag" became required`},
+				{Kind: diff.KindAdded, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/requestBody/content/application~1json/schema/properties/age", Message: `request body optional property "age" added`},
+				{Kind: diff.KindRemoved, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/requestBody/content/application~1json/schema/properties/name", Message: `request body property "name" removed`},
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/requestBody/content/application~1json/schema/required", Message: `request body property "tag" became required`},
+			},
+		},
+		{
+			name: "when nested properties change",
+			new:  change(t, "            name: {type: string}\n", "            name: {type: integer}\n"),
+			want: []diff.Change{
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "GET", Pointer: "/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/owner/properties/name/type", Message: `response 200 property "owner.name" type changed from string to integer`},
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/requestBody/content/application~1json/schema/properties/owner/properties/name/type", Message: `request body property "owner.name" type changed from string to integer`},
+			},
+		},
+		{
+			name: "when bodies change",
+			new:  change(t, "      requestBody:\n        content:\n          application/json:", "      requestBody:\n        required: true\n        content:\n          application/xml:"),
+			want: []diff.Change{
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/requestBody", Message: "request body became required"},
+				{Kind: diff.KindRemoved, Breaking: true, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/requestBody/content/application~1json", Message: `request body media type "application/json" removed`},
+				{Kind: diff.KindAdded, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/requestBody/content/application~1xml", Message: `request body media type "application/xml" added`},
+			},
+		},
+		{
+			name: "when responses change",
+			new:  change(t, `        "204": {description: Updated}`, `        "200": {description: Updated}`+"\n      deprecated: true"),
+			want: []diff.Change{
+				{Kind: diff.KindChanged, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/deprecated", Message: "operation deprecated"},
+				{Kind: diff.KindAdded, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/responses/200", Message: "response 200 added"},
+				{Kind: diff.KindRemoved, Breaking: true, Path: "/pets/{id}", Method: "PUT", Pointer: "/paths/~1pets~1{id}/put/responses/204", Message: "response 204 removed"},
+			},
+		},
+		{
+			name: "when Swagger 2.0 documents change",
+			old:  "swagger: \"2.0\"\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    post:\n      parameters:\n        - {name: body, in: body, schema: {$ref: \"#/definitions/Pet\"}}\n      responses:\n        200: {description: OK, schema: {type: array, items: {$ref: \"#/definitions/Pet\"}}}\ndefinitions:\n  Pet: {properties: {name: {type: string, enum: [a]}}}\n",
+			new:  "swagger: \"2.0\"\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    post:\n      parameters:\n        - {name: body, in: body, required: true, schema: {$ref: \"#/definitions/Pet\"}}\n      responses:\n        200: {description: OK, schema: {type: array, items: {$ref: \"#/definitions/Pet\"}}}\ndefinitions:\n  Pet: {properties: {name: {type: string, enum: [a, b]}}}\n",
+			want: []diff.Change{
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets", Method: "POST", Pointer: "/paths/~1pets/post/parameters/0", Message: "request body became required"},
+				{Kind: diff.KindChanged, Path: "/pets", Method: "POST", Pointer: "/paths/~1pets/post/parameters/0/schema/properties/name/enum", Message: `request body property "name" values "b" added`},
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets", Method: "POST", Pointer: "/paths/~1pets/post/responses/200/schema/items/properties/name/enum", Message: `response 200 items property "name" values "b" added`},
+			},
+		},
+		{
+			name: "when allOf schemas change",
+			old:  "openapi: 3.1.0\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    get:\n      responses:\n        200:\n          description: OK\n          content:\n            application/json:\n              schema:\n                allOf:\n                  - {properties: {id: {type: integer}}, required: [id]}\n                  - {properties: {name: {type: string}}}\n",
+			new:  "openapi: 3.1.0\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    get:\n      responses:\n        200:\n          description: OK\n          content:\n            application/json:\n              schema:\n                allOf:\n                  - {properties: {id: {type: [integer, \"null\"]}}}\n                  - {properties: {name: {type: string}}}\n",
+			want: []diff.Change{
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets", Method: "GET", Pointer: "/paths/~1pets/get/responses/200/content/application~1json/schema/required", Message: `response 200 property "id" became optional`},
+				{Kind: diff.KindChanged, Breaking: true, Path: "/pets", Method: "GET", Pointer: "/paths/~1pets/get/responses/200/content/application~1json/schema/properties/id/type", Message: `response 200 property "id" type changed from integer to integer|null`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := tt.old
+			if old == "" {
+				old = base
+			}
+			report, err := diff.Compare([]byte(old), []byte(tt.new))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, report.Changes)
+		})
+	}
+}
+
+func TestCompareTestdata(t *testing.T) {
+	yaml, err := testdata.FS.ReadFile("petstore.yaml")
+	require.NoError(t, err)
+	json, err := testdata.FS.ReadFile("petstore.json")
+	require.NoError(t, err)
+
+	report, err := diff.Compare(yaml, json)
+	require.NoError(t, err)
+	assert.Empty(t, report.Changes, "formats are compared by content")
+	assert.False(t, report.HasBreaking())
+}
+
+func TestCompareErrors(t *testing.T) {
+	_, err := diff.Compare([]byte("openapi: [3.0"), []byte(base))
+	assert.Error(t, err)
+	_, err = diff.Compare([]byte(base), []byte("null"))
+	assert.Error(t, err)
+	_, err = diff.Compare([]byte(base), []byte("[]"))
+	assert.Error(t, err)
+}
+
+func TestCompareCycles(t *testing.T) {
+	doc := func(typ string) string {
+		return "openapi: 3.0.3\ninfo: {title: T, version: \"1\"}\npaths:\n  /nodes:\n    get:\n      responses:\n        200:\n          description: OK\n          content:\n            application/json:\n              schema: {$ref: \"#/components/schemas/Node\"}\ncomponents:\n  schemas:\n    Node:\n      allOf:\n        - {$ref: \"#/components/schemas/Base\"}\n      properties:\n        next: {$ref: \"#/components/schemas/Node\"}\n    Base:\n      properties:\n        id: {type: " + typ + "}\n"
+	}
+	report, err := diff.Compare([]byte(doc("string")), []byte(doc("integer")))
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 1)
+	assert.Equal(t, `response 200 property "id" type changed from string to integer`, report.Changes[0].Message)
+}
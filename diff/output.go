@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is an output format of reports.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+)
+
+// Write writes r to w in format.
+func Write(w io.Writer, format Format, r *Report) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, r)
+	case FormatMarkdown:
+		return WriteMarkdown(w, r)
+	case FormatJSON:
+		return WriteJSON(w, r)
+	default:
+		return fmt.Errorf("unknown diff output format %q: use text, markdown or json", format)
+	}
+}
+
+// WriteText writes the breaking changes, then the other ones, one per line.
+func WriteText(w io.Writer, r *Report) error {
+	var b strings.Builder
+	if len(r.Changes) == 0 {
+		b.WriteString("No changes.\n")
+	}
+	for _, section := range sections(r) {
+		fmt.Fprintf(&b, "%s (%d):\n", section.title, len(section.changes))
+		for _, c := range section.changes {
+			fmt.Fprintf(&b, "  %s\n", c)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMarkdown writes the changes as Markdown lists, e.g. for release notes.
+func WriteMarkdown(w io.Writer, r *Report) error {
+	var b strings.Builder
+	b.WriteString("# API Changes\n")
+	if len(r.Changes) == 0 {
+		b.WriteString("\nNo changes.\n")
+	}
+	for _, section := range sections(r) {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+		for _, c := range section.changes {
+			fmt.Fprintf(&b, "- `%s`: %s\n", c.Operation(), c.Message)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the number of breaking changes and the changes.
+func WriteJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	changes := r.Changes
+	if changes == nil {
+		changes = []Change{}
+	}
+	return enc.Encode(struct {
+		Breaking int      `json:"breaking"`
+		Changes  []Change `json:"changes"`
+	}{len(r.Breaking()), changes})
+}
+
+type section struct {
+	title   string
+	changes []Change
+}
+
+// sections groups the changes of r, omitting empty groups.
+func sections(r *Report) []section {
+	var out []section
+	if changes := r.Breaking(); len(changes) > 0 {
+		out = append(out, section{"Breaking changes", changes})
+	}
+	if changes := r.NonBreaking(); len(changes) > 0 {
+		out = append(out, section{"Non-breaking changes", changes})
+	}
+	return out
+}
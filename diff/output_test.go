@@ -0,0 +1,55 @@
+package diff_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReport() *diff.Report {
+	return &diff.Report{Changes: []diff.Change{
+		{Kind: diff.KindAdded, Path: "/owners", Pointer: "/paths/~1owners", Message: "path added"},
+		{Kind: diff.KindRemoved, Breaking: true, Path: "/pets", Method: "DELETE", Pointer: "/paths/~1pets/delete", Message: "operation removed"},
+	}}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, diff.Write(&buf, diff.FormatText, newReport()))
+	assert.Equal(t, "Breaking changes (1):\n  DELETE /pets: operation removed\nNon-breaking changes (1):\n  /owners: path added\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, diff.WriteText(&buf, &diff.Report{}))
+	assert.Equal(t, "No changes.\n", buf.String())
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, diff.Write(&buf, diff.FormatMarkdown, newReport()))
+	assert.Equal(t, "# API Changes\n\n## Breaking changes\n\n- `DELETE /pets`: operation removed\n\n## Non-breaking changes\n\n- `/owners`: path added\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, diff.WriteMarkdown(&buf, &diff.Report{}))
+	assert.Equal(t, "# API Changes\n\nNo changes.\n", buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, diff.Write(&buf, diff.FormatJSON, newReport()))
+	assert.JSONEq(t, `{"breaking": 1, "changes": [
+		{"kind": "added", "breaking": false, "path": "/owners", "pointer": "/paths/~1owners", "message": "path added"},
+		{"kind": "removed", "breaking": true, "path": "/pets", "method": "DELETE", "pointer": "/paths/~1pets/delete", "message": "operation removed"}
+	]}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, diff.WriteJSON(&buf, &diff.Report{}))
+	assert.JSONEq(t, `{"breaking": 0, "changes": []}`, buf.String())
+}
+
+func TestWriteErrors(t *testing.T) {
+	err := diff.Write(&bytes.Buffer{}, "html", newReport())
+	assert.EqualError(t, err, `unknown diff output format "html": use text, markdown or json`)
+}
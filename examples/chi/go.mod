@@ -5,6 +5,11 @@ go 1.20
 require (
 	github.com/go-chi/chi/v5 v5.2.2
 	github.com/oaswrap/spec-ui v0.0.0
+	github.com/oaswrap/spec-ui/adapters/chi v0.0.0
 )
 
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
 replace github.com/oaswrap/spec-ui => ../..
+
+replace github.com/oaswrap/spec-ui/adapters/chi => ../../adapters/chi
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	specui "github.com/oaswrap/spec-ui"
+	chiadapter "github.com/oaswrap/spec-ui/adapters/chi"
 	"github.com/oaswrap/spec-ui/stoplight"
 )
 
@@ -21,11 +22,7 @@ func main() {
 		stoplight.WithUI(),
 	)
 
-	r.Get(handler.DocsPath(), handler.DocsFunc())
-	r.Get(handler.SpecPath(), handler.SpecFunc())
-	if handler.AssetsEnabled() {
-		r.Handle(handler.AssetsPath()+"/*", handler.Assets())
-	}
+	chiadapter.Register(r, handler)
 
 	log.Printf("OpenAPI Documentation available at http://localhost:3000/docs")
 	log.Printf("OpenAPI YAML available at http://localhost:3000/docs/openapi.yaml")
@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	github.com/labstack/echo/v4 v4.13.4
 	github.com/oaswrap/spec-ui v0.0.0
+	github.com/oaswrap/spec-ui/adapters/echo v0.0.0
 )
 
 require (
@@ -17,6 +18,9 @@ require (
 	golang.org/x/net v0.40.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/oaswrap/spec-ui => ../..
+
+replace github.com/oaswrap/spec-ui/adapters/echo => ../../adapters/echo
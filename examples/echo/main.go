@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	specui "github.com/oaswrap/spec-ui"
+	echoadapter "github.com/oaswrap/spec-ui/adapters/echo"
 	"github.com/oaswrap/spec-ui/swaggerui"
 )
 
@@ -20,11 +21,7 @@ func main() {
 		swaggerui.WithUI(),
 	)
 
-	e.GET(handler.DocsPath(), echo.WrapHandler(handler.Docs()))
-	e.GET(handler.SpecPath(), echo.WrapHandler(handler.Spec()))
-	if handler.AssetsEnabled() {
-		e.GET(handler.AssetsPath()+"/*", echo.WrapHandler(handler.Assets()))
-	}
+	echoadapter.Register(e, handler)
 
 	log.Printf("OpenAPI Documentation available at http://localhost:3000/docs")
 	log.Printf("OpenAPI YAML available at http://localhost:3000/docs/openapi.yaml")
@@ -5,6 +5,7 @@ go 1.20
 require (
 	github.com/gofiber/fiber/v2 v2.52.12
 	github.com/oaswrap/spec-ui v0.0.0
+	github.com/oaswrap/spec-ui/adapters/fiber v0.0.0
 )
 
 require (
@@ -14,11 +15,17 @@ require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/oaswrap/spec-ui/adapters/fasthttp v0.0.0 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasthttp v1.51.0 // indirect
 	github.com/valyala/tcplisten v1.0.0 // indirect
 	golang.org/x/sys v0.28.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/oaswrap/spec-ui => ../..
+
+replace github.com/oaswrap/spec-ui/adapters/fiber => ../../adapters/fiber
+
+replace github.com/oaswrap/spec-ui/adapters/fasthttp => ../../adapters/fasthttp
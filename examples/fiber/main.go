@@ -4,8 +4,8 @@ import (
 	"log"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	specui "github.com/oaswrap/spec-ui"
+	fiberadapter "github.com/oaswrap/spec-ui/adapters/fiber"
 	"github.com/oaswrap/spec-ui/scalar"
 )
 
@@ -21,11 +21,7 @@ func main() {
 		scalar.WithUI(),
 	)
 
-	app.Get(handler.DocsPath(), adaptor.HTTPHandler(handler.Docs()))
-	app.Get(handler.SpecPath(), adaptor.HTTPHandler(handler.Spec()))
-	if handler.AssetsEnabled() {
-		app.Get(handler.AssetsPath()+"/*", adaptor.HTTPHandler(handler.Assets()))
-	}
+	fiberadapter.Register(app, handler)
 
 	log.Printf("OpenAPI Documentation available at http://localhost:3000/docs")
 	log.Printf("OpenAPI YAML available at http://localhost:3000/docs/openapi.yaml")
@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	github.com/gin-gonic/gin v1.10.1
 	github.com/oaswrap/spec-ui v0.0.0
+	github.com/oaswrap/spec-ui/adapters/gin v0.0.0
 )
 
 require (
@@ -37,3 +38,5 @@ require (
 )
 
 replace github.com/oaswrap/spec-ui => ../..
+
+replace github.com/oaswrap/spec-ui/adapters/gin => ../../adapters/gin
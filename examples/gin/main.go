@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	specui "github.com/oaswrap/spec-ui"
+	ginadapter "github.com/oaswrap/spec-ui/adapters/gin"
 	"github.com/oaswrap/spec-ui/swaggerui"
 )
 
@@ -21,11 +22,7 @@ func main() {
 		swaggerui.WithUI(),
 	)
 
-	r.GET(handler.DocsPath(), gin.WrapH(handler.Docs()))
-	r.GET(handler.SpecPath(), gin.WrapH(handler.Spec()))
-	if handler.AssetsEnabled() {
-		r.GET(handler.AssetsPath()+"/*filepath", gin.WrapH(handler.Assets()))
-	}
+	ginadapter.Register(r, handler)
 
 	log.Printf("OpenAPI Documentation available at http://localhost:3000/docs")
 	log.Printf("OpenAPI YAML available at http://localhost:3000/docs/openapi.yaml")
@@ -4,4 +4,6 @@ go 1.22
 
 require github.com/oaswrap/spec-ui v0.0.0
 
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
 replace github.com/oaswrap/spec-ui => ../..
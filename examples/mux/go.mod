@@ -7,4 +7,6 @@ require (
 	github.com/oaswrap/spec-ui v0.0.0
 )
 
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
 replace github.com/oaswrap/spec-ui => ../..
@@ -0,0 +1,134 @@
+package specui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/source"
+)
+
+// exportAssetsDir is the directory holding the embedded assets in an export.
+const exportAssetsDir = "_assets"
+
+// Export writes a static copy of the documentation to dir, so that it can be
+// published on any file server:
+//
+//   - index.html, the docs page
+//   - openapi.json and openapi.yaml, the specification in both formats
+//   - _assets, the UI assets when they are embedded
+//
+// URLs in the docs page are relative to dir, which must be served as a
+// directory (e.g. "/api/", not "/api/index.html"). A specification served
+// from an absolute URL is referenced as is and not exported, and of the
+// versions of a store, see WithSpecStore, only the latest is exported.
+func (h *Handler) Export(dir string) error {
+	if err := h.CheckConfig(); err != nil {
+		return err
+	}
+
+	format := source.FormatYAML
+	if strings.HasSuffix(h.cfg.SpecPath, ".json") {
+		format = source.FormatJSON
+	}
+	remoteSpec := strings.HasPrefix(h.cfg.SpecPath, "https://") || strings.HasPrefix(h.cfg.SpecPath, "http://")
+
+	cfg := *h.cfg
+	if !remoteSpec {
+		cfg.SpecPath = "./openapi." + format
+	}
+	cfg.AssetsPath = "./" + exportAssetsDir
+	// The export holds the latest version only: the version selector needs
+	// the versions path of a server.
+	cfg.Versions.Store = nil
+	if f, ok := cfg.AssetsFS.(*asset.FS); ok {
+		// Fingerprinting only matters for the far-future caching of Assets.
+		cfg.AssetsFS = f.FS
+	}
+
+	rec := httptest.NewRecorder()
+	cfg.DocsHandlerFactory(&cfg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		return fmt.Errorf("export: rendering docs page: status %d", rec.Code)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), rec.Body.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	if !remoteSpec {
+		for _, format := range []string{source.FormatJSON, source.FormatYAML} {
+			b, err := spec.Load(h.cfg, format)
+			if err != nil {
+				return fmt.Errorf("export: loading specification: %w", err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "openapi."+format), b, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cfg.EmbedAssets && cfg.AssetsFS != nil {
+		return copyFS(filepath.Join(dir, exportAssetsDir), cfg.AssetsFS)
+	}
+	return nil
+}
+
+// ExportHTML writes the documentation as a single HTML page to w, with the UI
+// assets and the specification inlined, so that it can be opened from disk or
+// shared as an attachment. It requires a provider *emb package.
+func (h *Handler) ExportHTML(w io.Writer) error {
+	if err := h.CheckConfig(); err != nil {
+		return err
+	}
+	if !h.cfg.EmbedAssets || h.cfg.AssetsFS == nil {
+		return errors.New("export: single-file HTML requires embedded assets: use a provider *emb package")
+	}
+
+	cfg := *h.cfg
+	cfg.InlineAssets = true
+	cfg.InlineSpec = true
+	cfg.Versions.Store = nil
+
+	// Load the specification up front: once rendering has started, an error
+	// could only be reported inside the page.
+	if _, err := h.specHandler().Load(); err != nil {
+		return fmt.Errorf("export: loading specification: %w", err)
+	}
+
+	rec := httptest.NewRecorder()
+	cfg.DocsHandlerFactory(&cfg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		return fmt.Errorf("export: rendering docs page: status %d", rec.Code)
+	}
+	_, err := w.Write(rec.Body.Bytes())
+	return err
+}
+
+func copyFS(dir string, fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(name))
+		if d.IsDir() {
+			return os.MkdirAll(dst, 0o755)
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, b, 0o644)
+	})
+}
@@ -1,30 +1,58 @@
 package specui
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io/fs"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/diff"
+	"github.com/oaswrap/spec-ui/internal/asset"
 	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/lint"
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/validate"
 )
 
+var errNoChangelog = errors.New("no previous OpenAPI specification configured: use WithChangelog")
+
+var errNoProvider = errors.New("no UI provider configured: use WithSwaggerUI, WithStoplightElements, WithReDoc, WithScalar, or WithRapiDoc")
+
 // NewHandler creates a new HTTP handler for the OpenAPI UI.
 //
 // It applies the provided options to configure the OpenAPI UI.
 func NewHandler(opts ...Option) *Handler {
 	cfg := newConfig(opts...)
 
-	return &Handler{cfg: cfg}
+	h := &Handler{cfg: cfg}
+	// Docs pages inlining the specification show the one served at the spec
+	// path.
+	cfg.SpecLoader = func(ctx context.Context) ([]byte, string, error) {
+		return h.specHandler().Current(ctx)
+	}
+	return h
 }
 
 // Handler handles HTTP requests for the OpenAPI UI.
 type Handler struct {
-	cfg         *config.SpecUI
-	docsOnce    sync.Once
-	docsHandler http.Handler
-	assetsOnce  sync.Once
-	assets      http.Handler
+	cfg           *config.SpecUI
+	docsOnce      sync.Once
+	docsHandler   http.Handler
+	assetsOnce    sync.Once
+	assets        http.Handler
+	specOnce      sync.Once
+	spec          *spec.Handler
+	changelogOnce sync.Once
+	changelog     http.Handler
+	versionsOnce  sync.Once
+	versions      http.Handler
+	contents      contentCache
 }
 
 // DocsPath returns the path to the API documentation.
@@ -47,21 +75,159 @@ func (h *Handler) AssetsPath() string {
 	return h.cfg.AssetsPath
 }
 
+// CheckConfig reports configuration errors that would otherwise surface as a
+// panic when the documentation handler is first created.
+func (h *Handler) CheckConfig() error {
+	if h.cfg.DocsHandlerFactory == nil {
+		return errNoProvider
+	}
+	if err := asset.Check(h.cfg); err != nil {
+		return err
+	}
+	if h.cfg.Offline {
+		if err := h.checkOffline(); err != nil {
+			return err
+		}
+	}
+	if h.cfg.Validation != config.ValidationOff {
+		problems, err := h.Validate()
+		if err != nil && h.cfg.Validation == config.ValidationFail {
+			return err
+		}
+		if len(problems) > 0 && h.cfg.Validation == config.ValidationFail {
+			return problems
+		}
+	}
+	return nil
+}
+
+// LoadSpec loads the OpenAPI specification now rather than on the first
+// request, and returns the error of the load, e.g. to fail at startup when
+// the specification file cannot be read. The error is the underlying one,
+// such as an *fs.PathError, not the message shown to clients. LoadSpec
+// returns the error of ctx when it is done before the load starts, or while
+// it waits for a load started by a request. The load itself is not canceled
+// with ctx, since requests wait for it too; it times out after a minute.
+//
+// A load that fails with an error that may not happen again, such as an I/O
+// failure reading the file, is retried by the next call or request; other
+// results are cached.
+func (h *Handler) LoadSpec(ctx context.Context) error {
+	_, err := h.specHandler().LoadContext(ctx)
+	return err
+}
+
+// Validate loads the OpenAPI specification and checks it against the JSON
+// Schema of its version (Swagger 2.0, OpenAPI 3.0 or 3.1). It returns the
+// problems found, each locating the invalid value with a JSON pointer, or an
+// error when the specification cannot be loaded or parsed.
+func (h *Handler) Validate() (validate.Errors, error) {
+	return h.specHandler().Validate()
+}
+
+func (h *Handler) checkOffline() error {
+	if !h.cfg.EmbedAssets {
+		return errors.New("offline mode requires embedded assets: use a provider *emb package")
+	}
+
+	// URLs in an inlined specification are data, not resources of the page.
+	cfg := *h.cfg
+	cfg.InlineSpec = false
+
+	rec := httptest.NewRecorder()
+	cfg.DocsHandlerFactory(&cfg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.cfg.DocsPath, nil))
+	if urls := asset.ExternalURLs(h.cfg.DocsPath, rec.Body.Bytes()); len(urls) > 0 {
+		return fmt.Errorf("offline mode: docs page references external URLs: %s", strings.Join(urls, ", "))
+	}
+
+	// The provider bundles load fonts and scripts of their own at runtime.
+	if h.cfg.AssetsFS == nil {
+		return nil
+	}
+	return fs.WalkDir(h.cfg.AssetsFS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := fs.ReadFile(h.cfg.AssetsFS, name)
+		if err != nil {
+			return err
+		}
+		if urls := asset.ExternalURLs(name, b); len(urls) > 0 {
+			return fmt.Errorf("offline mode: asset %s references external URLs: %s", name, strings.Join(urls, ", "))
+		}
+		return nil
+	})
+}
+
+// Lint loads the OpenAPI specification and checks it against the style rules
+// of cfg, see package lint.
+func (h *Handler) Lint(cfg lint.Config) (*lint.Report, error) {
+	b, err := h.specHandler().Load()
+	if err != nil {
+		return nil, err
+	}
+	return lint.Lint(b, cfg)
+}
+
+// Diff loads the OpenAPI specification and compares it with the previous one
+// configured with WithChangelog, see package diff.
+func (h *Handler) Diff() (*diff.Report, error) {
+	if h.cfg.Changelog.SpecFile == "" {
+		return nil, errNoChangelog
+	}
+	var src config.SpecSource = source.File{Path: h.cfg.Changelog.SpecFile}
+	if h.cfg.Changelog.SpecFS != nil {
+		src = source.FS{FS: h.cfg.Changelog.SpecFS, Path: h.cfg.Changelog.SpecFile}
+	}
+	previous := &config.SpecUI{
+		SpecSource:     src,
+		ConvertSwagger: h.cfg.ConvertSwagger,
+		Redaction:      h.cfg.Redaction,
+	}
+	old, err := spec.Load(previous, source.FormatJSON)
+	if err != nil {
+		return nil, fmt.Errorf("previous specification: %w", err)
+	}
+	b, err := h.specHandler().Load()
+	if err != nil {
+		return nil, err
+	}
+	return diff.Compare(old, b)
+}
+
 // Docs returns the HTTP handler for the API documentation.
 // The handler is created once and cached for subsequent calls.
 func (h *Handler) Docs() http.Handler {
+	return h.observed(observe.Docs, h.docs())
+}
+
+func (h *Handler) docs() http.Handler {
 	if h.cfg.DocsHandlerFactory == nil {
-		panic(errors.New("no UI provider configured: use WithSwaggerUI, WithStoplightElements, WithReDoc, WithScalar, or WithRapiDoc"))
+		panic(errNoProvider)
 	}
 	h.docsOnce.Do(func() {
-		h.docsHandler = h.cfg.DocsHandlerFactory(h.cfg)
+		h.docsHandler = newDocs(h.cfg, h.specHandler())
 	})
 	return h.docsHandler
 }
 
+// newDocs returns the handler of the docs page configured in cfg, which shows
+// the validation problems of the specification of s in page mode.
+func newDocs(cfg *config.SpecUI, s *spec.Handler) http.Handler {
+	docs := cfg.DocsHandlerFactory(cfg)
+	if cfg.Validation == config.ValidationPage {
+		docs = &validationPage{docs: docs, spec: s, cfg: cfg}
+	}
+	return docs
+}
+
 // Assets returns the HTTP handler for embedded UI assets.
 // Returns nil when running in CDN mode.
 func (h *Handler) Assets() http.Handler {
+	return h.observed(observe.Assets, h.assetsHandler())
+}
+
+func (h *Handler) assetsHandler() http.Handler {
 	if h.cfg.AssetsHandlerFactory == nil {
 		return nil
 	}
@@ -71,14 +237,81 @@ func (h *Handler) Assets() http.Handler {
 	return h.assets
 }
 
+// ChangelogEnabled returns true when a previous specification is configured
+// with WithChangelog.
+func (h *Handler) ChangelogEnabled() bool {
+	return h.cfg.Changelog.SpecFile != ""
+}
+
+// ChangelogPath returns the path to the changelog page.
+func (h *Handler) ChangelogPath() string {
+	return h.cfg.Changelog.Path
+}
+
+// Changelog returns the HTTP handler for the changelog page, which lists the
+// changes from the previous specification. The changes are computed on the
+// first request. Returns nil when no previous specification is configured.
+func (h *Handler) Changelog() http.Handler {
+	return h.observed(observe.Changelog, h.changelogHandler())
+}
+
+func (h *Handler) changelogHandler() http.Handler {
+	if !h.ChangelogEnabled() {
+		return nil
+	}
+	h.changelogOnce.Do(func() {
+		h.changelog = &changelogPage{handler: h}
+	})
+	return h.changelog
+}
+
+// VersionsEnabled returns true when a store of versions of the specification
+// is configured with WithSpecStore.
+func (h *Handler) VersionsEnabled() bool {
+	return h.cfg.Versions.Store != nil
+}
+
+// VersionsPath returns the path prefix of the version pages. The versions are
+// listed as JSON at the path itself, and the docs page and the specification
+// of each version are served under it, e.g. at /docs/v/1.0.0 and
+// /docs/v/1.0.0/openapi.json.
+func (h *Handler) VersionsPath() string {
+	return h.cfg.Versions.Path
+}
+
+// Versions returns the HTTP handler for the versions path and the paths under
+// it. The docs page and the specification of a version are created on its
+// first request. Returns nil when no store is configured.
+func (h *Handler) Versions() http.Handler {
+	return h.observed(observe.Versions, h.versionsHandler())
+}
+
+func (h *Handler) versionsHandler() http.Handler {
+	if !h.VersionsEnabled() {
+		return nil
+	}
+	h.versionsOnce.Do(func() {
+		h.versions = &versionsHandler{handler: h}
+	})
+	return h.versions
+}
+
 // DocsFunc returns the HTTP handler function for the API documentation.
 func (h *Handler) DocsFunc() http.HandlerFunc {
 	return h.Docs().ServeHTTP
 }
 
 // Spec returns the HTTP handler for the OpenAPI specification.
+// The handler is created once and cached for subsequent calls.
 func (h *Handler) Spec() http.Handler {
-	return spec.NewHandler(h.cfg)
+	return h.observed(observe.Spec, h.specHandler())
+}
+
+func (h *Handler) specHandler() *spec.Handler {
+	h.specOnce.Do(func() {
+		h.spec = spec.NewHandler(h.cfg)
+	})
+	return h.spec
 }
 
 // SpecFunc returns the HTTP handler function for the OpenAPI specification.
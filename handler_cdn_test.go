@@ -0,0 +1,91 @@
+package specui_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/redoc"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerCDN(t *testing.T) {
+	t.Run("template", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithCDN("https://unpkg.com/{package}@{version}/{file}"),
+			swaggerui.WithUI(),
+		)
+		assert.NoError(t, h.CheckConfig())
+
+		rec := httptest.NewRecorder()
+		h.DocsFunc()(rec, httptest.NewRequest("GET", "/docs", nil))
+
+		assert.Contains(t, rec.Body.String(), "https://unpkg.com/swagger-ui@5.32.1/dist/swagger-ui-bundle.js")
+		assert.Contains(t, rec.Body.String(), `integrity="sha384-`)
+		assert.Contains(t, rec.Body.String(), `crossorigin="anonymous"`)
+	})
+	t.Run("provider version", func(t *testing.T) {
+		h := specui.NewHandler(
+			redoc.WithUI(config.ReDoc{CDN: config.ProviderCDN{Version: "2.4.0"}}),
+		)
+
+		rec := httptest.NewRecorder()
+		h.DocsFunc()(rec, httptest.NewRequest("GET", "/docs", nil))
+
+		assert.Contains(t, rec.Body.String(), "https://cdn.jsdelivr.net/npm/redoc@2.4.0/bundles/redoc.standalone.js")
+		assert.NotContains(t, rec.Body.String(), "integrity=")
+	})
+	t.Run("provider integrity", func(t *testing.T) {
+		h := specui.NewHandler(
+			redoc.WithUI(config.ReDoc{CDN: config.ProviderCDN{
+				Version:   "2.4.0",
+				Integrity: map[string]string{"redoc.standalone.js": "sha384-custom"},
+			}}),
+		)
+
+		rec := httptest.NewRecorder()
+		h.DocsFunc()(rec, httptest.NewRequest("GET", "/docs", nil))
+
+		assert.Contains(t, rec.Body.String(), `integrity="sha384-custom" crossorigin="anonymous"`)
+	})
+	t.Run("insecure", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithCDN("http://mirror.local/{package}@{version}/{file}"),
+			swaggerui.WithUI(),
+		)
+		assert.Error(t, h.CheckConfig())
+		assert.Panics(t, func() { h.Docs() })
+	})
+	t.Run("insecure allowed", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithCDN("http://mirror.local/{package}@{version}/{file}"),
+			specui.WithInsecureCDN(),
+			swaggerui.WithUI(),
+		)
+		assert.NoError(t, h.CheckConfig())
+
+		rec := httptest.NewRecorder()
+		h.DocsFunc()(rec, httptest.NewRequest("GET", "/docs", nil))
+
+		assert.Contains(t, rec.Body.String(), "http://mirror.local/swagger-ui@5.32.1/dist/swagger-ui-bundle.js")
+	})
+	t.Run("ignored in embed mode", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithCDN("http://mirror.local/{package}@{version}/{file}"),
+			swaggeruiemb.WithUI(),
+		)
+		assert.NoError(t, h.CheckConfig())
+
+		rec := httptest.NewRecorder()
+		h.DocsFunc()(rec, httptest.NewRequest("GET", "/docs", nil))
+
+		assert.NotContains(t, rec.Body.String(), "integrity=")
+	})
+	t.Run("no provider", func(t *testing.T) {
+		h := specui.NewHandler()
+		assert.Error(t, h.CheckConfig())
+	})
+}
@@ -0,0 +1,100 @@
+package specui_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/redoc"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerChangelog(t *testing.T) {
+	previous := fstest.MapFS{
+		"v1.yaml": {Data: []byte("openapi: 3.0.3\ninfo: {title: Pets, version: \"0.9\"}\npaths:\n  /legacy:\n    get:\n      responses: {200: {description: OK}}\n")},
+	}
+
+	t.Run("when a previous specification is configured", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithTitle("Pets"),
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithChangelog("v1.yaml", previous),
+			redoc.WithUI(),
+		)
+		require.True(t, h.ChangelogEnabled())
+		assert.Equal(t, "/docs/changelog", h.ChangelogPath())
+
+		report, err := h.Diff()
+		require.NoError(t, err)
+		assert.True(t, report.HasBreaking())
+
+		rec := httptest.NewRecorder()
+		h.Changelog().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.ChangelogPath(), nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "<title>Pets - Changelog</title>")
+		assert.Contains(t, rec.Body.String(), "Breaking changes (1)")
+		assert.Contains(t, rec.Body.String(), "<code>/legacy</code>: path removed")
+		assert.Contains(t, rec.Body.String(), "<code>/pet</code>: path added")
+	})
+	t.Run("when the specification did not change", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithChangelog("testdata/petstore.json", nil),
+			specui.WithChangelogPath("/changes"),
+			redoc.WithUI(),
+		)
+		assert.Equal(t, "/changes", h.ChangelogPath())
+
+		rec := httptest.NewRecorder()
+		h.Changelog().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.ChangelogPath(), nil))
+		assert.Contains(t, rec.Body.String(), "No changes since the previous version.")
+	})
+	t.Run("when the previous specification is missing", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithChangelog("v0.yaml", previous),
+			redoc.WithUI(),
+		)
+		_, err := h.Diff()
+		assert.ErrorContains(t, err, "previous specification")
+
+		rec := httptest.NewRecorder()
+		h.Changelog().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.ChangelogPath(), nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"detail":"failed to compare OpenAPI specifications"`)
+		assert.NotContains(t, rec.Body.String(), "v0.yaml", "the error does not reveal file paths")
+	})
+	t.Run("when the previous specification is added after a failure", func(t *testing.T) {
+		fsys := fstest.MapFS{}
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithChangelog("v1.yaml", fsys),
+			specui.WithLogger(&recordLogger{}),
+			redoc.WithUI(),
+		)
+
+		rec := httptest.NewRecorder()
+		h.Changelog().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.ChangelogPath(), nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		fsys["v1.yaml"] = previous["v1.yaml"]
+		rec = httptest.NewRecorder()
+		h.Changelog().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.ChangelogPath(), nil))
+		assert.Equal(t, http.StatusOK, rec.Code, "failures are not kept")
+		assert.Contains(t, rec.Body.String(), "Breaking changes (1)")
+	})
+	t.Run("when no previous specification is configured", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), redoc.WithUI())
+		assert.False(t, h.ChangelogEnabled())
+		assert.Nil(t, h.Changelog())
+
+		_, err := h.Diff()
+		assert.EqualError(t, err, "no previous OpenAPI specification configured: use WithChangelog")
+	})
+}
@@ -0,0 +1,79 @@
+package specui_test
+
+import (
+	"net/http"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerContent(t *testing.T) {
+	h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS), swaggeruiemb.WithUI())
+
+	tests := []struct {
+		name        string
+		path        string
+		ok          bool
+		status      int
+		contentType string
+	}{
+		{"when requesting the docs", "/docs", true, http.StatusOK, "text/html"},
+		{"when requesting the spec", "/docs/openapi.json", true, http.StatusOK, "application/json"},
+		{"when requesting an asset", "/docs/_assets/swagger-ui.css", true, http.StatusOK, "text/css; charset=utf-8"},
+		{"when requesting an unknown asset", "/docs/_assets/missing.js", true, http.StatusNotFound, "text/plain; charset=utf-8"},
+		{"when the path is not a route", "/docs/other", false, 0, ""},
+		{"when the path only shares the assets prefix", "/docs/_assetsx", false, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := h.Content(tt.path)
+			require.Equal(t, tt.ok, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.status, c.Status)
+			assert.Equal(t, tt.contentType, c.Header.Get("Content-Type"))
+			assert.NotEmpty(t, c.Body)
+		})
+	}
+
+	t.Run("when requesting a path twice", func(t *testing.T) {
+		first, _ := h.Content("/docs")
+		second, _ := h.Content("/docs")
+		assert.Same(t, first, second, "responses are rendered once")
+
+		missing, _ := h.Content("/docs/_assets/missing.js")
+		again, _ := h.Content("/docs/_assets/missing.js")
+		assert.NotSame(t, missing, again, "unknown asset paths are not cached")
+	})
+	t.Run("when negotiating the docs", func(t *testing.T) {
+		c, _ := h.Content("/docs")
+		require.NotNil(t, c.Gzip)
+		assert.Equal(t, "gzip", c.Gzip.Header.Get("Content-Encoding"))
+
+		assert.Same(t, c, c.Negotiate("", ""))
+		assert.Same(t, c.Gzip, c.Negotiate("", "br, gzip"))
+		notModified := c.Negotiate(c.Header.Get("ETag"), "gzip")
+		assert.Equal(t, http.StatusNotModified, notModified.Status)
+		assert.Empty(t, notModified.Body)
+
+		spec, _ := h.Content(h.SpecPath())
+		assert.Nil(t, spec.Gzip, "the spec is not compressed")
+	})
+	t.Run("when the spec depends on the request", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+			specui.WithSpecVariant(func(r *http.Request) string { return r.Host }),
+			swaggerui.WithUI(),
+		)
+		_, ok := h.Content(h.SpecPath())
+		assert.False(t, ok)
+		_, ok = h.Content(h.DocsPath())
+		assert.True(t, ok)
+	})
+}
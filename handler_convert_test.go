@@ -0,0 +1,131 @@
+package specui_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/stoplight"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerSwaggerConversion(t *testing.T) {
+	t.Run("when converting a Swagger 2.0 file", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("swagger.yaml", &testdata.FS),
+			specui.WithSpecPath("/docs/openapi.yaml"),
+			specui.WithSwaggerConversion(),
+			scalar.WithUI(),
+		)
+
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "openapi: 3.0.3\n")
+		assert.Contains(t, rec.Body.String(), "#/components/schemas/Pet")
+		assert.NotContains(t, rec.Body.String(), "#/definitions/")
+	})
+	t.Run("when converting a generated Swagger 2.0 specification", func(t *testing.T) {
+		gen := &specGenerator{json: `{"swagger":"2.0","info":{"title":"Pets","version":"1"},"paths":{"/pets":{"get":{"schemes":["wss"],"responses":{"200":{"description":"OK"}}}}}}`}
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(gen),
+			specui.WithSwaggerConversion(),
+			specui.WithValidation(config.ValidationFail),
+			specui.WithInlineSpec(),
+			stoplight.WithUI(),
+		)
+
+		problems, err := h.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, validate.Errors{
+			{Pointer: "/paths/~1pets/get/schemes", Line: 1, Column: 91, Message: "operation schemes are not converted: OpenAPI 3.0 servers apply to the whole URL"},
+		}, problems, "conversion warnings are reported with validation problems")
+		assert.Equal(t, problems, h.CheckConfig())
+
+		rec := httptest.NewRecorder()
+		h.Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.DocsPath(), nil))
+		assert.Contains(t, rec.Body.String(), `"openapi":"3.0.3"`, "the inlined specification is converted")
+	})
+	t.Run("when the specification is not Swagger 2.0", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+			specui.WithSwaggerConversion(),
+			scalar.WithUI(),
+		)
+
+		want, err := testdata.FS.ReadFile("petstore.json")
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+		assert.Equal(t, string(want), rec.Body.String())
+	})
+	t.Run("when conversion is disabled", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("swagger.yaml", &testdata.FS), scalar.WithUI())
+
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+		assert.Contains(t, rec.Body.String(), `swagger: "2.0"`)
+	})
+}
+
+func TestHandlerOpenAPI31Downgrade(t *testing.T) {
+	const spec31 = `{"openapi":"3.1.0","info":{"title":"Pets","version":"1"},"paths":{},"components":{"schemas":{"Pet":{"type":["object","null"],"examples":[{},{}]}}}}`
+
+	serve := func(t *testing.T, h *specui.Handler) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	t.Run("when downgrading for every provider", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(&specGenerator{json: spec31}),
+			specui.WithOpenAPI31Downgrade(),
+			scalar.WithUI(),
+		)
+		body := serve(t, h)
+		assert.Contains(t, body, `"openapi": "3.0.3"`)
+		assert.Contains(t, body, `"nullable": true`)
+
+		problems, err := h.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, validate.Errors{
+			{Pointer: "/components/schemas/Pet/examples", Line: 1, Column: 137, Message: "only the first of 2 examples is kept"},
+		}, problems)
+	})
+	t.Run("when downgrading for other providers", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(&specGenerator{json: spec31}),
+			specui.WithOpenAPI31Downgrade(config.ProviderStoplightElements),
+			scalar.WithUI(),
+		)
+		assert.Equal(t, spec31, serve(t, h))
+	})
+	t.Run("when downgrading for the selected provider", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(&specGenerator{json: spec31}),
+			specui.WithOpenAPI31Downgrade(config.ProviderStoplightElements, config.ProviderScalar),
+			scalar.WithUI(),
+		)
+		assert.Contains(t, serve(t, h), `"openapi": "3.0.3"`)
+	})
+	t.Run("when composing with the Swagger 2.0 conversion", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("swagger.yaml", &testdata.FS),
+			specui.WithSwaggerConversion(),
+			specui.WithOpenAPI31Downgrade(),
+			stoplight.WithUI(),
+		)
+		body := serve(t, h)
+		assert.Contains(t, body, "openapi: 3.0.3\n")
+		assert.Contains(t, body, "nullable: true")
+	})
+}
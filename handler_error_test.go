@@ -0,0 +1,74 @@
+package specui_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/problem"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerErrorHandler(t *testing.T) {
+	serve := func(h *specui.Handler, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, h.SpecPath(), nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when the specification file is missing", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/secret/missing.yaml"), specui.WithLogger(&recordLogger{}), scalar.WithUI())
+
+		rec := serve(h, "application/json")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		assert.NotContains(t, rec.Body.String(), "testdata/secret", "file paths are not served")
+		var got problem.Details
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, problem.Details{
+			Type:     "about:blank",
+			Title:    "Not Found",
+			Status:   http.StatusNotFound,
+			Detail:   "OpenAPI specification file is not found",
+			Instance: "/docs/openapi.json",
+		}, got)
+	})
+	t.Run("when a browser opens the specification", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecGenerator(&mockGenerator{shouldFail: true}), specui.WithLogger(&recordLogger{}), scalar.WithUI())
+
+		rec := serve(h, "text/html,application/xhtml+xml")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "<p>failed to generate OpenAPI schema</p>")
+		assert.NotContains(t, rec.Body.String(), "failed to generate JSON")
+	})
+	t.Run("when a custom error handler is configured", func(t *testing.T) {
+		var status int
+		var cause error
+		h := specui.NewHandler(
+			specui.WithSpecFile("testdata/secret/missing.yaml"),
+			specui.WithLogger(&recordLogger{}),
+			specui.WithErrorHandler(func(w http.ResponseWriter, r *http.Request, s int, err error) {
+				status, cause = s, err
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			}),
+			scalar.WithUI(),
+		)
+
+		rec := serve(h, "application/json")
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "unavailable\n", rec.Body.String())
+		assert.Equal(t, http.StatusNotFound, status)
+		var pathErr *fs.PathError
+		require.True(t, errors.As(cause, &pathErr), "the underlying error is passed")
+		assert.Equal(t, "testdata/secret/missing.yaml", pathErr.Path)
+	})
+}
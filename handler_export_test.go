@@ -0,0 +1,170 @@
+package specui_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/oaswrap/spec-ui/rapidocemb"
+	"github.com/oaswrap/spec-ui/redocemb"
+	"github.com/oaswrap/spec-ui/scalaremb"
+	"github.com/oaswrap/spec-ui/stoplightemb"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var relativeAssetRe = regexp.MustCompile(`(?:src|href)="(\./_assets/[^"]+)"`)
+
+func fetch(t *testing.T, srv *httptest.Server, path string) (int, string) {
+	t.Helper()
+	resp, err := srv.Client().Get(srv.URL + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(b)
+}
+
+func TestHandlerExport(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  specui.Option
+	}{
+		{name: "when exporting Swagger UI", opt: swaggeruiemb.WithUI()},
+		{name: "when exporting Stoplight Elements", opt: stoplightemb.WithUI()},
+		{name: "when exporting ReDoc", opt: redocemb.WithUI()},
+		{name: "when exporting Scalar", opt: scalaremb.WithUI()},
+		{name: "when exporting RapiDoc", opt: rapidocemb.WithUI()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			h := specui.NewHandler(
+				specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+				specui.WithSpecPath("/docs/openapi.json"),
+				tt.opt,
+			)
+			require.NoError(t, h.Export(dir))
+
+			srv := httptest.NewServer(http.StripPrefix("/site", http.FileServer(http.Dir(dir))))
+			defer srv.Close()
+
+			status, page := fetch(t, srv, "/site/")
+			require.Equal(t, http.StatusOK, status)
+			assert.Regexp(t, `\.\\?/openapi\.json`, page)
+			assert.NotContains(t, page, "/docs")
+			assert.Empty(t, asset.ExternalURLs("index.html", []byte(page)))
+
+			matches := relativeAssetRe.FindAllStringSubmatch(page, -1)
+			require.NotEmpty(t, matches)
+			for _, m := range matches {
+				status, _ := fetch(t, srv, "/site/"+m[1][2:])
+				assert.Equal(t, http.StatusOK, status, m[1])
+			}
+
+			status, body := fetch(t, srv, "/site/openapi.json")
+			assert.Equal(t, http.StatusOK, status)
+			assert.Contains(t, body, `"title": "Swagger Petstore - OpenAPI 3.0"`)
+
+			status, body = fetch(t, srv, "/site/openapi.yaml")
+			assert.Equal(t, http.StatusOK, status)
+			assert.Contains(t, body, "title: Swagger Petstore - OpenAPI 3.0")
+		})
+	}
+}
+
+func TestHandlerExportCDN(t *testing.T) {
+	dir := t.TempDir()
+	h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS), swaggerui.WithUI())
+	require.NoError(t, h.Export(dir))
+
+	page, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "./openapi.json")
+	assert.Contains(t, string(page), "https://cdn.jsdelivr.net/npm/swagger-ui@")
+	assert.FileExists(t, filepath.Join(dir, "openapi.yaml"))
+	assert.NoDirExists(t, filepath.Join(dir, "_assets"))
+}
+
+func TestHandlerExportErrors(t *testing.T) {
+	t.Run("when no provider is configured", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS))
+		assert.Error(t, h.Export(t.TempDir()))
+	})
+	t.Run("when the specification is missing", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/missing.yaml"), swaggeruiemb.WithUI())
+		assert.ErrorContains(t, h.Export(t.TempDir()), "loading specification")
+	})
+	t.Run("when the specification is remote", func(t *testing.T) {
+		dir := t.TempDir()
+		h := specui.NewHandler(specui.WithSpecPath("https://api.example.com/openapi.json"), redocemb.WithUI())
+		require.NoError(t, h.Export(dir))
+		assert.NoFileExists(t, filepath.Join(dir, "openapi.json"))
+	})
+}
+
+var (
+	resourceRe = regexp.MustCompile(`(?:src|href)="([^"]*)"`)
+	scriptRe   = regexp.MustCompile(`(?s)<script[^>]*>.*?</script>`)
+	styleRe    = regexp.MustCompile(`(?s)<style[^>]*>.*?</style>`)
+)
+
+func TestHandlerExportHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  specui.Option
+	}{
+		{name: "when exporting Swagger UI", opt: swaggeruiemb.WithUI()},
+		{name: "when exporting Stoplight Elements", opt: stoplightemb.WithUI()},
+		{name: "when exporting ReDoc", opt: redocemb.WithUI()},
+		{name: "when exporting Scalar", opt: scalaremb.WithUI()},
+		{name: "when exporting RapiDoc", opt: rapidocemb.WithUI()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), tt.opt)
+
+			var buf bytes.Buffer
+			require.NoError(t, h.ExportHTML(&buf))
+			page := buf.String()
+
+			assert.Contains(t, page, `"title":"Swagger Petstore - OpenAPI 3.0"`)
+
+			// Scripts and styles are the embedded assets, checked by the
+			// TestNoExternalURLs test of each *emb package.
+			markup := styleRe.ReplaceAllString(scriptRe.ReplaceAllString(page, ""), "")
+			assert.Empty(t, asset.ExternalURLs("index.html", []byte(markup)))
+			assert.Empty(t, resourceRe.FindAllString(markup, -1), "the page references no other file")
+			assert.NotContains(t, markup, "{", "inlined scripts and styles do not end early")
+		})
+	}
+
+	t.Run("when the provider has fonts", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), redocemb.WithUI())
+
+		var buf bytes.Buffer
+		require.NoError(t, h.ExportHTML(&buf))
+		assert.Contains(t, buf.String(), `url("data:font/woff2;base64,`)
+	})
+}
+
+func TestHandlerExportHTMLErrors(t *testing.T) {
+	t.Run("when assets are loaded from a CDN", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), swaggerui.WithUI())
+		assert.ErrorContains(t, h.ExportHTML(io.Discard), "embedded assets")
+	})
+	t.Run("when the specification is missing", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/missing.yaml"), redocemb.WithUI())
+		assert.ErrorContains(t, h.ExportHTML(io.Discard), "loading specification")
+	})
+}
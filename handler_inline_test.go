@@ -0,0 +1,180 @@
+package specui_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/rapidoc"
+	"github.com/oaswrap/spec-ui/redoc"
+	"github.com/oaswrap/spec-ui/redocemb"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/stoplight"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerInlineSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		opt    specui.Option
+		inline string
+	}{
+		{name: "when using Swagger UI", opt: swaggerui.WithUI(), inline: `settings.spec = {"openapi":"3.0.4"`},
+		{name: "when using Stoplight Elements", opt: stoplight.WithUI(), inline: `const text = {"openapi":"3.0.4"`},
+		{name: "when using ReDoc", opt: redoc.WithUI(), inline: `Redoc.init({"openapi":"3.0.4"`},
+		{name: "when using Scalar", opt: scalar.WithUI(), inline: `config.content = {"openapi":"3.0.4"`},
+		{name: "when using RapiDoc", opt: rapidoc.WithUI(), inline: `loadSpec({"openapi":"3.0.4"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := specui.NewHandler(
+				specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+				specui.WithSpecPath("/docs/openapi.yaml"),
+				specui.WithInlineSpec(),
+				tt.opt,
+			)
+
+			rec := httptest.NewRecorder()
+			h.Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.inline)
+			assert.Contains(t, rec.Body.String(), `"title":"Swagger Petstore - OpenAPI 3.0"`)
+
+			rec = httptest.NewRecorder()
+			h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil))
+			assert.Equal(t, http.StatusOK, rec.Code, "the spec remains available for downloads")
+		})
+	}
+}
+
+func TestHandlerInlineSpecEscaping(t *testing.T) {
+	h := specui.NewHandler(
+		specui.WithSpecGenerator(&specGenerator{json: `{"openapi":"3.1.0","info":{"title":"</script><script>alert(1)</script>"}}`}),
+		specui.WithInlineSpec(),
+		redoc.WithUI(),
+	)
+
+	rec := httptest.NewRecorder()
+	h.Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "<script>alert(1)")
+	assert.Contains(t, rec.Body.String(), `"title":"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"`)
+}
+
+func TestHandlerInlineSpecErrors(t *testing.T) {
+	t.Run("when the specification is missing", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/missing.yaml"), specui.WithInlineSpec(), scalar.WithUI())
+
+		rec := httptest.NewRecorder()
+		h.Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "<html")
+	})
+	t.Run("when checking offline mode", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithInlineSpec(),
+			specui.WithOffline(),
+			redocemb.WithUI(),
+		)
+		assert.NoError(t, h.CheckConfig(), "URLs in the specification are not resources of the page")
+	})
+}
+
+type specGenerator struct {
+	json string
+}
+
+func (g *specGenerator) MarshalJSON() ([]byte, error) {
+	return []byte(g.json), nil
+}
+
+func (g *specGenerator) MarshalYAML() ([]byte, error) {
+	return []byte(g.json), nil
+}
+
+func TestHandlerInlineSpecSharedLoad(t *testing.T) {
+	var calls int32
+	h := specui.NewHandler(
+		specui.WithSpecSource(source.Func(func(context.Context) ([]byte, string, string, error) {
+			atomic.AddInt32(&calls, 1)
+			return []byte(`{"openapi":"3.1.0","info":{"title":"Pets","version":"1.0.0"},"paths":{}}`), source.FormatJSON, "3.1.0", nil
+		})),
+		specui.WithInlineSpec(),
+		scalar.WithUI(),
+	)
+
+	rec := httptest.NewRecorder()
+	h.Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"title":"Pets"`)
+
+	rec = httptest.NewRecorder()
+	h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "the page inlines the specification loaded for the spec path")
+}
+
+func TestHandlerInlineSpecReload(t *testing.T) {
+	t.Run("when the specification fails to load once", func(t *testing.T) {
+		var calls int32
+		h := specui.NewHandler(
+			specui.WithSpecSource(source.Func(func(context.Context) ([]byte, string, string, error) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					return nil, "", "", errors.New("registry unavailable")
+				}
+				return []byte(`{"openapi":"3.1.0","info":{"title":"Pets","version":"1.0.0"},"paths":{}}`), source.FormatJSON, "3.1.0", nil
+			})),
+			specui.WithInlineSpec(),
+			specui.WithLogger(&recordLogger{}),
+			scalar.WithUI(),
+		)
+
+		// The page is rendered, and fails, when the docs handler is created.
+		c, ok := h.Content(h.DocsPath())
+		require.True(t, ok)
+		assert.Equal(t, http.StatusOK, c.Status, "a failed render is not kept")
+		assert.Contains(t, string(c.Body), `"title":"Pets"`)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("when a remote specification is refreshed", func(t *testing.T) {
+		var version atomic.Value
+		version.Store("1.0.0")
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"openapi":"3.1.0","info":{"title":"Remote API","version":"` + version.Load().(string) + `"},"paths":{}}`))
+		}))
+		defer srv.Close()
+
+		h := specui.NewHandler(
+			specui.WithSpecSource(&source.Remote{URL: srv.URL, Refresh: 10 * time.Millisecond}),
+			specui.WithInlineSpec(),
+			redoc.WithUI(),
+		)
+		_, ok := h.Content(h.DocsPath())
+		assert.False(t, ok, "the content of a docs page inlining a refreshed specification is not cached")
+
+		get := func() string {
+			rec := httptest.NewRecorder()
+			h.Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			return rec.Body.String()
+		}
+		assert.Contains(t, get(), `"version":"1.0.0"`)
+
+		version.Store("2.0.0")
+		assert.Eventually(t, func() bool {
+			return strings.Contains(get(), `"version":"2.0.0"`)
+		}, time.Second, 20*time.Millisecond)
+	})
+}
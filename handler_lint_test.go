@@ -0,0 +1,28 @@
+package specui_test
+
+import (
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/lint"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerLint(t *testing.T) {
+	t.Run("when linting the specification", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), scalar.WithUI())
+
+		report, err := h.Lint(lint.Config{Severity: map[string]lint.Severity{"operation-tags": lint.SeverityError}})
+		require.NoError(t, err)
+		assert.NoError(t, report.Err(), "every petstore operation is tagged")
+	})
+	t.Run("when the specification is missing", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/missing.yaml"), scalar.WithUI())
+
+		_, err := h.Lint(lint.Config{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+package specui_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"syscall"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyFS fails to open files with an I/O error on the first call.
+type flakyFS struct {
+	opens int
+}
+
+func (f *flakyFS) Open(name string) (fs.File, error) {
+	f.opens++
+	if f.opens == 1 {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: syscall.EIO}
+	}
+	return testdata.FS.Open(name)
+}
+
+func TestHandlerLoadSpec(t *testing.T) {
+	t.Run("when the specification is valid", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), scalar.WithUI())
+
+		assert.NoError(t, h.LoadSpec(context.Background()))
+	})
+	t.Run("when the specification file is missing", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/missing.yaml"), specui.WithLogger(&recordLogger{}), scalar.WithUI())
+
+		err := h.LoadSpec(context.Background())
+		require.ErrorIs(t, err, fs.ErrNotExist)
+		var pathErr *fs.PathError
+		require.True(t, errors.As(err, &pathErr), "the underlying error is returned")
+		assert.Equal(t, "testdata/missing.yaml", pathErr.Path)
+	})
+	t.Run("when reading fails once", func(t *testing.T) {
+		fsys := &flakyFS{}
+		h := specui.NewHandler(specui.WithSpecIOFS("petstore.yaml", fsys), specui.WithLogger(&recordLogger{}), scalar.WithUI())
+
+		assert.ErrorIs(t, h.LoadSpec(context.Background()), syscall.EIO)
+		assert.NoError(t, h.LoadSpec(context.Background()), "the load is retried")
+	})
+	t.Run("when the context is done", func(t *testing.T) {
+		src := source.Func(func(ctx context.Context) ([]byte, string, string, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, "", "", err
+			}
+			b, err := testdata.FS.ReadFile("petstore.yaml")
+			return b, source.FormatYAML, "3.0.3", err
+		})
+		h := specui.NewHandler(specui.WithSpecSource(src), specui.WithLogger(&recordLogger{}), scalar.WithUI())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, h.LoadSpec(ctx), context.Canceled)
+		assert.NoError(t, h.LoadSpec(context.Background()), "a canceled load is retried")
+	})
+	t.Run("when rendering content while reading fails", func(t *testing.T) {
+		fsys := &flakyFS{}
+		h := specui.NewHandler(specui.WithSpecIOFS("petstore.yaml", fsys), specui.WithLogger(&recordLogger{}), scalar.WithUI())
+
+		c, ok := h.Content(h.SpecPath())
+		require.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, c.Status)
+		c, ok = h.Content(h.SpecPath())
+		require.True(t, ok)
+		assert.Equal(t, http.StatusOK, c.Status, "unavailable responses are not cached")
+	})
+}
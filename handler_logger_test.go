@@ -0,0 +1,92 @@
+package specui_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordLogger records the messages logged, as "LEVEL msg key=value ...".
+type recordLogger struct {
+	mu   sync.Mutex
+	logs []string
+}
+
+func (l *recordLogger) Debug(msg string, args ...any) { l.record("DEBUG", msg, args) }
+func (l *recordLogger) Info(msg string, args ...any)  { l.record("INFO", msg, args) }
+func (l *recordLogger) Warn(msg string, args ...any)  { l.record("WARN", msg, args) }
+func (l *recordLogger) Error(msg string, args ...any) { l.record("ERROR", msg, args) }
+
+func (l *recordLogger) record(level, msg string, args []any) {
+	parts := []string{level, msg}
+	for i := 0; i+1 < len(args); i += 2 {
+		parts = append(parts, fmt.Sprintf("%v=%v", args[i], args[i+1]))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, strings.Join(parts, " "))
+}
+
+func TestHandlerLogger(t *testing.T) {
+	get := func(h http.Handler, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	t.Run("when the generator fails", func(t *testing.T) {
+		l := &recordLogger{}
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(&mockGenerator{shouldFail: true}),
+			specui.WithLogger(l),
+			scalar.WithUI(),
+		)
+
+		rec := get(h.Spec(), "/docs/openapi.json")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "failed to generate OpenAPI schema")
+		assert.NotContains(t, rec.Body.String(), "failed to generate JSON", "the cause is not served")
+		assert.Equal(t, []string{
+			"ERROR failed to load OpenAPI specification provider=scalar path=/docs/openapi.json source=generator error=failed to generate JSON",
+		}, l.logs)
+	})
+	t.Run("when validating with warnings", func(t *testing.T) {
+		l := &recordLogger{}
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(&specGenerator{json: invalidSpec}),
+			specui.WithSpecPath("/docs/openapi.json"),
+			specui.WithValidation(config.ValidationWarn),
+			specui.WithLogger(l),
+			scalar.WithUI(),
+		)
+
+		require.NoError(t, h.CheckConfig())
+		assert.Equal(t, http.StatusOK, get(h.Spec(), "/docs/openapi.json").Code)
+		assert.Equal(t, []string{
+			`WARN invalid OpenAPI specification path=/docs/openapi.json problem=/info (line 1): missing required property "version"`,
+			"INFO validated OpenAPI specification path=/docs/openapi.json problems=1",
+		}, l.logs)
+	})
+	t.Run("when the specification is valid", func(t *testing.T) {
+		l := &recordLogger{}
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(&mockGenerator{}),
+			specui.WithValidation(config.ValidationFail),
+			specui.WithLogger(l),
+			scalar.WithUI(),
+		)
+
+		require.NoError(t, h.CheckConfig())
+		assert.Equal(t, http.StatusOK, get(h.Docs(), "/docs").Code)
+		assert.Equal(t, []string{"INFO validated OpenAPI specification path=/docs/openapi.json problems=0"}, l.logs)
+	})
+}
@@ -0,0 +1,149 @@
+package specui_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordObserver records the events observed.
+type recordObserver struct {
+	mu       sync.Mutex
+	requests []observe.Request
+	loads    []observe.Load
+}
+
+func (o *recordObserver) ObserveRequest(e observe.Request) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.requests = append(o.requests, e)
+}
+
+func (o *recordObserver) ObserveLoad(e observe.Load) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.loads = append(o.loads, e)
+}
+
+func TestHandlerObserver(t *testing.T) {
+	serve := func(h http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when serving every route", func(t *testing.T) {
+		obs := &recordObserver{}
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithSpecPath("/docs/openapi.yaml"),
+			specui.WithChangelog("v1.yaml", fstest.MapFS{"v1.yaml": {Data: []byte("openapi: 3.0.3\ninfo: {title: Pets, version: \"0.9\"}\npaths: {}\n")}}),
+			specui.WithObserver(obs),
+			swaggeruiemb.WithUI(),
+		)
+
+		docs := serve(h.Docs(), "/docs", nil)
+		spec := serve(h.Spec(), "/docs/openapi.yaml", nil)
+		serve(h.Assets(), "/docs/_assets/missing.js", nil)
+		changelog := serve(h.Changelog(), "/docs/changelog", nil)
+		serve(h.Docs(), "/docs", http.Header{"If-None-Match": {docs.Header().Get("ETag")}})
+
+		type request struct {
+			Route    observe.RouteKind
+			Provider string
+			Path     string
+			Status   int
+			Bytes    int64
+		}
+		var got []request
+		for _, e := range obs.requests {
+			assert.Equal(t, http.MethodGet, e.Method)
+			assert.NotNil(t, e.Context)
+			assert.False(t, e.Start.IsZero())
+			got = append(got, request{e.Route, e.Provider, e.Path, e.Status, e.Bytes})
+		}
+		assert.Equal(t, []request{
+			{observe.Docs, "swaggerui", "/docs", http.StatusOK, int64(docs.Body.Len())},
+			{observe.Spec, "swaggerui", "/docs/openapi.yaml", http.StatusOK, int64(spec.Body.Len())},
+			{observe.Assets, "swaggerui", "/docs/_assets/missing.js", http.StatusNotFound, 19},
+			{observe.Changelog, "swaggerui", "/docs/changelog", http.StatusOK, int64(changelog.Body.Len())},
+			{observe.Docs, "swaggerui", "/docs", http.StatusNotModified, 0},
+		}, got)
+
+		require.Len(t, obs.loads, 1, "the specification is loaded once")
+		assert.Equal(t, "swaggerui", obs.loads[0].Provider)
+		assert.Equal(t, "/docs/openapi.yaml", obs.loads[0].Path)
+		assert.Equal(t, "petstore.yaml", obs.loads[0].Source)
+		assert.Equal(t, spec.Body.Len(), obs.loads[0].Bytes)
+		assert.NoError(t, obs.loads[0].Err)
+	})
+	t.Run("when the generator fails", func(t *testing.T) {
+		obs := &recordObserver{}
+		h := specui.NewHandler(
+			specui.WithSpecGenerator(&mockGenerator{shouldFail: true}),
+			specui.WithObserver(obs),
+			scalar.WithUI(),
+		)
+
+		serve(h.SpecFunc(), "/docs/openapi.json", nil)
+		require.Len(t, obs.loads, 1)
+		assert.Equal(t, "generator", obs.loads[0].Source)
+		assert.Zero(t, obs.loads[0].Bytes)
+		assert.EqualError(t, obs.loads[0].Err, "failed to generate JSON")
+		require.Len(t, obs.requests, 1)
+		assert.Equal(t, http.StatusInternalServerError, obs.requests[0].Status)
+	})
+	t.Run("when several observers are added", func(t *testing.T) {
+		first, second := &recordObserver{}, &recordObserver{}
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithObserver(first),
+			specui.WithObserver(second),
+			scalar.WithUI(),
+		)
+
+		for _, r := range h.Routes() {
+			serve(r.Handler, r.Path, nil)
+		}
+		assert.Len(t, first.requests, 2)
+		assert.Len(t, second.requests, 2)
+		assert.Len(t, second.loads, 1)
+	})
+	t.Run("when rendering content", func(t *testing.T) {
+		obs := &recordObserver{}
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithObserver(obs),
+			scalar.WithUI(),
+		)
+
+		c, ok := h.Content("/docs")
+		require.True(t, ok)
+		assert.Equal(t, observe.Docs, c.Kind)
+		assert.Empty(t, obs.requests, "rendering is not a request")
+
+		h.ObserveRequest(observe.Request{Route: c.Kind, Path: "/docs", Status: c.Status})
+		require.Len(t, obs.requests, 1)
+		assert.Equal(t, "scalar", obs.requests[0].Provider)
+	})
+	t.Run("when no observer is configured", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), scalar.WithUI())
+
+		assert.Same(t, h.Docs(), h.Docs())
+		h.ObserveRequest(observe.Request{Route: observe.Docs})
+	})
+}
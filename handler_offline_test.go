@@ -0,0 +1,66 @@
+package specui_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/rapidocemb"
+	"github.com/oaswrap/spec-ui/redocemb"
+	"github.com/oaswrap/spec-ui/scalaremb"
+	"github.com/oaswrap/spec-ui/stoplightemb"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerOffline(t *testing.T) {
+	t.Run("embedded providers", func(t *testing.T) {
+		for _, opt := range []specui.Option{
+			swaggeruiemb.WithUI(),
+			stoplightemb.WithUI(),
+			redocemb.WithUI(),
+			scalaremb.WithUI(),
+			rapidocemb.WithUI(),
+		} {
+			h := specui.NewHandler(specui.WithOffline(), opt)
+			assert.NoError(t, h.CheckConfig())
+		}
+	})
+	t.Run("CDN provider", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithOffline(), swaggerui.WithUI())
+		assert.Error(t, h.CheckConfig())
+	})
+	t.Run("external logo", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithOffline(),
+			stoplightemb.WithUI(config.StoplightElements{Logo: "https://example.com/logo.png"}),
+		)
+		assert.ErrorContains(t, h.CheckConfig(), "https://example.com/logo.png")
+	})
+	t.Run("external URL in an asset", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithOffline(),
+			redocemb.WithUI(),
+			func(c *config.SpecUI) {
+				c.AssetsFS = fstest.MapFS{
+					"redoc.standalone.js": {Data: []byte(`import("https://cdn.example.com/worker.js")`)},
+				}
+			},
+		)
+		assert.ErrorContains(t, h.CheckConfig(), "redoc.standalone.js references external URLs: https://cdn.example.com/worker.js")
+	})
+	t.Run("external spec", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithOffline(),
+			specui.WithSpecPath("https://api.example.com/openapi.json"),
+			redocemb.WithUI(),
+		)
+		assert.ErrorContains(t, h.CheckConfig(), "https://api.example.com/openapi.json")
+	})
+	t.Run("not strict", func(t *testing.T) {
+		h := specui.NewHandler(swaggerui.WithUI())
+		assert.NoError(t, h.CheckConfig())
+	})
+}
@@ -0,0 +1,64 @@
+package specui_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerRoutes(t *testing.T) {
+	type route struct {
+		Path   string
+		Prefix bool
+		Kind   observe.RouteKind
+	}
+	tests := []struct {
+		name string
+		opts []specui.Option
+		want []route
+	}{
+		{
+			name: "when assets are served from a CDN",
+			opts: []specui.Option{swaggerui.WithUI()},
+			want: []route{{Path: "/docs", Kind: observe.Docs}, {Path: "/docs/openapi.json", Kind: observe.Spec}},
+		},
+		{
+			name: "when assets are embedded",
+			opts: []specui.Option{specui.WithAssetsPath("/static/ui"), swaggeruiemb.WithUI()},
+			want: []route{
+				{Path: "/docs", Kind: observe.Docs},
+				{Path: "/docs/openapi.json", Kind: observe.Spec},
+				{Path: "/static/ui", Prefix: true, Kind: observe.Assets},
+			},
+		},
+		{
+			name: "when a changelog is configured",
+			opts: []specui.Option{
+				specui.WithChangelog("v1.yaml", fstest.MapFS{}),
+				specui.WithChangelogPath("/docs/changes"),
+				swaggerui.WithUI(),
+			},
+			want: []route{
+				{Path: "/docs", Kind: observe.Docs},
+				{Path: "/docs/openapi.json", Kind: observe.Spec},
+				{Path: "/docs/changes", Kind: observe.Changelog},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := specui.NewHandler(tt.opts...)
+			var got []route
+			for _, r := range h.Routes() {
+				assert.NotNil(t, r.Handler, r.Path)
+				got = append(got, route{Path: r.Path, Prefix: r.Prefix, Kind: r.Kind})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+//go:build go1.21
+
+package specui_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerSlog(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	h := specui.NewHandler(
+		specui.WithSpecGenerator(&mockGenerator{shouldFail: true}),
+		specui.WithLogger(l),
+		scalar.WithUI(),
+	)
+
+	rec := httptest.NewRecorder()
+	h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "level=ERROR msg=\"failed to load OpenAPI specification\" provider=scalar path=/docs/openapi.json source=generator error=\"failed to generate JSON\"\n", buf.String())
+}
@@ -0,0 +1,98 @@
+package specui_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerSpecSource(t *testing.T) {
+	t.Run("when the specification is served from a URL", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"openapi":"3.1.0","info":{"title":"Remote API","version":"1.0.0"},"paths":{}}`))
+		}))
+		defer srv.Close()
+
+		h := specui.NewHandler(specui.WithSpecURL(srv.URL), scalar.WithUI())
+
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Remote API")
+	})
+
+	t.Run("when a remote specification is refreshed", func(t *testing.T) {
+		var version atomic.Value
+		version.Store("1.0.0")
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"openapi":"3.1.0","info":{"title":"Remote API","version":"` + version.Load().(string) + `"},"paths":{}}`))
+		}))
+		defer srv.Close()
+
+		src := &source.Remote{URL: srv.URL, Refresh: 10 * time.Millisecond}
+		h := specui.NewHandler(specui.WithSpecSource(src), scalar.WithUI())
+		_, ok := h.Content(h.SpecPath())
+		assert.False(t, ok, "the content of a refreshed specification is not cached")
+
+		get := func() string {
+			rec := httptest.NewRecorder()
+			h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			return rec.Body.String()
+		}
+		assert.Contains(t, get(), "1.0.0")
+
+		version.Store("2.0.0")
+		assert.Eventually(t, func() bool {
+			return strings.Contains(get(), "2.0.0")
+		}, time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("when a custom source fails", func(t *testing.T) {
+		errLoad := errors.New("registry unavailable")
+		h := specui.NewHandler(
+			specui.WithSpecSource(source.Func(func(context.Context) ([]byte, string, string, error) {
+				return nil, "", "", errLoad
+			})),
+			specui.WithLogger(&recordLogger{}),
+			scalar.WithUI(),
+		)
+
+		assert.ErrorIs(t, h.LoadSpec(context.Background()), errLoad)
+	})
+}
+
+func TestHandlerDeprecatedSpecFields(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  specui.Option
+	}{
+		{name: "when SpecFile is set", opt: func(c *config.SpecUI) { c.SpecFile = "testdata/petstore.json" }},
+		{name: "when SpecEmbedFS is set", opt: func(c *config.SpecUI) { c.SpecFile, c.SpecEmbedFS = "petstore.json", &testdata.FS }},
+		{name: "when SpecIOFS is set", opt: func(c *config.SpecUI) { c.SpecFile, c.SpecIOFS = "petstore.json", testdata.FS }},
+		{name: "when SpecGenerator is set", opt: func(c *config.SpecUI) { c.SpecGenerator = &specGenerator{json: `{"openapi":"3.1.0"}`} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := specui.NewHandler(tt.opt, scalar.WithUI())
+
+			rec := httptest.NewRecorder()
+			h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"openapi"`)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package specui_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/redact"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/oaswrap/spec-ui/transform"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestHandlerSpecTransform(t *testing.T) {
+	logo := func(doc *transform.Document, _ string) error {
+		logo := &yaml.Node{Kind: yaml.MappingNode}
+		logo.Content = []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "url"},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "/logo.png"},
+		}
+		return doc.Set("/info/x-logo", logo)
+	}
+	serve := func(t *testing.T, h *specui.Handler, host string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, h.SpecPath(), nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("when adding a vendor extension", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithSpecPath("/docs/openapi.yaml"),
+			specui.WithSpecTransform(logo),
+			scalar.WithUI(),
+		)
+
+		rec := serve(t, h, "example.com")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "  x-logo:\n    url: /logo.png\n")
+	})
+	t.Run("when transforming per request variant", func(t *testing.T) {
+		var calls int32
+		server := func(doc *transform.Document, variant string) error {
+			atomic.AddInt32(&calls, 1)
+			servers := &yaml.Node{Kind: yaml.SequenceNode}
+			server := &yaml.Node{Kind: yaml.MappingNode}
+			server.Content = []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "url"},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "https://" + variant + "/v1"},
+			}
+			servers.Content = append(servers.Content, server)
+			return doc.Set("/servers", servers)
+		}
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+			specui.WithSpecTransform(server),
+			specui.WithSpecVariant(func(r *http.Request) string { return r.Host }),
+			scalar.WithUI(),
+		)
+
+		assert.Contains(t, serve(t, h, "eu.example.com").Body.String(), `"url": "https://eu.example.com/v1"`)
+		assert.Contains(t, serve(t, h, "us.example.com").Body.String(), `"url": "https://us.example.com/v1"`)
+		assert.Contains(t, serve(t, h, "eu.example.com").Body.String(), `"url": "https://eu.example.com/v1"`)
+		// Once when loading, then once per variant.
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls), "results are cached per variant")
+	})
+	t.Run("when composing with the built-in transforms", func(t *testing.T) {
+		var version string
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("swagger.yaml", &testdata.FS),
+			specui.WithSwaggerConversion(),
+			specui.WithSpecTransform(func(doc *transform.Document, _ string) error {
+				version = doc.Version()
+				doc.Warn(doc.Get("/info"), "/info", "no logo")
+				return nil
+			}),
+			specui.WithValidation(config.ValidationWarn),
+			scalar.WithUI(),
+		)
+
+		problems, err := h.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, "3.0.3", version, "transformers run after the conversion")
+		assert.Contains(t, problems, validate.Error{Pointer: "/info", Line: 3, Column: 3, Message: "no logo"})
+	})
+	t.Run("when no transformer changes the specification", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+			specui.WithSpecTransform(func(*transform.Document, string) error { return nil }),
+			scalar.WithUI(),
+		)
+
+		want, err := testdata.FS.ReadFile("petstore.json")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), serve(t, h, "example.com").Body.String())
+	})
+	t.Run("when a transformer fails", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+			specui.WithSpecTransform(func(*transform.Document, string) error { return errors.New("boom") }),
+			scalar.WithUI(),
+		)
+
+		rec := serve(t, h, "example.com")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "failed to transform OpenAPI specification: boom")
+	})
+}
+
+func TestHandlerRedaction(t *testing.T) {
+	const internal = `{"openapi":"3.0.3","info":{"title":"Users","version":"1","x-owner":"team-a"},"paths":{` +
+		`"/users":{"get":{"responses":{"200":{"description":"OK","content":{"application/json":{"example":{"email":"jane@corp.example.com"}}}}}}},` +
+		`"/admin":{"post":{"x-internal":true,"responses":{"204":{"$ref":"#/components/responses/Done"}}}}},` +
+		`"components":{"responses":{"Done":{"description":"Done"}}}}`
+
+	tests := []struct {
+		name string
+		opts []specui.Option
+	}{
+		{
+			name: "when the specification is generated",
+			opts: []specui.Option{specui.WithSpecGenerator(&specGenerator{json: internal})},
+		},
+		{
+			name: "when the specification is a file",
+			opts: []specui.Option{specui.WithSpecIOFS("openapi.json", fstest.MapFS{"openapi.json": {Data: []byte(internal)}})},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := specui.NewHandler(append(tt.opts,
+				specui.WithRedaction(redact.Config{
+					Extensions: []string{"x-owner"},
+					Examples:   []*regexp.Regexp{redact.Email},
+				}),
+				scalar.WithUI(),
+			)...)
+
+			rec := httptest.NewRecorder()
+			h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, h.SpecPath(), nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, `{"openapi":"3.0.3","info":{"title":"Users","version":"1"},"paths":{`+
+				`"/users":{"get":{"responses":{"200":{"description":"OK","content":{"application/json":{"example":{"email":"REDACTED"}}}}}}}},`+
+				`"components":{"responses":{}}}`, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package specui_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const invalidSpec = `{"openapi":"3.0.3","info":{"title":"<Pets>"},"paths":{}}`
+
+func TestHandlerValidate(t *testing.T) {
+	t.Run("when the specification is valid", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS), scalar.WithUI())
+
+		errs, err := h.Validate()
+		require.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+	t.Run("when the specification is invalid", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecGenerator(&specGenerator{json: invalidSpec}), scalar.WithUI())
+
+		errs, err := h.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, validate.Errors{
+			{Pointer: "/info", Line: 1, Column: 27, Message: `missing required property "version"`},
+		}, errs)
+		assert.NoError(t, h.CheckConfig(), "validation is off by default")
+	})
+	t.Run("when the specification is missing", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/missing.yaml"), scalar.WithUI())
+
+		_, err := h.Validate()
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
+
+func TestHandlerValidation(t *testing.T) {
+	newHandler := func(mode config.Validation) *specui.Handler {
+		return specui.NewHandler(
+			specui.WithSpecGenerator(&specGenerator{json: invalidSpec}),
+			specui.WithValidation(mode),
+			scalar.WithUI(),
+		)
+	}
+	get := func(h http.Handler, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	t.Run("when failing on problems", func(t *testing.T) {
+		h := newHandler(config.ValidationFail)
+
+		var errs validate.Errors
+		require.True(t, errors.As(h.CheckConfig(), &errs))
+		assert.Len(t, errs, 1)
+
+		rec := get(h.Spec(), "/docs/openapi.json")
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		var body struct {
+			Detail string           `json:"detail"`
+			Errors []validate.Error `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, `invalid OpenAPI specification: /info (line 1): missing required property "version"`, body.Detail)
+		assert.Equal(t, []validate.Error{errs[0]}, body.Errors)
+	})
+	t.Run("when logging problems", func(t *testing.T) {
+		var logs bytes.Buffer
+		log.SetOutput(&logs)
+		defer log.SetOutput(os.Stderr)
+		h := newHandler(config.ValidationWarn)
+
+		require.NoError(t, h.CheckConfig())
+		assert.Equal(t, http.StatusOK, get(h.Spec(), "/docs/openapi.json").Code)
+		assert.Equal(t, http.StatusOK, get(h.Docs(), "/docs").Code)
+		assert.Equal(t, 1, bytes.Count(logs.Bytes(), []byte(`WARN invalid OpenAPI specification path=/docs/openapi.json problem="/info (line 1): missing required property \"version\""`)), "problems are logged once")
+	})
+	t.Run("when showing problems on the docs page", func(t *testing.T) {
+		h := newHandler(config.ValidationPage)
+
+		require.NoError(t, h.CheckConfig())
+		assert.Equal(t, http.StatusOK, get(h.Spec(), "/docs/openapi.json").Code)
+		rec := get(h.Docs(), "/docs")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Invalid OpenAPI specification")
+		assert.Contains(t, rec.Body.String(), `<code>/info</code> (line 1): missing required property &#34;version&#34;`)
+		assert.NotContains(t, rec.Body.String(), "<Pets>")
+	})
+	t.Run("when the docs page specification is valid", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecEmbedFS("petstore.yaml", &testdata.FS),
+			specui.WithValidation(config.ValidationPage),
+			scalar.WithUI(),
+		)
+
+		rec := get(h.Docs(), "/docs")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "Invalid OpenAPI specification")
+	})
+	t.Run("when the docs page specification is missing", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecFile("testdata/missing.yaml"),
+			specui.WithValidation(config.ValidationPage),
+			scalar.WithUI(),
+		)
+
+		rec := get(h.Docs(), "/docs")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "OpenAPI specification file is not found")
+		assert.NotContains(t, rec.Body.String(), "missing.yaml", "the error does not reveal file paths")
+	})
+	t.Run("when failing on a missing specification", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecFile("testdata/missing.yaml"),
+			specui.WithValidation(config.ValidationFail),
+			scalar.WithUI(),
+		)
+
+		assert.Error(t, h.CheckConfig())
+	})
+}
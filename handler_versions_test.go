@@ -0,0 +1,192 @@
+package specui_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/stoplight"
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore fails to list its versions.
+type failingStore struct {
+	store.Memory
+}
+
+func (s *failingStore) List(context.Context) ([]string, error) {
+	return nil, errors.New("connection refused")
+}
+
+func newVersionsStore(t *testing.T) *store.Memory {
+	t.Helper()
+	s := &store.Memory{}
+	require.NoError(t, s.Put("1.0.0", []byte("openapi: 3.0.3\ninfo: {title: Pets, version: 1.0.0}\npaths: {}\n")))
+	require.NoError(t, s.Put("2.0.0", []byte(`{"openapi":"3.1.0","info":{"title":"Pets","version":"2.0.0"},"paths":{}}`)))
+	return s
+}
+
+func TestHandlerVersions(t *testing.T) {
+	get := func(h http.Handler, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	t.Run("when no store is configured", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecFile("testdata/petstore.yaml"), scalar.WithUI())
+
+		assert.False(t, h.VersionsEnabled())
+		assert.Nil(t, h.Versions())
+		assert.NotContains(t, get(h.Docs(), "/docs").Body.String(), "specui-versions")
+	})
+
+	t.Run("when listing the versions", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecStore(newVersionsStore(t)), scalar.WithUI())
+		require.True(t, h.VersionsEnabled())
+		assert.Equal(t, "/docs/v", h.VersionsPath())
+
+		rec := get(h.Versions(), "/docs/v")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"latest":"2.0.0","versions":["2.0.0","1.0.0"]}`, rec.Body.String())
+
+		_, ok := h.Content("/docs/v")
+		assert.False(t, ok, "the list is not cached")
+	})
+
+	t.Run("when serving the latest version", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecStore(newVersionsStore(t)), scalar.WithUI())
+
+		rec := get(h.Spec(), "/docs/openapi.json")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"2.0.0"`)
+
+		body := get(h.Docs(), "/docs").Body.String()
+		assert.Contains(t, body, `class="specui-versions"`)
+		assert.Contains(t, body, `<option value="/docs" selected>latest</option>`)
+	})
+
+	t.Run("when serving a version", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecStore(newVersionsStore(t)), stoplight.WithUI())
+
+		rec := get(h.Versions(), "/docs/v/1.0.0")
+		require.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, `"openapiURL":"/docs/v/1.0.0/openapi.json"`)
+		assert.Contains(t, body, `<option value="/docs">latest</option>`)
+		assert.Contains(t, body, `<option value="/docs/v/1.0.0" selected>1.0.0</option>`)
+
+		rec = get(h.Versions(), "/docs/v/1.0.0/openapi.json")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var doc struct {
+			OpenAPI string `json:"openapi"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc), "YAML versions are served as JSON")
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+	})
+
+	t.Run("when the version path is not found", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecStore(newVersionsStore(t)), scalar.WithUI())
+
+		for _, path := range []string{"/docs/v/3.0.0", "/docs/v/3.0.0/openapi.json", "/docs/v/1.0.0/openapi.yaml", "/docs/v/..", "/docs/vx"} {
+			rec := get(h.Versions(), path)
+			assert.Equal(t, http.StatusNotFound, rec.Code, path)
+			assert.Contains(t, rec.Body.String(), "OpenAPI specification version is not found", path)
+		}
+	})
+
+	t.Run("when the store fails", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecStore(&failingStore{}), specui.WithLogger(&recordLogger{}), scalar.WithUI())
+
+		for _, path := range []string{"/docs/v", "/docs/v/1.0.0"} {
+			rec := get(h.Versions(), path)
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code, path)
+			assert.Contains(t, rec.Body.String(), "OpenAPI specification versions are temporarily unavailable", path)
+		}
+	})
+
+	t.Run("when the versions path is set", func(t *testing.T) {
+		h := specui.NewHandler(
+			specui.WithSpecStore(newVersionsStore(t)),
+			specui.WithVersionsPath("/api/versions"),
+			scalar.WithUI(),
+		)
+
+		var paths []string
+		for _, r := range h.Routes() {
+			paths = append(paths, r.Path)
+		}
+		assert.Equal(t, []string{"/docs", "/docs/openapi.json", "/api/versions", "/api/versions"}, paths)
+
+		assert.Equal(t, http.StatusOK, get(h.Versions(), "/api/versions/2.0.0/openapi.json").Code)
+		_, ok := h.Content("/api/versions/2.0.0/openapi.json")
+		assert.False(t, ok, "the responses under the versions path are not cached")
+	})
+
+	t.Run("when a version is removed from the store", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"1.0.0.yaml": {Data: []byte("openapi: 3.0.3\ninfo: {title: Pets, version: 1.0.0}\npaths: {}\n")},
+			"2.0.0.yaml": {Data: []byte("openapi: 3.0.3\ninfo: {title: Pets, version: 2.0.0}\npaths: {}\n")},
+		}
+		h := specui.NewHandler(specui.WithSpecStore(store.Dir{FS: fsys}), scalar.WithUI())
+		require.Equal(t, http.StatusOK, get(h.Versions(), "/docs/v/1.0.0").Code)
+		require.Equal(t, http.StatusOK, get(h.Versions(), "/docs/v/1.0.0/openapi.json").Code)
+
+		delete(fsys, "1.0.0.yaml")
+		assert.Equal(t, http.StatusNotFound, get(h.Versions(), "/docs/v/1.0.0").Code)
+		assert.Equal(t, http.StatusNotFound, get(h.Versions(), "/docs/v/1.0.0/openapi.json").Code)
+	})
+
+	t.Run("when a version is published", func(t *testing.T) {
+		s := newVersionsStore(t)
+		h := specui.NewHandler(specui.WithSpecStore(s), specui.WithVersionsRefresh(10*time.Millisecond), scalar.WithUI())
+		assert.Contains(t, get(h.Spec(), "/docs/openapi.json").Body.String(), `"version":"2.0.0"`)
+
+		require.NoError(t, s.Put("3.0.0", []byte(`{"openapi":"3.1.0","info":{"title":"Pets","version":"3.0.0"},"paths":{}}`)))
+		assert.Eventually(t, func() bool {
+			return strings.Contains(get(h.Spec(), "/docs/openapi.json").Body.String(), `"version":"3.0.0"`)
+		}, time.Second, 20*time.Millisecond, "the latest version is loaded again")
+	})
+
+	t.Run("when exporting the docs", func(t *testing.T) {
+		h := specui.NewHandler(specui.WithSpecStore(newVersionsStore(t)), scalar.WithUI())
+		dir := t.TempDir()
+		require.NoError(t, h.Export(dir))
+
+		index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(index), "specui-versions", "exports have no version selector")
+		spec, err := os.ReadFile(filepath.Join(dir, "openapi.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(spec), `"2.0.0"`)
+	})
+
+	t.Run("when the docs page shows validation problems", func(t *testing.T) {
+		s := &store.Memory{}
+		require.NoError(t, s.Put("1.0.0", []byte("openapi: 3.0.3\ninfo: {title: Pets}\npaths: {}\n")))
+		h := specui.NewHandler(
+			specui.WithSpecStore(s),
+			specui.WithValidation(config.ValidationPage),
+			specui.WithLogger(&recordLogger{}),
+			scalar.WithUI(),
+		)
+
+		rec := get(h.Versions(), "/docs/v/1.0.0")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "missing required property")
+	})
+}
@@ -0,0 +1,110 @@
+// Package adaptertest holds the route matrix shared by the tests of the
+// router adapters, so that each adapter only tests how it mounts the routes.
+package adaptertest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/swaggeruiemb"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Serve mounts h on a new router of the adapter under test and returns its
+// response to req.
+type Serve func(t *testing.T, h *specui.Handler, req *http.Request) *http.Response
+
+// Recorder returns a Serve for adapters that mount on an http.Handler: mount
+// registers h on a new router and returns it.
+func Recorder(mount func(h *specui.Handler) http.Handler) Serve {
+	return func(t *testing.T, h *specui.Handler, req *http.Request) *http.Response {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mount(h).ServeHTTP(rec, req)
+		return rec.Result()
+	}
+}
+
+// Routes requests every route of a handler mounted with serve: the docs, the
+// specification, the assets, the changelog and the versions.
+func Routes(t *testing.T, serve Serve) {
+	t.Helper()
+
+	previous := fstest.MapFS{"v1.yaml": {Data: []byte("openapi: 3.0.3\ninfo: {title: Pets, version: \"0.9\"}\npaths: {}\n")}}
+
+	tests := []struct {
+		name   string
+		opts   []specui.Option
+		path   string
+		status int
+		body   string
+	}{
+		{
+			name:   "when requesting the docs",
+			opts:   []specui.Option{swaggerui.WithUI()},
+			path:   "/docs",
+			status: http.StatusOK,
+			body:   "swagger-ui",
+		},
+		{
+			name:   "when requesting the spec",
+			opts:   []specui.Option{swaggerui.WithUI()},
+			path:   "/docs/openapi.json",
+			status: http.StatusOK,
+			body:   `"openapi"`,
+		},
+		{
+			name:   "when requesting an embedded asset",
+			opts:   []specui.Option{swaggeruiemb.WithUI()},
+			path:   "/docs/_assets/swagger-ui.css",
+			status: http.StatusOK,
+		},
+		{
+			name:   "when assets are served from a CDN",
+			opts:   []specui.Option{swaggerui.WithUI()},
+			path:   "/docs/_assets/swagger-ui.css",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when requesting the changelog",
+			opts:   []specui.Option{specui.WithChangelog("v1.yaml", previous), swaggerui.WithUI()},
+			path:   "/docs/changelog",
+			status: http.StatusOK,
+			body:   "Changelog",
+		},
+		{
+			name:   "when listing the versions",
+			opts:   []specui.Option{specui.WithSpecStore(store.Dir{FS: previous}), swaggerui.WithUI()},
+			path:   "/docs/v",
+			status: http.StatusOK,
+			body:   `"versions":["v1"]`,
+		},
+		{
+			name:   "when requesting the spec of a version",
+			opts:   []specui.Option{specui.WithSpecStore(store.Dir{FS: previous}), swaggerui.WithUI()},
+			path:   "/docs/v/v1/openapi.json",
+			status: http.StatusOK,
+			body:   `"openapi": "3.0.3"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := specui.NewHandler(append(tt.opts, specui.WithSpecEmbedFS("petstore.json", &testdata.FS))...)
+
+			resp := serve(t, h, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Contains(t, string(body), tt.body)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package asset
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/constant"
+)
+
+// Package describes the npm package a provider loads its assets from.
+type Package struct {
+	Name      string            // npm package name, e.g. "swagger-ui"
+	Version   string            // Version pinned by this module
+	Dir       string            // Directory holding the asset files within the package
+	Integrity map[string]string // SRI hashes of the pinned version, keyed by file
+}
+
+var (
+	RapiDoc = Package{
+		constant.RapiDocPackage, constant.RapiDocVersion, constant.RapiDocAssetsDir,
+		constant.RapiDocIntegrity,
+	}
+	ReDoc = Package{
+		constant.RedocPackage, constant.RedocVersion, constant.RedocAssetsDir,
+		constant.RedocIntegrity,
+	}
+	Scalar = Package{
+		constant.ScalarPackage, constant.ScalarVersion, constant.ScalarAssetsDir,
+		constant.ScalarIntegrity,
+	}
+	StoplightElements = Package{
+		constant.StoplightElementsPackage, constant.StoplightElementsVersion, constant.StoplightElementsAssetsDir,
+		constant.StoplightElementsIntegrity,
+	}
+	SwaggerUI = Package{
+		constant.SwaggerUIPackage, constant.SwaggerUIVersion, constant.SwaggerUIAssetsDir,
+		constant.SwaggerUIIntegrity,
+	}
+)
+
+// Resolver maps provider asset files to the URLs they are loaded from.
+type Resolver struct {
+	url       func(file string) string
+	integrity map[string]string
+	embedded  bool
+	inline    fs.FS
+}
+
+// Local returns a resolver for assets served by the application under base.
+func Local(base string) *Resolver {
+	base = strings.TrimSuffix(base, "/")
+	return &Resolver{url: func(file string) string {
+		return base + "/" + file
+	}}
+}
+
+// Embedded returns a resolver for embedded assets served under base. When
+// fsys is fingerprinted, URLs point to the fingerprinted file names.
+func Embedded(base string, fsys fs.FS) *Resolver {
+	base = strings.TrimSuffix(base, "/")
+	r := &Resolver{embedded: true, url: func(file string) string {
+		return base + "/" + file
+	}}
+	if f, ok := fsys.(*FS); ok {
+		r.url = func(file string) string {
+			return base + "/" + f.Name(file)
+		}
+	}
+	return r
+}
+
+// New returns a resolver for the assets of pkg.
+//
+// Inlined assets are read from cfg.AssetsFS and embedded assets are served
+// from cfg.AssetsPath. Otherwise URLs are built
+// from the provider override, the configured CDN template or the default
+// jsDelivr template, in that order.
+//
+// Integrity hashes are taken from the override when set, and otherwise from
+// pkg unless the override pins a different version.
+func New(cfg *config.SpecUI, pkg Package, override config.ProviderCDN) (*Resolver, error) {
+	if cfg.InlineAssets {
+		if cfg.AssetsFS == nil {
+			return nil, errors.New("inlining assets requires embedded assets: use a provider *emb package")
+		}
+		return Inline(cfg.AssetsFS), nil
+	}
+	if cfg.EmbedAssets {
+		return Embedded(cfg.AssetsPath, cfg.AssetsFS), nil
+	}
+
+	var r *Resolver
+	if override.BaseURL != "" {
+		r = Local(override.BaseURL)
+	} else {
+		tpl := cfg.CDN.Template
+		if tpl == "" {
+			tpl = constant.CDNTemplate
+		}
+		version := pkg.Version
+		if override.Version != "" {
+			version = override.Version
+		}
+		r = fromTemplate(tpl, pkg, version)
+	}
+
+	if err := checkURL(r.URL(""), cfg.CDN.AllowInsecure); err != nil {
+		return nil, fmt.Errorf("invalid %s assets URL: %w", pkg.Name, err)
+	}
+
+	switch {
+	case override.Integrity != nil:
+		r.integrity = override.Integrity
+	case override.Version == "" || override.Version == pkg.Version:
+		r.integrity = pkg.Integrity
+	}
+
+	return r, nil
+}
+
+// Check reports whether the assets of the configured provider can be resolved.
+func Check(cfg *config.SpecUI) error {
+	var (
+		pkg      Package
+		override config.ProviderCDN
+	)
+	switch cfg.Provider {
+	case config.ProviderSwaggerUI:
+		pkg = SwaggerUI
+		if cfg.SwaggerUI != nil {
+			override = cfg.SwaggerUI.CDN
+		}
+	case config.ProviderStoplightElements:
+		pkg = StoplightElements
+		if cfg.StoplightElements != nil {
+			override = cfg.StoplightElements.CDN
+		}
+	case config.ProviderReDoc:
+		pkg = ReDoc
+		if cfg.ReDoc != nil {
+			override = cfg.ReDoc.CDN
+		}
+	case config.ProviderScalar:
+		pkg = Scalar
+		if cfg.Scalar != nil {
+			override = cfg.Scalar.CDN
+		}
+	case config.ProviderRapiDoc:
+		pkg = RapiDoc
+		if cfg.RapiDoc != nil {
+			override = cfg.RapiDoc.CDN
+		}
+	default:
+		return fmt.Errorf("unknown provider %d", cfg.Provider)
+	}
+
+	_, err := New(cfg, pkg, override)
+	return err
+}
+
+// URL returns the URL of file, relative to the provider assets directory.
+func (r *Resolver) URL(file string) string {
+	return r.url(file)
+}
+
+// Integrity returns the integrity and crossorigin attributes for file, or an
+// empty string when no hash is known for it.
+func (r *Resolver) Integrity(file string) template.HTMLAttr {
+	hash, ok := r.integrity[file]
+	if !ok {
+		return ""
+	}
+	return template.HTMLAttr(` integrity="` + template.HTMLEscapeString(hash) + `" crossorigin="anonymous"`) //nolint:gosec // The hash is escaped.
+}
+
+// Embedded reports whether the assets are served from embedded files.
+func (r *Resolver) Embedded() bool {
+	return r.embedded
+}
+
+// FuncMap returns the template functions used by provider index templates.
+func (r *Resolver) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"asset":     r.URL,
+		"integrity": r.Integrity,
+		"embedded":  r.Embedded,
+		"inline":    r.Inline,
+		"script":    r.Script,
+		"style":     r.Style,
+	}
+}
+
+func fromTemplate(tpl string, pkg Package, version string) *Resolver {
+	replacer := strings.NewReplacer("{package}", pkg.Name, "{version}", version)
+	tpl = replacer.Replace(tpl)
+	if !strings.Contains(tpl, "{file}") {
+		tpl = strings.TrimSuffix(tpl, "/") + "/{file}"
+	}
+
+	return &Resolver{url: func(file string) string {
+		return strings.Replace(tpl, "{file}", path.Join(pkg.Dir, file), 1)
+	}}
+}
+
+func checkURL(raw string, allowInsecure bool) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if allowInsecure {
+		return nil
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.New("must be an absolute https URL, use WithInsecureCDN to allow other schemes")
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package asset_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *config.SpecUI
+		pkg      asset.Package
+		override config.ProviderCDN
+		file     string
+		expected string
+	}{
+		{
+			name:     "when using the default CDN",
+			config:   &config.SpecUI{},
+			pkg:      asset.SwaggerUI,
+			file:     "swagger-ui-bundle.js",
+			expected: "https://cdn.jsdelivr.net/npm/swagger-ui@5.32.1/dist/swagger-ui-bundle.js",
+		},
+		{
+			name:     "when the package has no assets directory",
+			config:   &config.SpecUI{},
+			pkg:      asset.StoplightElements,
+			file:     "styles.min.css",
+			expected: "https://cdn.jsdelivr.net/npm/@stoplight/elements@9.0.16/styles.min.css",
+		},
+		{
+			name:     "when overriding the provider version",
+			config:   &config.SpecUI{},
+			pkg:      asset.ReDoc,
+			override: config.ProviderCDN{Version: "2.4.0"},
+			file:     "redoc.standalone.js",
+			expected: "https://cdn.jsdelivr.net/npm/redoc@2.4.0/bundles/redoc.standalone.js",
+		},
+		{
+			name:     "when using a CDN template",
+			config:   &config.SpecUI{CDN: config.CDN{Template: "https://unpkg.com/{package}@{version}/{file}"}},
+			pkg:      asset.Scalar,
+			file:     "browser/standalone.min.js",
+			expected: "https://unpkg.com/@scalar/api-reference@1.51.0/dist/browser/standalone.min.js",
+		},
+		{
+			name:     "when the CDN template has no file placeholder",
+			config:   &config.SpecUI{CDN: config.CDN{Template: "https://mirror.example.com/npm/{package}/{version}/"}},
+			pkg:      asset.RapiDoc,
+			file:     "rapidoc-min.js",
+			expected: "https://mirror.example.com/npm/rapidoc/9.3.8/dist/rapidoc-min.js",
+		},
+		{
+			name:     "when overriding the provider base URL",
+			config:   &config.SpecUI{CDN: config.CDN{Template: "https://unpkg.com/{package}@{version}/{file}"}},
+			pkg:      asset.SwaggerUI,
+			override: config.ProviderCDN{BaseURL: "https://static.example.com/swagger-ui/"},
+			file:     "swagger-ui.min.css",
+			expected: "https://static.example.com/swagger-ui/swagger-ui.min.css",
+		},
+		{
+			name:     "when serving embedded assets",
+			config:   &config.SpecUI{EmbedAssets: true, AssetsPath: "/docs/_assets"},
+			pkg:      asset.SwaggerUI,
+			override: config.ProviderCDN{BaseURL: "https://static.example.com/swagger-ui"},
+			file:     "swagger-ui.min.css",
+			expected: "/docs/_assets/swagger-ui.min.css",
+		},
+		{
+			name:     "when allowing an insecure CDN",
+			config:   &config.SpecUI{CDN: config.CDN{AllowInsecure: true}},
+			pkg:      asset.SwaggerUI,
+			override: config.ProviderCDN{BaseURL: "http://mirror.local/swagger-ui"},
+			file:     "swagger-ui.min.css",
+			expected: "http://mirror.local/swagger-ui/swagger-ui.min.css",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := asset.New(tt.config, tt.pkg, tt.override)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r.URL(tt.file))
+		})
+	}
+}
+
+func TestNewInsecure(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *config.SpecUI
+		override config.ProviderCDN
+	}{
+		{
+			name:     "when the base URL is http",
+			config:   &config.SpecUI{},
+			override: config.ProviderCDN{BaseURL: "http://mirror.local/swagger-ui"},
+		},
+		{
+			name:     "when the base URL is relative",
+			config:   &config.SpecUI{},
+			override: config.ProviderCDN{BaseURL: "/static/swagger-ui"},
+		},
+		{
+			name:   "when the CDN template is http",
+			config: &config.SpecUI{CDN: config.CDN{Template: "http://mirror.local/{package}@{version}/{file}"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := asset.New(tt.config, asset.SwaggerUI, tt.override)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	providers := []config.Provider{
+		config.ProviderSwaggerUI,
+		config.ProviderStoplightElements,
+		config.ProviderReDoc,
+		config.ProviderScalar,
+		config.ProviderRapiDoc,
+	}
+	for _, p := range providers {
+		assert.NoError(t, asset.Check(&config.SpecUI{Provider: p}))
+	}
+
+	assert.Error(t, asset.Check(&config.SpecUI{
+		Provider: config.ProviderReDoc,
+		ReDoc:    &config.ReDoc{CDN: config.ProviderCDN{BaseURL: "http://mirror.local"}},
+	}))
+	assert.Error(t, asset.Check(&config.SpecUI{Provider: config.Provider(99)}))
+}
+
+func TestIntegrity(t *testing.T) {
+	pkg := asset.Package{
+		Name:      "redoc",
+		Version:   "2.5.2",
+		Dir:       "bundles",
+		Integrity: map[string]string{"redoc.standalone.js": "sha384-pinned"},
+	}
+	tests := []struct {
+		name     string
+		config   *config.SpecUI
+		override config.ProviderCDN
+		expected string
+	}{
+		{
+			name:     "when using the pinned version",
+			config:   &config.SpecUI{},
+			expected: ` integrity="sha384-pinned" crossorigin="anonymous"`,
+		},
+		{
+			name:     "when pinning the same version",
+			config:   &config.SpecUI{},
+			override: config.ProviderCDN{Version: "2.5.2"},
+			expected: ` integrity="sha384-pinned" crossorigin="anonymous"`,
+		},
+		{
+			name:     "when pinning another version",
+			config:   &config.SpecUI{},
+			override: config.ProviderCDN{Version: "2.4.0"},
+		},
+		{
+			name:   "when supplying hashes for another version",
+			config: &config.SpecUI{},
+			override: config.ProviderCDN{
+				Version:   "2.4.0",
+				Integrity: map[string]string{"redoc.standalone.js": "sha384-custom"},
+			},
+			expected: ` integrity="sha384-custom" crossorigin="anonymous"`,
+		},
+		{
+			name:   "when serving embedded assets",
+			config: &config.SpecUI{EmbedAssets: true, AssetsPath: "/docs/_assets"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := asset.New(tt.config, pkg, tt.override)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(r.Integrity("redoc.standalone.js")))
+			assert.Empty(t, r.Integrity("unknown.js"))
+		})
+	}
+}
+
+func TestIntegrityDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		pkg   asset.Package
+		files []string
+	}{
+		{
+			name:  "when loading RapiDoc from the CDN",
+			pkg:   asset.RapiDoc,
+			files: []string{"rapidoc-min.js"},
+		},
+		{
+			name:  "when loading Redoc from the CDN",
+			pkg:   asset.ReDoc,
+			files: []string{"redoc.standalone.js"},
+		},
+		{
+			// browser/standalone.min.js is still minified by jsDelivr on the
+			// fly, so no hash is published for it.
+			name:  "when loading Scalar from the CDN",
+			pkg:   asset.Scalar,
+			files: []string{"style.css"},
+		},
+		{
+			name:  "when loading Stoplight Elements from the CDN",
+			pkg:   asset.StoplightElements,
+			files: []string{"styles.min.css", "web-components.min.js"},
+		},
+		{
+			name:  "when loading Swagger UI from the CDN",
+			pkg:   asset.SwaggerUI,
+			files: []string{"swagger-ui.css", "swagger-ui-bundle.js", "swagger-ui-standalone-preset.js"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := asset.New(&config.SpecUI{}, tt.pkg, config.ProviderCDN{})
+			require.NoError(t, err)
+			for _, file := range tt.files {
+				assert.Contains(t, string(r.Integrity(file)), ` integrity="sha384-`, file)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package asset
+
+import (
+	"bytes"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// pageRefs matches references that make a browser load a resource from
+	// another origin in an HTML page or stylesheet: src and href attributes,
+	// url() and @import, and string literals holding the URL of an asset or a
+	// specification document.
+	pageRefs = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(?:src|href|srcset|poster|data)\s*=\s*["']?((?:https?:)?//[^"'\s>]+)`),
+		cssURL,
+		cssImport,
+		regexp.MustCompile(`(?i)["']((?:https?:)?//[^"'\s]+\.(?:js|mjs|css|woff2?|ttf|otf|eot|svg|png|gif|jpe?g|ico|webp|json|ya?ml))["']`),
+	}
+
+	// scriptRefs matches the same references in a script, where attribute
+	// assignments and JSON endpoints are usually links rather than loads.
+	scriptRefs = []*regexp.Regexp{
+		cssURL,
+		cssImport,
+		regexp.MustCompile(`(?i)["']((?:https?:)?//[^"'\s]+\.(?:js|mjs|css|woff2?|ttf|otf|eot|svg|png|gif|jpe?g|ico|webp))["']`),
+	}
+
+	cssURL    = regexp.MustCompile(`url\(\s*["']?((?:https?:)?//[^"')\s]+)`)
+	cssImport = regexp.MustCompile(`(?i)@import\s+["']((?:https?:)?//[^"']+)`)
+)
+
+// ExternalURLs returns the URLs of resources loaded from other origins by the
+// named page, stylesheet or script. URL patterns containing wildcards are not
+// requests and are ignored. Slashes escaped for script strings are matched.
+func ExternalURLs(name string, content []byte) []string {
+	content = bytes.ReplaceAll(content, []byte(`\/`), []byte(`/`))
+
+	refs := pageRefs
+	if ext := path.Ext(name); ext == ".js" || ext == ".mjs" {
+		refs = scriptRefs
+	}
+
+	seen := map[string]bool{}
+	var urls []string
+	for _, re := range refs {
+		for _, m := range re.FindAllSubmatch(content, -1) {
+			u := string(m[1])
+			if seen[u] || strings.Contains(u, "*") {
+				continue
+			}
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,66 @@
+package asset_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "when a page loads a script",
+			file:     "index.html",
+			content:  `<script src="https://cdn.example.com/app.js"></script>`,
+			expected: []string{"https://cdn.example.com/app.js"},
+		},
+		{
+			name:     "when a page loads a protocol-relative stylesheet",
+			file:     "index.html",
+			content:  `<link href='//fonts.example.com/css' rel="stylesheet">`,
+			expected: []string{"//fonts.example.com/css"},
+		},
+		{
+			name:     "when a page fetches a remote spec",
+			file:     "index.html",
+			content:  `var url = "https://api.example.com/openapi.json";`,
+			expected: []string{"https://api.example.com/openapi.json"},
+		},
+		{
+			name:     "when a page only uses local URLs",
+			file:     "index.html",
+			content:  `<script src="/docs/_assets/app.js"></script><a>https://example.com</a>`,
+			expected: nil,
+		},
+		{
+			name:     "when a stylesheet imports fonts",
+			file:     "style.css",
+			content:  `@import "https://fonts.example.com/a.css"; @font-face{src:url(https://fonts.example.com/a.woff2)}`,
+			expected: []string{"https://fonts.example.com/a.woff2", "https://fonts.example.com/a.css"},
+		},
+		{
+			name:     "when a script references a font",
+			file:     "app.js",
+			content:  `const css = "@font-face{src:url(https://fonts.example.com/a.woff2)}"`,
+			expected: []string{"https://fonts.example.com/a.woff2"},
+		},
+		{
+			name:     "when a script builds URLs and links",
+			file:     "app.js",
+			content:  `a.href="https://example.com";new URL("https://localhost");x="https://example.com/oembed.json";y="https://example.com/*/a.png"`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, asset.ExternalURLs(tt.file, []byte(tt.content)))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package asset
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// immutableCacheControl is sent for fingerprinted files, whose content never
+// changes for a given URL.
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// cssRelativeURL matches the url() references of style sheets.
+var cssRelativeURL = regexp.MustCompile(`url\(\s*(['"]?)([^'")\s]+)(['"]?)\s*\)`)
+
+// FS is a file system whose files are also available at fingerprinted paths
+// embedding a hash of their content, e.g. "redoc.standalone.1f2e3d4c5b6a.js".
+type FS struct {
+	fs.FS
+	names map[string]string // file → fingerprinted name
+	files map[string]string // fingerprinted name → file
+	css   map[string][]byte // fingerprinted style sheet → content with fingerprinted url()s
+}
+
+// Fingerprint hashes every file of fsys and returns the fingerprinted file
+// system. At their fingerprinted paths, style sheets reference the
+// fingerprinted paths of the other files, such as fonts, with relative url()s,
+// so that those are cached for a year too; their fingerprint hashes the
+// rewritten content. References between style sheets are left as is.
+func Fingerprint(fsys fs.FS) (*FS, error) {
+	f := &FS{
+		FS:    fsys,
+		names: map[string]string{},
+		files: map[string]string{},
+		css:   map[string][]byte{},
+	}
+
+	var sheets []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if path.Ext(name) == ".css" {
+			sheets = append(sheets, name)
+			return nil
+		}
+		file, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return f.add(name, file, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range sheets {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		rewritten := f.rewriteURLs(name, b)
+		if err := f.add(name, bytes.NewReader(rewritten), rewritten); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// add fingerprints the file name with the content r, served from css at the
+// fingerprinted path when it is not nil.
+func (f *FS) add(name string, r io.Reader, css []byte) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	ext := path.Ext(name)
+	fingerprinted := strings.TrimSuffix(name, ext) + "." + sum[:12] + ext
+	f.names[name] = fingerprinted
+	f.files[fingerprinted] = name
+	if css != nil {
+		f.css[fingerprinted] = css
+	}
+	return nil
+}
+
+// rewriteURLs returns the style sheet b, named name, with its relative url()s
+// of fingerprinted files replaced by their fingerprinted paths.
+func (f *FS) rewriteURLs(name string, b []byte) []byte {
+	return cssRelativeURL.ReplaceAllFunc(b, func(match []byte) []byte {
+		m := cssRelativeURL.FindSubmatch(match)
+		ref := string(m[2])
+		if !bytes.Equal(m[1], m[3]) || strings.Contains(ref, ":") || strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "#") {
+			return match
+		}
+		file, suffix := ref, ""
+		if i := strings.IndexAny(ref, "?#"); i >= 0 {
+			file, suffix = ref[:i], ref[i:]
+		}
+		fingerprinted, ok := f.names[path.Join(path.Dir(name), file)]
+		if !ok {
+			return match
+		}
+		return []byte("url(" + string(m[1]) + path.Join(path.Dir(file), path.Base(fingerprinted)) + suffix + string(m[3]) + ")")
+	})
+}
+
+// Name returns the fingerprinted name of file, or file itself when it is not
+// part of the file system.
+func (f *FS) Name(file string) string {
+	if name, ok := f.names[file]; ok {
+		return name
+	}
+	return file
+}
+
+// Open opens the named file, resolving fingerprinted names to their file.
+func (f *FS) Open(name string) (fs.File, error) {
+	file, ok := f.files[name]
+	if !ok {
+		return f.FS.Open(name)
+	}
+	css, ok := f.css[name]
+	if !ok {
+		return f.FS.Open(file)
+	}
+	info, err := fs.Stat(f.FS, file)
+	if err != nil {
+		return nil, err
+	}
+	return &memFile{
+		Reader: bytes.NewReader(css),
+		info:   memFileInfo{FileInfo: info, size: int64(len(css))},
+	}, nil
+}
+
+// Handler returns an HTTP handler serving the files under prefix. Responses
+// for fingerprinted paths are cached for a year.
+func (f *FS) Handler(prefix string) http.Handler {
+	fileServer := http.StripPrefix(prefix, http.FileServer(http.FS(f)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		if _, ok := f.files[name]; ok {
+			w.Header().Set("Cache-Control", immutableCacheControl)
+		}
+		fileServer.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,89 @@
+package asset_test
+
+import (
+	"io/fs"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	fsys, err := asset.Fingerprint(fstest.MapFS{
+		"app.js":          {Data: []byte("console.log('app')")},
+		"browser/app.css": {Data: []byte("body{}")},
+	})
+	require.NoError(t, err)
+
+	name := fsys.Name("app.js")
+	assert.Regexp(t, `^app\.[0-9a-f]{12}\.js$`, name)
+	assert.Regexp(t, `^browser/app\.[0-9a-f]{12}\.css$`, fsys.Name("browser/app.css"))
+	assert.Equal(t, "missing.js", fsys.Name("missing.js"))
+
+	b, err := fs.ReadFile(fsys, name)
+	require.NoError(t, err)
+	assert.Equal(t, "console.log('app')", string(b))
+
+	t.Run("Handler", func(t *testing.T) {
+		h := fsys.Handler("/docs/_assets")
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/docs/_assets/"+name, nil))
+		assert.Equal(t, 200, rec.Code)
+		assert.Equal(t, "console.log('app')", rec.Body.String())
+		assert.Equal(t, "public, max-age=31536000, immutable", rec.Header().Get("Cache-Control"))
+		assert.Contains(t, rec.Header().Get("Content-Type"), "javascript")
+
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/docs/_assets/app.js", nil))
+		assert.Equal(t, 200, rec.Code)
+		assert.Empty(t, rec.Header().Get("Cache-Control"))
+
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/docs/_assets/app.000000000000.js", nil))
+		assert.Equal(t, 404, rec.Code)
+	})
+	t.Run("Resolver", func(t *testing.T) {
+		r, err := asset.New(&config.SpecUI{EmbedAssets: true, AssetsPath: "/docs/_assets", AssetsFS: fsys}, asset.SwaggerUI, config.ProviderCDN{})
+		require.NoError(t, err)
+		assert.Equal(t, "/docs/_assets/"+name, r.URL("app.js"))
+		assert.True(t, r.Embedded())
+	})
+}
+
+func TestFingerprintStyleSheets(t *testing.T) {
+	css := `@font-face{src:url(font.woff2) format("woff2")}` +
+		`@font-face{src:url("../fonts/font.woff2?v=1#x")}` +
+		`a{background:url(data:image/png;base64,AA==)}` +
+		`b{background:url(/logo.png)}` +
+		`c{background:url('missing.png')}`
+	fsys, err := asset.Fingerprint(fstest.MapFS{
+		"fonts/font.woff2": {Data: []byte("woff2")},
+		"fonts/fonts.css":  {Data: []byte(css)},
+	})
+	require.NoError(t, err)
+
+	font := path.Base(fsys.Name("fonts/font.woff2"))
+	b, err := fs.ReadFile(fsys, fsys.Name("fonts/fonts.css"))
+	require.NoError(t, err)
+	assert.Equal(t, `@font-face{src:url(`+font+`) format("woff2")}`+
+		`@font-face{src:url("../fonts/`+font+`?v=1#x")}`+
+		`a{background:url(data:image/png;base64,AA==)}`+
+		`b{background:url(/logo.png)}`+
+		`c{background:url('missing.png')}`, string(b), "relative url()s of fingerprinted files are rewritten")
+
+	b, err = fs.ReadFile(fsys, "fonts/fonts.css")
+	require.NoError(t, err)
+	assert.Equal(t, css, string(b), "the style sheet is served as is at its own path")
+
+	rec := httptest.NewRecorder()
+	fsys.Handler("/docs/_assets").ServeHTTP(rec, httptest.NewRequest("GET", "/docs/_assets/"+fsys.Name("fonts/fonts.css"), nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), "url("+font+")")
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/css")
+}
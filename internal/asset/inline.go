@@ -0,0 +1,93 @@
+package asset
+
+import (
+	"encoding/base64"
+	"html/template"
+	"io/fs"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	scriptEndRe = regexp.MustCompile(`(?i)</(script)`)
+	styleEndRe  = regexp.MustCompile(`(?i)</(style)`)
+	cssURLRe    = regexp.MustCompile(`url\(\s*(['"]?)([^'")]+)(['"]?)\s*\)`)
+)
+
+// mimeTypes covers the asset types missing from the mime package defaults.
+var mimeTypes = map[string]string{
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".ico":   "image/x-icon",
+	".svg":   "image/svg+xml",
+}
+
+// Inline returns a resolver for assets inlined in the page from fsys.
+func Inline(fsys fs.FS) *Resolver {
+	return &Resolver{embedded: true, inline: fsys, url: func(file string) string {
+		return file
+	}}
+}
+
+// Inline reports whether the assets are inlined in the page.
+func (r *Resolver) Inline() bool {
+	return r.inline != nil
+}
+
+// Script returns the content of a JavaScript file, escaped for a script
+// element.
+func (r *Resolver) Script(file string) (template.JS, error) {
+	b, err := fs.ReadFile(r.inline, file)
+	if err != nil {
+		return "", err
+	}
+	// "</script" can only appear in string or regular expression literals,
+	// where "<\/script" has the same meaning.
+	b = scriptEndRe.ReplaceAll(b, []byte(`<\/$1`))
+	return template.JS(b), nil //nolint:gosec // Assets are part of this module.
+}
+
+// Style returns the content of a CSS file, escaped for a style element.
+// Files it references with url() are inlined as data URIs.
+func (r *Resolver) Style(file string) (template.CSS, error) {
+	b, err := fs.ReadFile(r.inline, file)
+	if err != nil {
+		return "", err
+	}
+
+	var readErr error
+	b = cssURLRe.ReplaceAllFunc(b, func(m []byte) []byte {
+		sub := cssURLRe.FindSubmatch(m)
+		ref := string(sub[2])
+		if strings.Contains(ref, ":") || strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "#") {
+			return m
+		}
+		name := path.Join(path.Dir(file), ref)
+		data, err := fs.ReadFile(r.inline, name)
+		if err != nil {
+			readErr = err
+			return m
+		}
+		return []byte(`url("` + dataURI(name, data) + `")`)
+	})
+	if readErr != nil {
+		return "", readErr
+	}
+
+	b = styleEndRe.ReplaceAll(b, []byte(`<\/$1`))
+	return template.CSS(b), nil //nolint:gosec // Assets are part of this module.
+}
+
+func dataURI(name string, data []byte) string {
+	ext := path.Ext(name)
+	typ, ok := mimeTypes[ext]
+	if !ok {
+		typ = mime.TypeByExtension(ext)
+	}
+	if typ == "" {
+		typ = "application/octet-stream"
+	}
+	return "data:" + typ + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
@@ -0,0 +1,62 @@
+package asset_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInline(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":                {Data: []byte(`document.write("<script></script>");var s="</SCRIPT>"`)},
+		"app.css":               {Data: []byte(`body{background:url('img/bg.png')}a{background:url(data:image/png;base64,AA==)}b{content:"</style>"}`)},
+		"img/bg.png":            {Data: []byte("png")},
+		"fonts/fonts.css":       {Data: []byte(`@font-face{src:url("open-sans.woff2") format("woff2")}`)},
+		"fonts/open-sans.woff2": {Data: []byte("woff2")},
+		"broken.css":            {Data: []byte(`a{background:url(missing.png)}`)},
+	}
+	r := asset.Inline(fsys)
+	assert.True(t, r.Inline())
+	assert.True(t, r.Embedded())
+
+	t.Run("when inlining a script", func(t *testing.T) {
+		js, err := r.Script("app.js")
+		require.NoError(t, err)
+		assert.Equal(t, `document.write("<script><\/script>");var s="<\/SCRIPT>"`, string(js))
+	})
+	t.Run("when inlining a style", func(t *testing.T) {
+		css, err := r.Style("app.css")
+		require.NoError(t, err)
+		assert.Equal(t, `body{background:url("data:image/png;base64,cG5n")}a{background:url(data:image/png;base64,AA==)}b{content:"<\/style>"}`, string(css))
+	})
+	t.Run("when inlining fonts", func(t *testing.T) {
+		css, err := r.Style("fonts/fonts.css")
+		require.NoError(t, err)
+		assert.Equal(t, `@font-face{src:url("data:font/woff2;base64,d29mZjI=") format("woff2")}`, string(css))
+	})
+	t.Run("when a file is missing", func(t *testing.T) {
+		_, err := r.Script("missing.js")
+		assert.Error(t, err)
+		_, err = r.Style("broken.css")
+		assert.Error(t, err)
+	})
+	t.Run("when not inlining", func(t *testing.T) {
+		assert.False(t, asset.Local("/assets").Inline())
+	})
+}
+
+func TestNewInline(t *testing.T) {
+	fsys := fstest.MapFS{"redoc.standalone.js": {Data: []byte("redoc")}}
+
+	r, err := asset.New(&config.SpecUI{InlineAssets: true, EmbedAssets: true, AssetsFS: fsys}, asset.ReDoc, config.ProviderCDN{})
+	require.NoError(t, err)
+	assert.True(t, r.Inline())
+	assert.Empty(t, r.Integrity("redoc.standalone.js"))
+
+	_, err = asset.New(&config.SpecUI{InlineAssets: true}, asset.ReDoc, config.ProviderCDN{})
+	assert.ErrorContains(t, err, "requires embedded assets")
+}
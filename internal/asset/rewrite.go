@@ -0,0 +1,61 @@
+package asset
+
+import (
+	"bytes"
+	"io/fs"
+	"regexp"
+)
+
+// Rewrite returns a file system serving the files of fsys, with every match
+// of re in the named file replaced by repl. The file is rewritten once, when
+// Rewrite is called.
+func Rewrite(fsys fs.FS, name string, re *regexp.Regexp, repl string) (fs.FS, error) {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rewriteFS{
+		FS:   fsys,
+		name: name,
+		data: re.ReplaceAll(b, []byte(repl)),
+		info: info,
+	}, nil
+}
+
+type rewriteFS struct {
+	fs.FS
+	name string
+	data []byte
+	info fs.FileInfo
+}
+
+func (f *rewriteFS) Open(name string) (fs.File, error) {
+	if name != f.name {
+		return f.FS.Open(name)
+	}
+	return &memFile{
+		Reader: bytes.NewReader(f.data),
+		info:   memFileInfo{FileInfo: f.info, size: int64(len(f.data))},
+	}, nil
+}
+
+type memFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *memFile) Close() error { return nil }
+
+type memFileInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (i memFileInfo) Size() int64 { return i.size }
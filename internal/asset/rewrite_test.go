@@ -0,0 +1,37 @@
+package asset_test
+
+import (
+	"io/fs"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewrite(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":   {Data: []byte(`load("https://cdn.example.com/a.js");load("https://cdn.example.com/b.js")`)},
+		"other.js": {Data: []byte(`load("https://cdn.example.com/a.js")`)},
+	}
+
+	rewritten, err := asset.Rewrite(fsys, "app.js", regexp.MustCompile(`https://cdn\.example\.com/[^"]+`), "data:,")
+	require.NoError(t, err)
+
+	b, err := fs.ReadFile(rewritten, "app.js")
+	require.NoError(t, err)
+	assert.Equal(t, `load("data:,");load("data:,")`, string(b))
+
+	info, err := fs.Stat(rewritten, "app.js")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(b)), info.Size())
+
+	b, err = fs.ReadFile(rewritten, "other.js")
+	require.NoError(t, err)
+	assert.Equal(t, `load("https://cdn.example.com/a.js")`, string(b))
+
+	_, err = asset.Rewrite(fsys, "missing.js", regexp.MustCompile(`x`), "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package asset
+
+import (
+	"fmt"
+	"reflect"
+	"text/template"
+	"text/template/parse"
+)
+
+// Static returns the provider index template tpl rendered with funcs, the
+// template functions of the handlers, so that it parses without them: the
+// calls are replaced by their values and the conditions on them by the branch
+// they select, leaving the actions on the page data as they are. It backs the
+// deprecated IndexTpl functions of the providers, which pass the functions of
+// a Local resolver for their assets base and of a configuration that neither
+// inlines the specification nor lists versions.
+//
+// Static fails when a call is left that it cannot evaluate, such as one taking
+// page data as argument.
+func Static(tpl string, funcs ...map[string]interface{}) (string, error) {
+	all := map[string]interface{}{}
+	for _, m := range funcs {
+		for name, fn := range m {
+			all[name] = fn
+		}
+	}
+
+	t, err := template.New("index").Funcs(all).Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+	root := t.Tree.Root
+	if root.Nodes, err = staticNodes(root.Nodes, all); err != nil {
+		return "", err
+	}
+	out := root.String()
+
+	// The template must parse without the functions.
+	if _, err := template.New("index").Parse(out); err != nil {
+		return "", fmt.Errorf("static template: %w", err)
+	}
+	return out, nil
+}
+
+// staticNodes returns nodes with the calls to funcs evaluated.
+func staticNodes(nodes []parse.Node, funcs map[string]interface{}) ([]parse.Node, error) {
+	var out []parse.Node
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *parse.ActionNode:
+			v, ok, err := evalPipe(n.Pipe, funcs)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				out = append(out, &parse.TextNode{NodeType: parse.NodeText, Pos: n.Pos, Text: []byte(fmt.Sprint(v))})
+				continue
+			}
+		case *parse.IfNode:
+			v, ok, err := evalPipe(n.Pipe, funcs)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				branch := n.ElseList
+				if truth(v) {
+					branch = n.List
+				}
+				if branch == nil {
+					continue
+				}
+				nodes, err := staticNodes(branch.Nodes, funcs)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, nodes...)
+				continue
+			}
+			if err := staticBranches(&n.BranchNode, funcs); err != nil {
+				return nil, err
+			}
+		case *parse.RangeNode:
+			if err := staticBranches(&n.BranchNode, funcs); err != nil {
+				return nil, err
+			}
+		case *parse.WithNode:
+			if err := staticBranches(&n.BranchNode, funcs); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func staticBranches(n *parse.BranchNode, funcs map[string]interface{}) error {
+	for _, list := range []*parse.ListNode{n.List, n.ElseList} {
+		if list == nil {
+			continue
+		}
+		nodes, err := staticNodes(list.Nodes, funcs)
+		if err != nil {
+			return err
+		}
+		list.Nodes = nodes
+	}
+	return nil
+}
+
+// evalPipe returns the value of pipe and true when it is a single call to
+// funcs, or to not, with constant arguments.
+func evalPipe(pipe *parse.PipeNode, funcs map[string]interface{}) (interface{}, bool, error) {
+	if len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 {
+		return nil, false, nil
+	}
+	return evalCall(pipe.Cmds[0].Args, funcs)
+}
+
+func evalCall(args []parse.Node, funcs map[string]interface{}) (interface{}, bool, error) {
+	ident, ok := args[0].(*parse.IdentifierNode)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var in []reflect.Value
+	for _, arg := range args[1:] {
+		switch arg := arg.(type) {
+		case *parse.StringNode:
+			in = append(in, reflect.ValueOf(arg.Text))
+		case *parse.IdentifierNode:
+			v, ok, err := evalCall([]parse.Node{arg}, funcs)
+			if !ok || err != nil {
+				return nil, ok, err
+			}
+			in = append(in, reflect.ValueOf(v))
+		default:
+			return nil, false, nil
+		}
+	}
+
+	if ident.Ident == "not" {
+		if len(in) != 1 {
+			return nil, false, nil
+		}
+		return !truth(in[0].Interface()), true, nil
+	}
+	fn, ok := funcs[ident.Ident]
+	if !ok {
+		return nil, false, nil
+	}
+	f := reflect.ValueOf(fn)
+	if f.Type().NumIn() != len(in) {
+		return nil, false, fmt.Errorf("%s: called with %d arguments", ident.Ident, len(in))
+	}
+	out := f.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, false, fmt.Errorf("%s: %w", ident.Ident, out[1].Interface().(error))
+	}
+	return out[0].Interface(), true, nil
+}
+
+// truth reports whether v is true in a template condition.
+func truth(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.IsValid() && !rv.IsZero()
+}
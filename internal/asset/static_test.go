@@ -0,0 +1,59 @@
+package asset_test
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatic(t *testing.T) {
+	funcs := template.FuncMap{
+		"inlineSpec": func() bool { return false },
+		"spec":       func() (template.JS, error) { panic("the specification is not inlined") },
+		"versions":   func() (template.HTML, error) { return "", nil },
+	}
+
+	tests := []struct {
+		name     string
+		tpl      string
+		expected string
+		err      string
+	}{
+		{
+			name: "when rendering the asset functions",
+			tpl: `<body>
+{{- versions }}
+{{- if inline }}
+<script>{{ script "app.js" }}</script>
+{{- else }}
+<script src="{{ asset "app.js" }}"{{ integrity "app.js" }}></script>
+{{- end }}
+{{- if not inlineSpec }}<p>{{ .Title }}</p>{{ end }}
+{{- if embedded }}<style>{{ style "fonts.css" }}</style>{{ end }}
+{{- if .Logo }}<img src="{{ .Logo }}">{{ end }}
+<script>init({{ if inlineSpec }}{{ spec }}{{ else }}url{{ end }})</script>`,
+			expected: `<body>
+<script src="https://cdn.example.com/app/app.js"></script><p>{{.Title}}</p>{{if .Logo}}<img src="{{.Logo}}">{{end}}
+<script>init(url)</script>`,
+		},
+		{
+			name: "when a function takes page data",
+			tpl:  `<script src="{{ asset .Script }}"></script>`,
+			err:  `function "asset" not defined`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := asset.Static(tt.tpl, asset.Local("https://cdn.example.com/app/").FuncMap(), funcs)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
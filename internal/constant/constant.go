@@ -1,17 +1,11 @@
 package constant
 
-const (
-	RapiDocAssetBase   = "https://cdn.jsdelivr.net/npm/rapidoc@9.3.8/dist"
-	RapiDocFaviconBase = "https://rapidocweb.com"
-
-	RedocAssetsBase = "https://cdn.jsdelivr.net/npm/redoc@2.5.2/bundles"
-
-	ScalarAssetBase   = "https://cdn.jsdelivr.net/npm/@scalar/api-reference@1.51.0/dist"
-	ScalarFaviconBase = "https://scalar.com"
+// CDNTemplate is the default URL template used to load provider assets.
+const CDNTemplate = "https://cdn.jsdelivr.net/npm/{package}@{version}/{file}"
 
-	StoplightElementsAssetsBase = "https://cdn.jsdelivr.net/npm/@stoplight/elements@9.0.16"
+const (
+	RapiDocFaviconBase          = "https://rapidocweb.com"
+	ScalarFaviconBase           = "https://scalar.com"
 	StoplightElementFaviconBase = "https://docs.stoplight.io"
-
-	SwaggerUIAssetsBase  = "https://cdn.jsdelivr.net/npm/swagger-ui@5.32.1/dist"
-	SwaggerUIFaviconBase = "https://petstore.swagger.io"
+	SwaggerUIFaviconBase        = "https://petstore.swagger.io"
 )
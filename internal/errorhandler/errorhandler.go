@@ -0,0 +1,15 @@
+// Package errorhandler provides the error handler of the handler.
+package errorhandler
+
+import (
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/problem"
+)
+
+// Get returns the error handler configured in cfg, or problem.Write.
+func Get(cfg *config.SpecUI) config.ErrorHandler {
+	if cfg.ErrorHandler != nil {
+		return cfg.ErrorHandler
+	}
+	return problem.Write
+}
@@ -0,0 +1,76 @@
+// Package logger provides the default logger of the handler.
+package logger
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/config"
+)
+
+// Std logs with a standard library logger, in the format of the default
+// log/slog handler: the level, the message and key=value attributes.
+type Std struct {
+	Logger  *log.Logger // Destination, the standard logger when nil
+	Verbose bool        // Log Info messages too; Debug messages are never logged
+}
+
+var std = &Std{}
+
+// Get returns the logger configured in cfg, or one that logs warnings and
+// errors with the standard logger.
+func Get(cfg *config.SpecUI) config.Logger {
+	if cfg.Logger != nil {
+		return cfg.Logger
+	}
+	return std
+}
+
+func (l *Std) Debug(msg string, args ...any) {}
+
+func (l *Std) Info(msg string, args ...any) {
+	if l.Verbose {
+		l.print("INFO", msg, args)
+	}
+}
+
+func (l *Std) Warn(msg string, args ...any) {
+	l.print("WARN", msg, args)
+}
+
+func (l *Std) Error(msg string, args ...any) {
+	l.print("ERROR", msg, args)
+}
+
+func (l *Std) print(level, msg string, args []any) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		key, value := "!BADKEY", args[i]
+		if i+1 < len(args) {
+			key, value = fmt.Sprint(args[i]), args[i+1]
+		}
+		b.WriteByte(' ')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(quote(fmt.Sprint(value)))
+	}
+
+	if l.Logger != nil {
+		l.Logger.Print(b.String())
+		return
+	}
+	log.Print(b.String())
+}
+
+// quote quotes values that would not read as a single attribute.
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStd(t *testing.T) {
+	tests := []struct {
+		name    string
+		verbose bool
+		log     func(l config.Logger)
+		want    string
+	}{
+		{
+			name: "when logging an error",
+			log: func(l config.Logger) {
+				l.Error("failed to load", "path", "/docs/openapi.yaml", "error", errors.New("open spec.yaml: no such file"))
+			},
+			want: "ERROR failed to load path=/docs/openapi.yaml error=\"open spec.yaml: no such file\"\n",
+		},
+		{
+			name: "when logging a warning",
+			log: func(l config.Logger) {
+				l.Warn("invalid", "problem", `missing "version"`, "empty", "", "count", 2)
+			},
+			want: "WARN invalid problem=\"missing \\\"version\\\"\" empty=\"\" count=2\n",
+		},
+		{
+			name: "when a value has no key",
+			log:  func(l config.Logger) { l.Warn("odd", "key", "value", "extra") },
+			want: "WARN odd key=value !BADKEY=extra\n",
+		},
+		{
+			name: "when logging info without verbose",
+			log:  func(l config.Logger) { l.Info("validated") },
+		},
+		{
+			name:    "when logging info with verbose",
+			verbose: true,
+			log:     func(l config.Logger) { l.Info("validated", "problems", 0) },
+			want:    "INFO validated problems=0\n",
+		},
+		{
+			name:    "when logging debug",
+			verbose: true,
+			log:     func(l config.Logger) { l.Debug("served") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(&logger.Std{Logger: log.New(&buf, "", 0), Verbose: tt.verbose})
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Run("when no logger is configured", func(t *testing.T) {
+		assert.IsType(t, &logger.Std{}, logger.Get(&config.SpecUI{}))
+	})
+	t.Run("when a logger is configured", func(t *testing.T) {
+		l := &logger.Std{Verbose: true}
+		assert.Same(t, l, logger.Get(&config.SpecUI{Logger: l}))
+	})
+}
@@ -0,0 +1,175 @@
+// Package page serves HTML pages rendered ahead of requests, with their
+// validators and compressed body.
+package page
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/errorhandler"
+	"github.com/oaswrap/spec-ui/internal/logger"
+	"github.com/oaswrap/spec-ui/problem"
+)
+
+// Page is a pre-rendered HTML page.
+type Page struct {
+	body    []byte
+	gzipped []byte // body compressed with gzip, nil when that is not smaller
+	etag    string
+	err     error               // Error of the rendering, reported on each request
+	onError config.ErrorHandler // Writer of the error response
+}
+
+// Renderer serves the page rendered from a template. The page is rendered
+// again when its key changes, such as the ETag of a specification it inlines,
+// and after a failed render, which is answered with the error handler and not
+// kept.
+type Renderer struct {
+	cfg  *config.SpecUI
+	tpl  *template.Template
+	data any
+	key  func(ctx context.Context) string // Key of the page, nil for a page that never changes
+
+	mu      sync.Mutex
+	page    *Page
+	pageKey string // Key the page was rendered with
+}
+
+// Render returns the renderer of the page made of tpl executed with data, and
+// renders it. key returns the key of the page, see Renderer; it is nil for a
+// page that does not change once rendered. Failures are logged with the
+// logger of cfg.
+func Render(cfg *config.SpecUI, tpl *template.Template, data any, key func(ctx context.Context) string) *Renderer {
+	r := &Renderer{cfg: cfg, tpl: tpl, data: data, key: key}
+	r.current(context.Background())
+	return r
+}
+
+// current returns the page for the current key, rendering it when needed.
+func (r *Renderer) current(ctx context.Context) *Page {
+	var key string
+	if r.key != nil {
+		key = r.key(ctx)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.page != nil && r.page.err == nil && r.pageKey == key {
+		return r.page
+	}
+	r.page, r.pageKey = render(r.cfg, r.tpl, r.data), key
+	return r.page
+}
+
+// Body returns the uncompressed body of the page, or the error of its
+// rendering.
+func (r *Renderer) Body() ([]byte, error) {
+	return r.current(context.Background()).Body()
+}
+
+// ServeHTTP writes the page for the current key, see Page.ServeHTTP.
+func (r *Renderer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.current(req.Context()).ServeHTTP(w, req)
+}
+
+// render executes tpl with data and returns the resulting page.
+func render(cfg *config.SpecUI, tpl *template.Template, data any) *Page {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		logger.Get(cfg).Error("failed to render docs page", "provider", cfg.Provider, "path", cfg.DocsPath, "error", err)
+		return &Page{err: err, onError: errorhandler.Get(cfg)}
+	}
+	return New(buf.Bytes())
+}
+
+// New returns the page made of body.
+func New(body []byte) *Page {
+	sum := sha256.Sum256(body)
+	p := &Page{body: body, etag: `"` + hex.EncodeToString(sum[:16]) + `"`}
+
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	_, _ = zw.Write(body)
+	if zw.Close() == nil && buf.Len() < len(body) {
+		p.gzipped = buf.Bytes()
+	}
+	return p
+}
+
+// Body returns the uncompressed body of the page, or the error of its
+// rendering.
+func (p *Page) Body() ([]byte, error) {
+	return p.body, p.err
+}
+
+// ServeHTTP writes the page, compressed when the client accepts gzip, or
+// 304 Not Modified when the client already has it.
+func (p *Page) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.err != nil {
+		p.onError(w, r, http.StatusInternalServerError, &problem.Error{Message: "failed to render docs page", Err: p.err})
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/html")
+	header.Set("ETag", p.etag)
+	if p.gzipped != nil {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if Matches(r.Header.Get("If-None-Match"), p.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body := p.body
+	if p.gzipped != nil && AcceptsGzip(r.Header.Get("Accept-Encoding")) {
+		body = p.gzipped
+		header.Set("Content-Encoding", "gzip")
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	_, _ = w.Write(body)
+}
+
+// Matches reports whether an If-None-Match header matches etag.
+func Matches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsGzip reports whether an Accept-Encoding header allows gzip.
+func AcceptsGzip(acceptEncoding string) bool {
+	gzipQ, anyQ := -1.0, -1.0
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if f, err := strconv.ParseFloat(value, 64); err == nil && strings.EqualFold(key, "q") {
+				q = f
+			}
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "gzip":
+			gzipQ = q
+		case "*":
+			anyQ = q
+		}
+	}
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+	return anyQ > 0
+}
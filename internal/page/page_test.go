@@ -0,0 +1,134 @@
+package page_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/page"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage(t *testing.T) {
+	body := "<html><body>" + strings.Repeat("<p>Pets</p>", 100) + "</body></html>"
+	p := page.New([]byte(body))
+
+	serve := func(header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, req)
+		return rec
+	}
+	etag := serve(nil).Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	tests := []struct {
+		name     string
+		header   map[string]string
+		status   int
+		encoding string
+	}{
+		{"when the client does not accept gzip", nil, http.StatusOK, ""},
+		{"when the client accepts gzip", map[string]string{"Accept-Encoding": "br, gzip"}, http.StatusOK, "gzip"},
+		{"when the client accepts any encoding", map[string]string{"Accept-Encoding": "*;q=0.5"}, http.StatusOK, "gzip"},
+		{"when the client refuses gzip", map[string]string{"Accept-Encoding": "gzip;q=0, *"}, http.StatusOK, ""},
+		{"when the client has a low preference for gzip", map[string]string{"Accept-Encoding": "gzip;q=0.05"}, http.StatusOK, "gzip"},
+		{"when the client has the page", map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified, ""},
+		{"when the client has another page", map[string]string{"If-None-Match": `"other"`}, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.header)
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Equal(t, tt.encoding, rec.Header().Get("Content-Encoding"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+			assert.Equal(t, "text/html", rec.Header().Get("Content-Type"))
+			assert.Equal(t, rec.Header().Get("Content-Length"), strconv.Itoa(rec.Body.Len()))
+
+			got := rec.Body.Bytes()
+			if tt.encoding == "gzip" {
+				assert.Less(t, len(got), len(body))
+				zr, err := gzip.NewReader(bytes.NewReader(got))
+				require.NoError(t, err)
+				got, err = io.ReadAll(zr)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, body, string(got))
+		})
+	}
+}
+
+func TestPageSmall(t *testing.T) {
+	p := page.New([]byte("<p>"))
+	req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, req)
+	assert.Equal(t, "<p>", rec.Body.String(), "pages that gzip does not shrink are sent as is")
+	assert.Empty(t, rec.Header().Get("Vary"))
+}
+
+func TestRender(t *testing.T) {
+	t.Run("when the template executes", func(t *testing.T) {
+		p := page.Render(&config.SpecUI{}, template.Must(template.New("index").Parse("<h1>{{ . }}</h1>")), "Pets", nil)
+		body, err := p.Body()
+		require.NoError(t, err)
+		assert.Equal(t, "<h1>Pets</h1>", string(body))
+	})
+	t.Run("when the template fails", func(t *testing.T) {
+		fail := template.FuncMap{"fail": func() (string, error) { return "", errors.New("spec not found") }}
+		p := page.Render(&config.SpecUI{}, template.Must(template.New("index").Funcs(fail).Parse("<p>partial</p>{{ fail }}")), nil, nil)
+		_, err := p.Body()
+		require.Error(t, err)
+
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "failed to render docs page")
+		assert.NotContains(t, rec.Body.String(), "spec not found", "the template error is not served")
+		assert.NotContains(t, rec.Body.String(), "partial")
+	})
+	t.Run("when the template fails once", func(t *testing.T) {
+		failed := false
+		fail := template.FuncMap{"fail": func() (string, error) {
+			if !failed {
+				failed = true
+				return "", errors.New("spec unavailable")
+			}
+			return "Pets", nil
+		}}
+		p := page.Render(&config.SpecUI{}, template.Must(template.New("index").Funcs(fail).Parse("<h1>{{ fail }}</h1>")), nil, nil)
+		body, err := p.Body()
+		require.NoError(t, err, "a failed render is not kept")
+		assert.Equal(t, "<h1>Pets</h1>", string(body))
+	})
+	t.Run("when the key changes", func(t *testing.T) {
+		title := "Pets"
+		funcs := template.FuncMap{"title": func() string { return title }}
+		key := func(context.Context) string { return title }
+		p := page.Render(&config.SpecUI{}, template.Must(template.New("index").Funcs(funcs).Parse("<h1>{{ title }}</h1>")), nil, key)
+
+		title = "Stores"
+		body, err := p.Body()
+		require.NoError(t, err)
+		assert.Equal(t, "<h1>Stores</h1>", string(body))
+	})
+}
@@ -0,0 +1,105 @@
+// Package providertest holds the checks shared by the tests of the provider
+// *emb packages, so that each package only tests its own configuration.
+package providertest
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"io/fs"
+	"net/http/httptest"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Provider describes the embedded assets of a provider *emb package.
+type Provider struct {
+	WithUI    specui.Option     // WithUI option of the package, with the default configuration
+	Assets    fs.FS             // Vendored files, as embedded before any rewriting
+	Integrity map[string]string // SRI hashes generated for the pinned version, see internal/constant
+	Script    string            // Main script of the provider, referenced by the docs page
+}
+
+// Assets checks the embedded assets of p: the SRI hashes match the vendored
+// files, neither the docs page nor the assets served reference other origins,
+// and the docs page references the fingerprinted assets.
+func Assets(t *testing.T, p Provider) {
+	t.Helper()
+
+	newConfig := func() *config.SpecUI {
+		cfg := &config.SpecUI{
+			Title:      "My API",
+			DocsPath:   "/docs",
+			SpecPath:   "/docs/openapi.json",
+			AssetsPath: "/docs/_assets",
+		}
+		p.WithUI(cfg)
+		return cfg
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, cfg *config.SpecUI)
+	}{
+		{
+			name: "when hashing the vendored files",
+			run: func(t *testing.T, _ *config.SpecUI) {
+				for file, hash := range p.Integrity {
+					b, err := fs.ReadFile(p.Assets, file)
+					require.NoError(t, err)
+
+					sum := sha512.Sum384(b)
+					assert.Equal(t, "sha384-"+base64.StdEncoding.EncodeToString(sum[:]), hash, file)
+				}
+			},
+		},
+		{
+			name: "when serving the docs page and the assets",
+			run: func(t *testing.T, cfg *config.SpecUI) {
+				docsRec := httptest.NewRecorder()
+				cfg.DocsHandlerFactory(cfg).ServeHTTP(docsRec, httptest.NewRequest("GET", "/docs", nil))
+				assert.Empty(t, asset.ExternalURLs("index.html", docsRec.Body.Bytes()), "docs page")
+
+				assets := cfg.AssetsHandlerFactory(cfg)
+				err := fs.WalkDir(p.Assets, ".", func(name string, d fs.DirEntry, err error) error {
+					if err != nil || d.IsDir() {
+						return err
+					}
+					rec := httptest.NewRecorder()
+					assets.ServeHTTP(rec, httptest.NewRequest("GET", "/docs/_assets/"+name, nil))
+					assert.Equal(t, 200, rec.Code, name)
+					assert.Empty(t, asset.ExternalURLs(name, rec.Body.Bytes()), name)
+					return nil
+				})
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "when referencing fingerprinted assets",
+			run: func(t *testing.T, cfg *config.SpecUI) {
+				fsys, ok := cfg.AssetsFS.(*asset.FS)
+				require.True(t, ok, "the assets are fingerprinted")
+				name := fsys.Name(p.Script)
+				assert.NotEqual(t, p.Script, name)
+
+				docsRec := httptest.NewRecorder()
+				cfg.DocsHandlerFactory(cfg).ServeHTTP(docsRec, httptest.NewRequest("GET", "/docs", nil))
+				assert.Contains(t, docsRec.Body.String(), "/docs/_assets/"+name)
+
+				assetsRec := httptest.NewRecorder()
+				cfg.AssetsHandlerFactory(cfg).ServeHTTP(assetsRec, httptest.NewRequest("GET", "/docs/_assets/"+name, nil))
+				assert.Equal(t, 200, assetsRec.Code)
+				assert.Equal(t, "public, max-age=31536000, immutable", assetsRec.Header().Get("Cache-Control"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newConfig())
+		})
+	}
+}
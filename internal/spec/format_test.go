@@ -0,0 +1,129 @@
+package spec_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestToJSON(t *testing.T) {
+	t.Run("when converting YAML", func(t *testing.T) {
+		in := "openapi: 3.0.3\ninfo:\n  version: \"1.0\"\n  title: Pets\npaths:\n  /pets:\n    get:\n      responses:\n        200:\n          description: &ok OK\n        404:\n          description: *ok\nx-count: 0x10\nx-empty:\n"
+		b, err := spec.ToJSON([]byte(in))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"openapi": "3.0.3",
+			"info": {"version": "1.0", "title": "Pets"},
+			"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}, "404": {"description": "OK"}}}}},
+			"x-count": 16,
+			"x-empty": null
+		}`, string(b))
+		assert.Regexp(t, `(?s)"version".*"title"`, string(b), "key order is kept")
+	})
+	t.Run("when the YAML is invalid", func(t *testing.T) {
+		_, err := spec.ToJSON([]byte("openapi: [3.0"))
+		assert.Error(t, err)
+	})
+	t.Run("when a value has no JSON equivalent", func(t *testing.T) {
+		_, err := spec.ToJSON([]byte("x-max: .inf\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestToYAML(t *testing.T) {
+	b, err := spec.ToYAML([]byte(`{"openapi": "3.0.3", "info": {"version": "1.0", "title": "Pets"}, "paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.0.3
+info:
+  version: "1.0"
+  title: Pets
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+`, string(b))
+}
+
+func TestLoad(t *testing.T) {
+	cfg := &config.SpecUI{SpecSource: source.FS{FS: &testdata.FS, Path: "petstore.yaml"}}
+
+	b, err := spec.Load(cfg, source.FormatJSON)
+	require.NoError(t, err)
+	want, err := testdata.FS.ReadFile("petstore.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(b))
+
+	b, err = spec.Load(cfg, source.FormatYAML)
+	require.NoError(t, err)
+	raw, err := testdata.FS.ReadFile("petstore.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, string(raw), string(b), "files in the requested format are returned as stored")
+
+	cfg = &config.SpecUI{SpecSource: source.FS{FS: &testdata.FS, Path: "petstore.json"}}
+	b, err = spec.Load(cfg, source.FormatYAML)
+	require.NoError(t, err)
+	var got, expected interface{}
+	require.NoError(t, yaml.Unmarshal(b, &got))
+	require.NoError(t, json.Unmarshal(want, &expected))
+	assert.Equal(t, normalize(expected), normalize(got))
+
+	_, err = spec.Load(&config.SpecUI{}, source.FormatJSON)
+	assert.ErrorIs(t, err, spec.ErrNotSet)
+}
+
+// normalize converts numbers decoded from YAML and JSON to a common type.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = normalize(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+	case int:
+		return float64(v)
+	}
+	return v
+}
+
+func TestScript(t *testing.T) {
+	t.Run("when the specification needs escaping", func(t *testing.T) {
+		js, err := spec.Script([]byte("{\n  \"info\": {\"description\": \"</script><!-- & \u2028\"}\n}"))
+		require.NoError(t, err)
+		assert.Equal(t, `{"info":{"description":"\u003c/script\u003e\u003c!-- \u0026 \u2028"}}`, string(js))
+	})
+	t.Run("when the specification is YAML", func(t *testing.T) {
+		b, err := testdata.FS.ReadFile("petstore.yaml")
+		require.NoError(t, err)
+		js, err := spec.Script(b)
+		require.NoError(t, err)
+		assert.Contains(t, string(js), `{"openapi":"3.0.4","info":{"title":"Swagger Petstore - OpenAPI 3.0"`)
+	})
+	t.Run("when the specification is not valid JSON", func(t *testing.T) {
+		_, err := spec.Script([]byte("{"))
+		assert.Error(t, err)
+	})
+}
+
+type rawGenerator struct {
+	json string
+}
+
+func (g *rawGenerator) MarshalJSON() ([]byte, error) {
+	return []byte(g.json), nil
+}
+
+func (g *rawGenerator) MarshalYAML() ([]byte, error) {
+	return []byte(g.json), nil
+}
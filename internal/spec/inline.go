@@ -0,0 +1,78 @@
+package spec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html/template"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/source"
+)
+
+// Script returns the specification b as a JSON value that is safe to embed in
+// a script element.
+func Script(b []byte) (template.JS, error) {
+	if format, _ := source.Detect(b); format != source.FormatJSON {
+		var err error
+		if b, err = ToJSON(b); err != nil {
+			return "", err
+		}
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, b); err != nil {
+		return "", err
+	}
+	// Escape <, > and & so that the document cannot close the script
+	// element, and U+2028 and U+2029, which end JavaScript string literals.
+	var buf bytes.Buffer
+	json.HTMLEscape(&buf, compact.Bytes())
+	return template.JS(buf.String()), nil //nolint:gosec // The JSON is escaped.
+}
+
+// Inline embeds the specification in the docs pages of the providers. It
+// takes the specification from the loader of cfg, see
+// config.SpecUI.SpecLoader, so that the pages show the specification served at
+// the spec path, or from a handler of its own when none is set.
+type Inline struct {
+	cfg  *config.SpecUI
+	load func(ctx context.Context) ([]byte, string, error)
+}
+
+// NewInline returns the inliner of the specification configured in cfg.
+func NewInline(cfg *config.SpecUI) *Inline {
+	load := cfg.SpecLoader
+	if load == nil {
+		load = NewHandler(cfg).Current
+	}
+	return &Inline{cfg: cfg, load: load}
+}
+
+// FuncMap returns the template functions used by provider index templates to
+// embed the specification in the docs page.
+func (i *Inline) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"inlineSpec": func() bool {
+			return i.cfg.InlineSpec
+		},
+		"spec": func() (template.JS, error) {
+			b, _, err := i.load(context.Background())
+			if err != nil {
+				return "", err
+			}
+			return Script(b)
+		},
+	}
+}
+
+// ETag returns the ETag of the specification embedded in the docs page, which
+// must be rendered again when it changes. It is "" when the specification is
+// not inlined, or fails to load.
+func (i *Inline) ETag(ctx context.Context) string {
+	if !i.cfg.InlineSpec {
+		return ""
+	}
+	_, etag, _ := i.load(ctx)
+	return etag
+}
@@ -1,108 +1,451 @@
 package spec
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"io"
+	"fmt"
 	"io/fs"
-	"log"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/errorhandler"
+	"github.com/oaswrap/spec-ui/internal/logger"
+	"github.com/oaswrap/spec-ui/internal/page"
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/oaswrap/spec-ui/problem"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/oaswrap/spec-ui/validate"
 )
 
+// ErrNotSet is returned when no specification source is configured.
+var ErrNotSet = errors.New("OpenAPI specification file is not set")
+
+// retryAfter is the Retry-After header of the responses to requests made
+// while the specification is temporarily unavailable.
+const retryAfter = "5"
+
+// loadTimeout is the timeout of a load of the specification. Loads are not
+// canceled with the request that started them, since other requests wait
+// for them too.
+const loadTimeout = time.Minute
+
 type Handler struct {
 	cfg      *config.SpecUI
 	fileType string
-	once     sync.Once
-	schema   []byte
+	pipeline *Pipeline
+	mu       sync.Mutex   // Guards loading
+	loading  *loadCall    // Load in progress, nil when none
+	state    atomic.Value // *result of the last load
+}
+
+// loadCall is a load of the specification in progress.
+type loadCall struct {
+	done chan struct{} // Closed when res is set
+	res  *result
+}
+
+// result is the result of a load of the specification.
+type result struct {
+	source   []byte          // Specification as read, before transforms
+	schema   []byte          // Specification as served
+	etag     string          // ETag of schema
+	warnings validate.Errors // Constructs that transforms could not convert
 	err      error
+	final    bool  // False when the load failed with an error that may not happen again
+	expires  int64 // Unix time in nanoseconds the result must be refreshed at, zero for never; accessed atomically
+
+	validateOnce sync.Once
+	problems     validate.Errors
+	validateErr  error
+}
+
+// fresh reports whether r can be used without loading the specification
+// again.
+func (r *result) fresh(now time.Time) bool {
+	if r == nil || !r.final {
+		return false
+	}
+	expires := atomic.LoadInt64(&r.expires)
+	return expires == 0 || now.UnixNano() < expires
 }
 
 func NewHandler(cfg *config.SpecUI) *Handler {
-	fileType := "yaml"
+	fileType := source.FormatYAML
 	if strings.HasSuffix(cfg.SpecPath, ".json") {
-		fileType = "json"
+		fileType = source.FormatJSON
 	}
-	return &Handler{cfg: cfg, fileType: fileType}
+	return &Handler{cfg: cfg, fileType: fileType, pipeline: NewPipeline(cfg)}
 }
 
-func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if h.cfg.SpecGenerator != nil {
-		h.once.Do(func() {
-			if h.fileType == "json" {
-				h.schema, h.err = h.cfg.SpecGenerator.MarshalJSON()
-				return
-			}
-			h.schema, h.err = h.cfg.SpecGenerator.MarshalYAML()
-		})
+// Load returns the specification, reading it on the first call.
+func (h *Handler) Load() ([]byte, error) {
+	return h.LoadContext(context.Background())
+}
 
-		if h.err != nil {
-			h.renderError(w, 500, errors.New("failed to generate OpenAPI schema"))
-			return
-		}
-	} else if h.cfg.SpecEmbedFS != nil {
-		h.once.Do(func() {
-			h.schema, h.err = h.cfg.SpecEmbedFS.ReadFile(h.cfg.SpecFile)
+// LoadContext returns the specification, loading it from the source on the
+// first call. The result is cached, unless the load failed with an error that
+// may not happen again, such as an I/O failure, in which case the next call
+// loads it again. Sources implementing source.Refresher are loaded again once
+// the refresh interval has passed, in the background while the previous
+// specification is served; when that fails, the previous specification is
+// kept.
+func (h *Handler) LoadContext(ctx context.Context) ([]byte, error) {
+	r := h.result(ctx)
+	return r.schema, r.err
+}
+
+// Current returns the specification like LoadContext, and its ETag, which
+// changes when the specification does.
+func (h *Handler) Current(ctx context.Context) (schema []byte, etag string, err error) {
+	r := h.result(ctx)
+	return r.schema, r.etag, r.err
+}
+
+// result returns the result of the last load of the specification, loading
+// it when it is not fresh. A stale specification is served while it is
+// loaded again in the background; otherwise the caller waits for the load,
+// which is shared with concurrent callers, until ctx is done.
+func (h *Handler) result(ctx context.Context) *result {
+	r, _ := h.state.Load().(*result)
+	if r.fresh(time.Now()) {
+		return r
+	}
+	if err := ctx.Err(); err != nil {
+		return &result{err: &readError{err: err}}
+	}
+
+	h.mu.Lock()
+	if r, _ = h.state.Load().(*result); r.fresh(time.Now()) {
+		h.mu.Unlock()
+		return r
+	}
+	call, leader := h.loading, false
+	if call == nil {
+		call, leader = &loadCall{done: make(chan struct{})}, true
+		h.loading = call
+	}
+	h.mu.Unlock()
+
+	stale := r != nil && r.err == nil
+	switch {
+	case leader && stale:
+		go h.refresh(call)
+		return r
+	case leader:
+		h.refresh(call)
+		return call.res
+	case stale:
+		return r
+	}
+	select {
+	case <-call.done:
+		return call.res
+	case <-ctx.Done():
+		return &result{err: &readError{err: ctx.Err()}}
+	}
+}
+
+// refresh loads the specification for call, and stores the result unless
+// the load failed and the previous specification can still be served.
+func (h *Handler) refresh(call *loadCall) {
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
+
+	r, _ := h.state.Load().(*result)
+	next := h.load(ctx)
+	switch {
+	case r != nil && r.err == nil && (next.err != nil || bytes.Equal(next.source, r.source)):
+		// Keep the previous specification, and its validation.
+		atomic.StoreInt64(&r.expires, h.expiry())
+		next = r
+	case next.err == nil:
+		next.expires = h.expiry()
+		h.state.Store(next)
+	default:
+		h.state.Store(next)
+	}
+
+	h.mu.Lock()
+	call.res = next
+	h.loading = nil
+	h.mu.Unlock()
+	close(call.done)
+}
+
+// expiry returns the time a specification loaded now must be refreshed at.
+func (h *Handler) expiry() int64 {
+	if Refreshes(h.cfg.SpecSource) {
+		return time.Now().Add(h.cfg.SpecSource.(source.Refresher).RefreshInterval()).UnixNano()
+	}
+	return 0
+}
+
+// Refreshes reports whether the specification of src is loaded again
+// periodically.
+func Refreshes(src config.SpecSource) bool {
+	r, ok := src.(source.Refresher)
+	return ok && r.RefreshInterval() > 0
+}
+
+// etag returns the ETag of the specification b.
+func etag(b []byte) string {
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func (h *Handler) load(ctx context.Context) *result {
+	r := &result{}
+	start := time.Now()
+	var format string
+	r.source, format, r.err = Read(ctx, h.cfg, h.fileType)
+	if r.err != nil {
+		r.source, r.err = nil, &readError{err: r.err}
+	} else if r.source, r.err = h.convert(r.source, format); r.err == nil {
+		r.schema, r.warnings, r.err = h.pipeline.Apply(r.source, "")
+	}
+	if r.err == nil {
+		r.etag = etag(r.schema)
+	}
+	r.final = !h.retryable(r.err)
+	if r.err != nil {
+		logger.Get(h.cfg).Error("failed to load OpenAPI specification",
+			"provider", h.cfg.Provider, "path", h.cfg.SpecPath, "source", sourceName(h.cfg), "error", r.err)
+	}
+	if h.cfg.Observer != nil {
+		h.cfg.Observer.ObserveLoad(observe.Load{
+			Provider: h.cfg.Provider.String(),
+			Path:     h.cfg.SpecPath,
+			Source:   sourceName(h.cfg),
+			Bytes:    len(r.schema),
+			Start:    start,
+			Duration: time.Since(start),
+			Err:      r.err,
 		})
+	}
+	return r
+}
 
-		if h.err != nil {
-			h.renderError(w, 404, errors.New("OpenAPI specification file is not found"))
-			return
+// convert converts b, a document in format, to the format of the spec path
+// when it is loaded from a store, whose versions may be stored in either
+// format but are served at the same path. Other sources are served as stored.
+func (h *Handler) convert(b []byte, format string) ([]byte, error) {
+	if _, ok := h.cfg.SpecSource.(store.Source); !ok || format == h.fileType {
+		return b, nil
+	}
+	if h.fileType == source.FormatJSON {
+		return ToJSON(b)
+	}
+	return ToYAML(b)
+}
+
+// readError is an error reading the specification, as opposed to
+// transforming it.
+type readError struct {
+	err error
+}
+
+func (e *readError) Error() string {
+	return e.err.Error()
+}
+
+func (e *readError) Unwrap() error {
+	return e.err
+}
+
+// retryable reports whether err is a load error that may not happen again,
+// such as an I/O failure reading the specification file. It is false for
+// missing files, permission errors, generator failures and transform errors.
+func (h *Handler) retryable(err error) bool {
+	status, _ := loadStatus(h.cfg, err)
+	return status == http.StatusServiceUnavailable
+}
+
+// loadStatus returns the HTTP status of the load error err, and the error
+// passed to the error handler, which shows clients a message that does not
+// reveal file paths or URLs.
+func loadStatus(cfg *config.SpecUI, err error) (int, error) {
+	var read *readError
+	_, generator := cfg.SpecSource.(source.Generator)
+	switch {
+	case err == nil:
+		return http.StatusOK, nil
+	case errors.Is(err, ErrNotSet):
+		return http.StatusInternalServerError, err
+	case !errors.As(err, &read):
+		// The specification was read but could not be transformed.
+		return http.StatusInternalServerError, err
+	case generator:
+		return http.StatusInternalServerError, &problem.Error{Message: "failed to generate OpenAPI schema", Err: read.err}
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound, &problem.Error{Message: "OpenAPI specification version is not found", Err: read.err}
+	case errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound, &problem.Error{Message: "OpenAPI specification file is not found", Err: read.err}
+	case errors.Is(err, fs.ErrPermission):
+		return http.StatusInternalServerError, &problem.Error{Message: "failed to read OpenAPI specification file", Err: read.err}
+	default:
+		return http.StatusServiceUnavailable, &problem.Error{Message: "OpenAPI specification is temporarily unavailable", Err: read.err}
+	}
+}
+
+// sourceName returns the name of the specification source configured in cfg,
+// for logs and observers: the path of a file, the URL of a remote source, or
+// "generator".
+func sourceName(cfg *config.SpecUI) string {
+	switch src := cfg.SpecSource.(type) {
+	case nil:
+		return "none"
+	case fmt.Stringer:
+		return src.String()
+	default:
+		return "custom"
+	}
+}
+
+// Validate loads the specification and checks it against the schema of its
+// OpenAPI version. A transformed specification is validated as read, and the
+// transform warnings are appended to the problems found. The result is
+// computed once per version of the specification; in warn mode the problems
+// found are logged.
+func (h *Handler) Validate() (validate.Errors, error) {
+	r := h.result(context.Background())
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.validateOnce.Do(func() {
+		r.problems, r.validateErr = validate.Document(r.source)
+		if r.validateErr == nil && len(r.warnings) > 0 {
+			r.problems = append(r.problems, r.warnings...)
 		}
-	} else if h.cfg.SpecIOFS != nil {
-		h.once.Do(func() {
-			var file fs.File
-			file, h.err = h.cfg.SpecIOFS.Open(h.cfg.SpecFile)
-			if h.err == nil {
-				h.schema, h.err = io.ReadAll(file)
-				if closeErr := file.Close(); closeErr != nil && h.err == nil {
-					h.err = closeErr
-				}
+		log := logger.Get(h.cfg)
+		switch {
+		case r.validateErr != nil:
+			log.Error("failed to validate OpenAPI specification", "path", h.cfg.SpecPath, "error", r.validateErr)
+			return
+		case h.cfg.Validation == config.ValidationWarn:
+			for _, p := range r.problems {
+				log.Warn("invalid OpenAPI specification", "path", h.cfg.SpecPath, "problem", p.Error())
 			}
-		})
+		}
+		log.Info("validated OpenAPI specification", "path", h.cfg.SpecPath, "problems", len(r.problems))
+	})
+	return r.problems, r.validateErr
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res := h.result(r.Context())
+	if res.err != nil {
+		h.ServeError(w, r, res.err)
+		return
+	}
 
-		if h.err != nil {
-			h.renderError(w, 404, errors.New("OpenAPI specification file is not found"))
+	switch h.cfg.Validation {
+	case config.ValidationWarn:
+		_, _ = h.Validate()
+	case config.ValidationFail:
+		problems, err := h.Validate()
+		if err == nil && len(problems) > 0 {
+			err = problems
+		}
+		if err != nil {
+			h.renderError(w, r, 500, err)
 			return
 		}
-	} else if h.cfg.SpecFile != "" {
-		h.once.Do(func() {
-			h.schema, h.err = os.ReadFile(h.cfg.SpecFile)
-		})
+	}
 
-		if h.err != nil {
-			h.renderError(w, 404, errors.New("OpenAPI specification file is not found"))
+	schema, tag := res.schema, res.etag
+	if h.cfg.SpecVariant != nil {
+		variant := h.cfg.SpecVariant(r)
+		b, _, err := h.pipeline.Apply(res.source, variant)
+		if err != nil {
+			logger.Get(h.cfg).Error("failed to transform OpenAPI specification", "path", h.cfg.SpecPath, "variant", variant, "error", err)
+			h.renderError(w, r, 500, err)
 			return
 		}
-	} else {
-		h.renderError(w, 500, errors.New("OpenAPI specification file is not set"))
-		return
+		schema, tag = b, etag(b)
 	}
 
-	if h.fileType == "json" {
+	if h.fileType == source.FormatJSON {
 		w.Header().Set("Content-Type", "application/json")
 	} else {
 		w.Header().Set("Content-Type", "application/x-yaml")
 	}
-	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(h.cfg.CacheAge)+", immutable")
+	// A specification that is refreshed is revalidated by clients, rather
+	// than kept for the cache age.
+	if Refreshes(h.cfg.SpecSource) {
+		w.Header().Set("Cache-Control", "public, no-cache")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(h.cfg.CacheAge)+", immutable")
+	}
+	w.Header().Set("ETag", tag)
+	if page.Matches(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	_, err := w.Write(h.schema)
-	if err != nil {
-		log.Printf("failed to write OpenAPI schema: %v", err)
+	if _, err := w.Write(schema); err != nil {
+		logger.Get(h.cfg).Error("failed to write OpenAPI specification", "path", h.cfg.SpecPath, "error", err)
 		return
 	}
 }
 
-func (h *Handler) renderError(w http.ResponseWriter, status int, err error) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(map[string]any{
-		"status":  status,
-		"message": err.Error(),
-	})
+// Read returns the specification loaded from the source configured in cfg,
+// and its format as reported by the source, or detected when it reports none.
+// Sources that can produce either format, such as generators, are asked for
+// format ("json" or "yaml"); files are returned as stored.
+func Read(ctx context.Context, cfg *config.SpecUI, format string) ([]byte, string, error) {
+	if cfg.SpecSource == nil {
+		return nil, "", ErrNotSet
+	}
+	b, loaded, _, err := cfg.SpecSource.Load(source.WithFormat(ctx, format))
+	if err != nil {
+		return nil, "", err
+	}
+	if loaded == "" {
+		loaded, _ = source.Detect(b)
+	}
+	return b, loaded, nil
+}
+
+// Load returns the specification configured in cfg, converted to format
+// ("json" or "yaml") when it is stored in the other one.
+func Load(cfg *config.SpecUI, format string) ([]byte, error) {
+	b, loaded, err := Read(context.Background(), cfg, format)
+	if err != nil {
+		return nil, err
+	}
+	if b, _, err = Transform(cfg, b); err != nil {
+		return nil, err
+	}
+	if loaded == format {
+		return b, nil
+	}
+	if format == source.FormatJSON {
+		return ToJSON(b)
+	}
+	return ToYAML(b)
+}
+
+// ServeError writes the error response to r for err, an error of Load or
+// Validate, with the error handler: the status tells missing specifications
+// from temporary failures, and the message does not reveal file paths or
+// URLs.
+func (h *Handler) ServeError(w http.ResponseWriter, r *http.Request, err error) {
+	status, err := loadStatus(h.cfg, err)
+	if status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+	h.renderError(w, r, status, err)
+}
+
+func (h *Handler) renderError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	errorhandler.Get(h.cfg)(w, r, status, err)
 }
@@ -1,15 +1,24 @@
 package spec_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/oaswrap/spec-ui/config"
 	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/source"
 	"github.com/oaswrap/spec-ui/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandler(t *testing.T) {
@@ -23,27 +32,24 @@ func TestHandler(t *testing.T) {
 		{
 			name: "when serving OpenAPI YAML from embed FS",
 			config: &config.SpecUI{
-				SpecPath:    "/docs/openapi.yaml",
-				SpecFile:    "petstore.yaml",
-				SpecEmbedFS: &testdata.FS,
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.FS{FS: &testdata.FS, Path: "petstore.yaml"},
 			},
 			contentType: "application/x-yaml",
 		},
 		{
 			name: "when serving OpenAPI JSON from embed FS",
 			config: &config.SpecUI{
-				SpecPath:    "/docs/openapi.json",
-				SpecFile:    "petstore.json",
-				SpecEmbedFS: &testdata.FS,
+				SpecPath:   "/docs/openapi.json",
+				SpecSource: source.FS{FS: &testdata.FS, Path: "petstore.json"},
 			},
 			contentType: "application/json",
 		},
 		{
 			name: "when serving OpenAPI YAML from embed FS and not found",
 			config: &config.SpecUI{
-				SpecPath:    "/docs/openapi.yaml",
-				SpecFile:    "notexists.yaml",
-				SpecEmbedFS: &testdata.FS,
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.FS{FS: &testdata.FS, Path: "notexists.yaml"},
 			},
 			shouldError: true,
 			errorStatus: 404,
@@ -51,24 +57,24 @@ func TestHandler(t *testing.T) {
 		{
 			name: "when serving OpenAPI YAML from OS FS",
 			config: &config.SpecUI{
-				SpecPath: "/docs/openapi.yaml",
-				SpecFile: "../../testdata/petstore.yaml",
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.File{Path: "../../testdata/petstore.yaml"},
 			},
 			contentType: "application/x-yaml",
 		},
 		{
 			name: "when serving OpenAPI JSON from OS FS",
 			config: &config.SpecUI{
-				SpecPath: "/docs/openapi.json",
-				SpecFile: "../../testdata/petstore.json",
+				SpecPath:   "/docs/openapi.json",
+				SpecSource: source.File{Path: "../../testdata/petstore.json"},
 			},
 			contentType: "application/json",
 		},
 		{
 			name: "when serving OpenAPI YAML from OS FS and not found",
 			config: &config.SpecUI{
-				SpecPath: "/docs/openapi.yaml",
-				SpecFile: "../../testdata/notexists.yaml",
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.File{Path: "../../testdata/notexists.yaml"},
 			},
 			shouldError: true,
 			errorStatus: 404,
@@ -76,27 +82,24 @@ func TestHandler(t *testing.T) {
 		{
 			name: "when serving OpenAPI YAML from IOFS",
 			config: &config.SpecUI{
-				SpecPath: "/docs/openapi.yaml",
-				SpecFile: "petstore.yaml",
-				SpecIOFS: os.DirFS("../../testdata"),
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.FS{FS: os.DirFS("../../testdata"), Path: "petstore.yaml"},
 			},
 			contentType: "application/x-yaml",
 		},
 		{
 			name: "when serving OpenAPI JSON from IOFS",
 			config: &config.SpecUI{
-				SpecPath: "/docs/openapi.json",
-				SpecFile: "petstore.json",
-				SpecIOFS: os.DirFS("../../testdata"),
+				SpecPath:   "/docs/openapi.json",
+				SpecSource: source.FS{FS: os.DirFS("../../testdata"), Path: "petstore.json"},
 			},
 			contentType: "application/json",
 		},
 		{
 			name: "when serving OpenAPI JSON from IOFS and not found",
 			config: &config.SpecUI{
-				SpecPath: "/docs/openapi.json",
-				SpecFile: "notexists.json",
-				SpecIOFS: os.DirFS("../../testdata"),
+				SpecPath:   "/docs/openapi.json",
+				SpecSource: source.FS{FS: os.DirFS("../../testdata"), Path: "notexists.json"},
 			},
 			shouldError: true,
 			errorStatus: 404,
@@ -104,31 +107,46 @@ func TestHandler(t *testing.T) {
 		{
 			name: "when serving OpenAPI YAML from SpecGenerator",
 			config: &config.SpecUI{
-				SpecPath:      "/docs/openapi.yaml",
-				SpecFile:      "petstore.yaml",
-				SpecGenerator: &mockGenerator{},
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.Generator{Generator: &mockGenerator{}},
 			},
 			contentType: "application/x-yaml",
 		},
 		{
 			name: "when serving OpenAPI JSON from SpecGenerator",
 			config: &config.SpecUI{
-				SpecPath:      "/docs/openapi.json",
-				SpecFile:      "petstore.json",
-				SpecGenerator: &mockGenerator{},
+				SpecPath:   "/docs/openapi.json",
+				SpecSource: source.Generator{Generator: &mockGenerator{}},
 			},
 			contentType: "application/json",
 		},
 		{
 			name: "when serving OpenAPI YAML from SpecGenerator and failure",
 			config: &config.SpecUI{
-				SpecPath:      "/docs/openapi.yaml",
-				SpecFile:      "petstore.yaml",
-				SpecGenerator: &mockGenerator{shouldFail: true},
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.Generator{Generator: &mockGenerator{shouldFail: true}},
+			},
+			shouldError: true,
+			errorStatus: 500,
+		},
+		{
+			name: "when the specification file cannot be read for lack of permission",
+			config: &config.SpecUI{
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.FS{FS: &failingFS{err: fs.ErrPermission}, Path: "petstore.yaml"},
 			},
 			shouldError: true,
 			errorStatus: 500,
 		},
+		{
+			name: "when reading the specification file fails",
+			config: &config.SpecUI{
+				SpecPath:   "/docs/openapi.yaml",
+				SpecSource: source.FS{FS: &failingFS{err: syscall.EIO}, Path: "petstore.yaml"},
+			},
+			shouldError: true,
+			errorStatus: 503,
+		},
 		{
 			name: "when config not set",
 			config: &config.SpecUI{
@@ -175,3 +193,221 @@ func (m *mockGenerator) MarshalYAML() ([]byte, error) {
 	}
 	return testdata.FS.ReadFile("petstore.yaml")
 }
+
+// failingFS fails to open its files with err for the given number of calls,
+// or always when failures is zero, and serves testdata.FS afterwards.
+type failingFS struct {
+	err      error
+	failures int
+	opens    int
+}
+
+func (f *failingFS) Open(name string) (fs.File, error) {
+	f.opens++
+	if f.failures == 0 || f.opens <= f.failures {
+		return nil, &fs.PathError{Op: "open", Path: "/srv/specs/" + name, Err: f.err}
+	}
+	return testdata.FS.Open(name)
+}
+
+func TestHandlerLoad(t *testing.T) {
+	serve := func(h *spec.Handler) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil))
+		return rec
+	}
+	newHandler := func(fsys fs.FS) *spec.Handler {
+		return spec.NewHandler(&config.SpecUI{
+			SpecPath:   "/docs/openapi.yaml",
+			SpecSource: source.FS{FS: fsys, Path: "petstore.yaml"},
+			Logger:     &nopLogger{},
+		})
+	}
+
+	t.Run("when reading fails once", func(t *testing.T) {
+		fsys := &failingFS{err: syscall.EIO, failures: 1}
+		h := newHandler(fsys)
+
+		rec := serve(h)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "5", rec.Header().Get("Retry-After"))
+		assert.Contains(t, rec.Body.String(), "OpenAPI specification is temporarily unavailable")
+		assert.NotContains(t, rec.Body.String(), "/srv/specs")
+
+		assert.Equal(t, http.StatusOK, serve(h).Code, "the load is retried")
+		assert.Equal(t, http.StatusOK, serve(h).Code)
+		assert.Equal(t, 2, fsys.opens, "a successful load is cached")
+	})
+	t.Run("when the file cannot be read for lack of permission", func(t *testing.T) {
+		fsys := &failingFS{err: fs.ErrPermission}
+		h := newHandler(fsys)
+
+		_, err := h.Load()
+		require.ErrorIs(t, err, fs.ErrPermission)
+		var pathErr *fs.PathError
+		require.True(t, errors.As(err, &pathErr), "the underlying error is returned")
+
+		rec := serve(h)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "failed to read OpenAPI specification file")
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+		assert.Equal(t, 1, fsys.opens, "the error is cached")
+	})
+	t.Run("when the file does not exist", func(t *testing.T) {
+		fsys := &failingFS{err: fs.ErrNotExist}
+		h := newHandler(fsys)
+
+		assert.Equal(t, http.StatusNotFound, serve(h).Code)
+		assert.Equal(t, http.StatusNotFound, serve(h).Code)
+		assert.Equal(t, 1, fsys.opens, "the error is cached")
+	})
+	t.Run("when validating after a failed read", func(t *testing.T) {
+		fsys := &failingFS{err: syscall.EIO, failures: 1}
+		h := newHandler(fsys)
+
+		_, err := h.Validate()
+		require.ErrorIs(t, err, syscall.EIO)
+		problems, err := h.Validate()
+		require.NoError(t, err, "validation is not cached with a retryable load error")
+		assert.Empty(t, problems)
+	})
+}
+
+// slowSource is a refreshing source whose loads after the first wait for
+// release, and which records whether their context was done.
+type slowSource struct {
+	loads    int32
+	started  chan struct{}
+	release  chan struct{}
+	canceled int32
+}
+
+func newSlowSource() *slowSource {
+	return &slowSource{started: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (s *slowSource) Load(ctx context.Context) ([]byte, string, string, error) {
+	if n := atomic.AddInt32(&s.loads, 1); n > 1 {
+		s.started <- struct{}{}
+		<-s.release
+	}
+	if ctx.Err() != nil {
+		atomic.StoreInt32(&s.canceled, 1)
+	}
+	return []byte(fmt.Sprintf("openapi: 3.0.3\ninfo: {title: Pets, version: \"%d\"}\npaths: {}\n", atomic.LoadInt32(&s.loads))), source.FormatYAML, "3.0.3", nil
+}
+
+func (s *slowSource) RefreshInterval() time.Duration { return time.Millisecond }
+
+func TestHandlerRefresh(t *testing.T) {
+	newHandler := func(src config.SpecSource) *spec.Handler {
+		return spec.NewHandler(&config.SpecUI{SpecPath: "/docs/openapi.yaml", SpecSource: src, Logger: &nopLogger{}})
+	}
+
+	t.Run("when a refresh is slow", func(t *testing.T) {
+		src := newSlowSource()
+		h := newHandler(src)
+		first, err := h.Load()
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+
+		b, err := h.Load()
+		require.NoError(t, err)
+		assert.Equal(t, first, b, "the stale specification is served during the refresh")
+		<-src.started
+		b, err = h.Load()
+		require.NoError(t, err)
+		assert.Equal(t, first, b, "requests do not wait for the refresh")
+
+		close(src.release)
+		assert.Eventually(t, func() bool {
+			b, _ := h.Load()
+			return string(b) != string(first)
+		}, time.Second, time.Millisecond)
+	})
+	t.Run("when the request waiting for the first load is canceled", func(t *testing.T) {
+		src := newSlowSource()
+		atomic.StoreInt32(&src.loads, 1)
+		h := newHandler(src)
+		loaded := make(chan error, 1)
+		go func() {
+			_, err := h.Load()
+			loaded <- err
+		}()
+		<-src.started
+
+		ctx, cancel := context.WithCancel(context.Background())
+		waited := make(chan error, 1)
+		go func() {
+			_, err := h.LoadContext(ctx)
+			waited <- err
+		}()
+		cancel()
+		assert.ErrorIs(t, <-waited, context.Canceled)
+
+		close(src.release)
+		require.NoError(t, <-loaded, "the load is not canceled with a waiting request")
+		assert.Zero(t, atomic.LoadInt32(&src.canceled))
+	})
+}
+
+// refreshingFS is a file source that refreshes hourly.
+type refreshingFS struct {
+	source.FS
+}
+
+func (refreshingFS) RefreshInterval() time.Duration { return time.Hour }
+
+func TestHandlerCaching(t *testing.T) {
+	serve := func(h *spec.Handler, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name         string
+		source       config.SpecSource
+		cacheControl string
+	}{
+		{
+			name:         "when the source does not refresh",
+			source:       source.FS{FS: &testdata.FS, Path: "petstore.yaml"},
+			cacheControl: "public, max-age=60, immutable",
+		},
+		{
+			name:         "when the source refreshes",
+			source:       refreshingFS{source.FS{FS: &testdata.FS, Path: "petstore.yaml"}},
+			cacheControl: "public, no-cache",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := spec.NewHandler(&config.SpecUI{SpecPath: "/docs/openapi.yaml", SpecSource: tt.source, CacheAge: 60, Logger: &nopLogger{}})
+
+			rec := serve(h, "")
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.cacheControl, rec.Header().Get("Cache-Control"))
+			etag := rec.Header().Get("ETag")
+			require.NotEmpty(t, etag)
+
+			rec = serve(h, etag)
+			assert.Equal(t, http.StatusNotModified, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			assert.Empty(t, rec.Body.String())
+
+			assert.Equal(t, http.StatusOK, serve(h, `"other"`).Code)
+		})
+	}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
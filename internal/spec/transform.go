@@ -0,0 +1,157 @@
+package spec
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/convert"
+	"github.com/oaswrap/spec-ui/redact"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/transform"
+	"github.com/oaswrap/spec-ui/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// maxVariants bounds the number of transformed specifications a pipeline
+// keeps, since variant keys may be derived from request headers.
+const maxVariants = 64
+
+// Pipeline applies the transformers of a configuration in order and caches
+// the results per input and variant.
+type Pipeline struct {
+	transformers []transform.Transformer
+
+	mu    sync.Mutex
+	cache map[pipelineKey]*pipelineResult
+	keys  []pipelineKey // Cached keys, oldest first
+}
+
+type pipelineKey struct {
+	input   [sha256.Size]byte
+	variant string
+}
+
+type pipelineResult struct {
+	once     sync.Once
+	b        []byte
+	warnings validate.Errors
+	err      error
+}
+
+// NewPipeline returns the pipeline configured in cfg: the conversion of
+// Swagger 2.0, the downgrade of OpenAPI 3.1 and the redaction when they are
+// enabled, then the transformers added with specui.WithSpecTransform.
+func NewPipeline(cfg *config.SpecUI) *Pipeline {
+	var transformers []transform.Transformer
+	if cfg.ConvertSwagger {
+		transformers = append(transformers, convert.TransformSwagger2)
+	}
+	if downgrades(cfg) {
+		transformers = append(transformers, convert.TransformOpenAPI31)
+	}
+	if cfg.Redaction != nil {
+		transformers = append(transformers, redact.Transformer(*cfg.Redaction))
+	}
+	transformers = append(transformers, cfg.SpecTransforms...)
+	return &Pipeline{transformers: transformers, cache: make(map[pipelineKey]*pipelineResult)}
+}
+
+// Apply returns b transformed for variant, in the format of b, along with the
+// warnings of the transformers. b is returned as is when no transformer
+// changes it.
+func (p *Pipeline) Apply(b []byte, variant string) ([]byte, validate.Errors, error) {
+	if len(p.transformers) == 0 {
+		return b, nil, nil
+	}
+
+	key := pipelineKey{input: sha256.Sum256(b), variant: variant}
+	p.mu.Lock()
+	result, ok := p.cache[key]
+	if !ok {
+		result = &pipelineResult{}
+		p.cache[key] = result
+		p.keys = append(p.keys, key)
+		if len(p.keys) > maxVariants {
+			delete(p.cache, p.keys[0])
+			p.keys = p.keys[1:]
+		}
+	}
+	p.mu.Unlock()
+
+	result.once.Do(func() {
+		result.b, result.warnings, result.err = p.apply(b, variant)
+	})
+	return result.b, result.warnings, result.err
+}
+
+func (p *Pipeline) apply(b []byte, variant string) ([]byte, validate.Errors, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		// Validation reports documents that cannot be transformed.
+		return b, nil, nil
+	}
+
+	var original yaml.Node
+	_ = yaml.Unmarshal(b, &original)
+
+	doc := &transform.Document{Root: node.Content[0]}
+	for _, t := range p.transformers {
+		if err := t(doc, variant); err != nil {
+			return nil, nil, fmt.Errorf("failed to transform OpenAPI specification: %w", err)
+		}
+	}
+	if equal(doc.Root, original.Content[0]) {
+		return b, doc.Warnings, nil
+	}
+
+	encode := encodeYAML
+	if format, _ := source.Detect(b); format == source.FormatJSON {
+		encode = encodeJSON
+	}
+	out, err := encode(doc.Root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode transformed OpenAPI specification: %w", err)
+	}
+	return out, doc.Warnings, nil
+}
+
+// Transform applies the pipeline configured in cfg to b outside a request.
+func Transform(cfg *config.SpecUI, b []byte) ([]byte, validate.Errors, error) {
+	return NewPipeline(cfg).Apply(b, "")
+}
+
+// equal reports whether two nodes hold the same values, ignoring styles,
+// comments and positions.
+func equal(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind || a.Tag != b.Tag || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.AliasNode {
+		return equal(a.Alias, b.Alias)
+	}
+	for i := range a.Content {
+		if !equal(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// downgrades reports whether OpenAPI 3.1 specifications are downgraded for
+// the provider of cfg.
+func downgrades(cfg *config.SpecUI) bool {
+	if !cfg.DowngradeOpenAPI31 {
+		return false
+	}
+	if len(cfg.DowngradeProviders) == 0 {
+		return true
+	}
+	for _, p := range cfg.DowngradeProviders {
+		if p == cfg.Provider {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package spec_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPipeline(t *testing.T) {
+	var calls int
+	title := func(doc *transform.Document, variant string) error {
+		calls++
+		return doc.Set("/info/title", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "Pets " + variant})
+	}
+	p := spec.NewPipeline(&config.SpecUI{SpecTransforms: []transform.Transformer{title}})
+
+	t.Run("when transforming JSON", func(t *testing.T) {
+		b, warnings, err := p.Apply([]byte(`{"openapi":"3.0.3","info":{"title":"Pets","version":"1"}}`), "eu")
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		assert.JSONEq(t, `{"openapi":"3.0.3","info":{"title":"Pets eu","version":"1"}}`, string(b))
+	})
+	t.Run("when transforming YAML", func(t *testing.T) {
+		b, _, err := p.Apply([]byte("openapi: 3.0.3\ninfo:\n  title: Pets\n  version: \"1\"\n"), "us")
+		require.NoError(t, err)
+		assert.Equal(t, "openapi: 3.0.3\ninfo:\n  title: Pets us\n  version: \"1\"\n", string(b))
+	})
+	t.Run("when the document cannot be parsed", func(t *testing.T) {
+		b, _, err := p.Apply([]byte("- not a document"), "")
+		require.NoError(t, err, "invalid documents are reported by validation")
+		assert.Equal(t, "- not a document", string(b))
+	})
+	t.Run("when evicting old variants", func(t *testing.T) {
+		in := []byte(`{"info":{}}`)
+		calls = 0
+		for i := 0; i < 65; i++ {
+			_, _, err := p.Apply(in, fmt.Sprint(i))
+			require.NoError(t, err)
+		}
+		_, _, _ = p.Apply(in, "64")
+		assert.Equal(t, 65, calls, "recent variants are cached")
+		_, _, _ = p.Apply(in, "0")
+		assert.Equal(t, 66, calls, "the oldest variant is evicted")
+	})
+}
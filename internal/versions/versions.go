@@ -0,0 +1,74 @@
+// Package versions renders the selector of the versions of the specification
+// on the docs pages of the providers.
+package versions
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/oaswrap/spec-ui/config"
+)
+
+// The selector lists the versions fetched from the versions path when the
+// page loads, so that the pages, which are rendered once, offer the versions
+// published since.
+var selectorTpl = template.Must(template.New("versions").Parse(`
+<nav class="specui-versions" style="position: fixed; top: 8px; right: 8px; z-index: 10000; font: 14px system-ui, sans-serif">
+	<select aria-label="API version" onchange="window.location.href = this.value">
+		<option value="{{ .DocsPath }}"{{ if not .Current }} selected{{ end }}>latest</option>
+{{- with .Current }}
+		<option value="{{ $.Path }}/{{ . }}" selected>{{ . }}</option>
+{{- end }}
+	</select>
+</nav>
+<script>
+	(function () {
+		var select = document.querySelector(".specui-versions select");
+		var path = {{ .Path }};
+		var current = {{ .Current }};
+		fetch(path, { headers: { Accept: "application/json" } })
+			.then(function (res) { return res.ok ? res.json() : Promise.reject(res.status); })
+			.then(function (list) {
+				select.options[0].text = list.latest ? "latest (" + list.latest + ")" : "latest";
+				select.options.length = 1;
+				list.versions.forEach(function (version) {
+					var option = new Option(version, path + "/" + encodeURIComponent(version));
+					option.selected = version === current;
+					select.add(option);
+				});
+			})
+			.catch(function () {});
+	})();
+</script>`))
+
+// FuncMap returns the template functions used by provider index templates to
+// render the version selector. The selector is empty when no store of
+// versions is configured.
+func FuncMap(cfg *config.SpecUI) template.FuncMap {
+	return template.FuncMap{
+		"versions": func() (template.HTML, error) {
+			return Selector(cfg)
+		},
+	}
+}
+
+// Selector returns the version selector of the docs page configured in cfg,
+// or "" when no store of versions is configured.
+func Selector(cfg *config.SpecUI) (template.HTML, error) {
+	if cfg.Versions.Store == nil {
+		return "", nil
+	}
+	data := struct {
+		DocsPath string // Docs page of the latest version
+		Path     string
+		Current  string
+	}{cfg.DocsPath, cfg.Versions.Path, cfg.Versions.Current}
+	if cfg.Versions.Current != "" {
+		data.DocsPath = cfg.Versions.LatestDocsPath
+	}
+	var buf bytes.Buffer
+	if err := selectorTpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil //nolint:gosec // The selector is rendered by html/template.
+}
@@ -0,0 +1,57 @@
+package versions_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/versions"
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions config.Versions
+		contains []string
+	}{
+		{
+			name:     "when the page shows the latest version",
+			versions: config.Versions{Path: "/docs/v", Store: &store.Memory{}},
+			contains: []string{
+				`<option value="/docs" selected>latest</option>`,
+				`var path = "/docs/v";`,
+				`var current = "";`,
+			},
+		},
+		{
+			name:     "when the page shows a version",
+			versions: config.Versions{Path: "/docs/v", Store: &store.Memory{}, Current: "1.0.0", LatestDocsPath: "/docs"},
+			contains: []string{
+				`<option value="/docs">latest</option>`,
+				`<option value="/docs/v/1.0.0" selected>1.0.0</option>`,
+				`var current = "1.0.0";`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.SpecUI{DocsPath: "/docs", Versions: tt.versions}
+			if tt.versions.Current != "" {
+				cfg.DocsPath = "/docs/v/" + tt.versions.Current
+			}
+			html, err := versions.Selector(cfg)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, string(html), s)
+			}
+		})
+	}
+
+	t.Run("when no store is configured", func(t *testing.T) {
+		html, err := versions.Selector(&config.SpecUI{DocsPath: "/docs"})
+		require.NoError(t, err)
+		assert.Empty(t, html)
+	})
+}
@@ -0,0 +1,156 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// methods are the operation keys of a path item.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Document is a parsed OpenAPI document.
+type Document struct {
+	Root *yaml.Node // Root mapping node
+}
+
+// Parse parses a JSON or YAML document.
+func Parse(b []byte) (*Document, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, errEmpty
+	}
+	root := resolve(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: expected an object at line %d", root.Line)
+	}
+	return &Document{Root: root}, nil
+}
+
+// Swagger reports whether the document is a Swagger 2.0 document.
+func (d *Document) Swagger() bool {
+	return node(d.Root, "swagger") != nil
+}
+
+// Get returns the value at the JSON pointer ptr, or nil when there is none.
+func (d *Document) Get(ptr string) *yaml.Node {
+	n := d.Root
+	if ptr == "" {
+		return n
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(ptr, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch n.Kind {
+		case yaml.MappingNode:
+			n = node(n, token)
+		case yaml.SequenceNode:
+			var i int
+			if _, err := fmt.Sscan(token, &i); err != nil || i < 0 || i >= len(n.Content) {
+				return nil
+			}
+			n = resolve(n.Content[i])
+		default:
+			return nil
+		}
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// Finding returns a finding located at n, the value at the JSON pointer ptr.
+func (d *Document) Finding(n *yaml.Node, ptr, format string, args ...interface{}) Finding {
+	return Finding{Pointer: ptr, Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+// Operation is an operation of a path item.
+type Operation struct {
+	Path    string     // Path template, e.g. "/pets/{id}"
+	Method  string     // Lower-case method, e.g. "get"
+	Pointer string     // JSON pointer to the operation
+	Node    *yaml.Node // Operation object
+}
+
+// Operations returns the operations of the document in document order.
+func (d *Document) Operations() []Operation {
+	var ops []Operation
+	paths := node(d.Root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i].Value, resolve(paths.Content[i+1])
+		if !strings.HasPrefix(path, "/") || item.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			method := item.Content[j].Value
+			op := resolve(item.Content[j+1])
+			if !isMethod(method) || op.Kind != yaml.MappingNode {
+				continue
+			}
+			ops = append(ops, Operation{
+				Path:    path,
+				Method:  method,
+				Pointer: Pointer("paths", path, method),
+				Node:    op,
+			})
+		}
+	}
+	return ops
+}
+
+// Pointer returns the JSON pointer made of tokens.
+func Pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return b.String()
+}
+
+func isMethod(key string) bool {
+	for _, m := range methods {
+		if m == key {
+			return true
+		}
+	}
+	return false
+}
+
+// reference is a "$ref" value and the JSON pointer of the object holding it.
+type reference struct {
+	from string
+	ref  string
+}
+
+// references returns the "$ref" values of the document.
+func (d *Document) references() []reference {
+	var refs []reference
+	var walk func(n *yaml.Node, ptr string)
+	walk = func(n *yaml.Node, ptr string) {
+		switch n.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key, value := n.Content[i].Value, n.Content[i+1]
+				if key == "$ref" && value.Kind == yaml.ScalarNode {
+					refs = append(refs, reference{from: ptr, ref: value.Value})
+					continue
+				}
+				walk(value, ptr+Pointer(key))
+			}
+		case yaml.SequenceNode:
+			for i, item := range n.Content {
+				walk(item, ptr+Pointer(fmt.Sprint(i)))
+			}
+		}
+	}
+	walk(d.Root, "")
+	return refs
+}
@@ -0,0 +1,223 @@
+// Package lint checks OpenAPI documents against style rules, such as every
+// operation having an operationId and tags, or paths being kebab-case.
+//
+// Rules implement the Rule interface; DefaultRules returns the built-in ones.
+// Lint runs rules on a document and returns a Report that can be written as
+// text, JSON or SARIF:
+//
+//	report, err := lint.Lint(b, lint.Config{
+//		Severity: map[string]lint.Severity{"operation-description": lint.SeverityOff},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	return report.Err()
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the importance of a finding.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"     // Disables a rule
+	SeverityInfo    Severity = "info"    // Suggestion
+	SeverityWarning Severity = "warning" // Style problem that should be fixed
+	SeverityError   Severity = "error"   // Problem that makes Report.Err fail
+)
+
+var severityRanks = map[Severity]int{SeverityInfo: 1, SeverityWarning: 2, SeverityError: 3}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(name string) (Severity, error) {
+	s := Severity(strings.ToLower(name))
+	if _, ok := severityRanks[s]; !ok && s != SeverityOff {
+		return "", fmt.Errorf("unknown severity %q: use off, info, warning or error", name)
+	}
+	return s, nil
+}
+
+// AtLeast reports whether s is as important as min.
+func (s Severity) AtLeast(min Severity) bool {
+	return severityRanks[s] >= severityRanks[min] && severityRanks[s] > 0
+}
+
+// Rule checks a document for one kind of problem.
+type Rule interface {
+	Name() string        // Unique name, e.g. "operation-tags"
+	Description() string // One-line description of what the rule checks
+	Severity() Severity  // Default severity of the findings
+	// Check returns the findings of the rule in doc. Their Rule and Severity
+	// fields are set by Lint.
+	Check(doc *Document) []Finding
+}
+
+// NewRule returns a Rule that runs check.
+func NewRule(name, description string, severity Severity, check func(doc *Document) []Finding) Rule {
+	return &rule{name: name, description: description, severity: severity, check: check}
+}
+
+type rule struct {
+	name        string
+	description string
+	severity    Severity
+	check       func(doc *Document) []Finding
+}
+
+func (r *rule) Name() string                  { return r.name }
+func (r *rule) Description() string           { return r.description }
+func (r *rule) Severity() Severity            { return r.severity }
+func (r *rule) Check(doc *Document) []Finding { return r.check(doc) }
+
+// Finding is a problem reported by a rule.
+type Finding struct {
+	Rule     string   `json:"rule"`     // Name of the rule
+	Severity Severity `json:"severity"` // Severity of the rule
+	Pointer  string   `json:"pointer"`  // JSON pointer to the value, e.g. "/paths/~1pets/get"
+	Line     int      `json:"line"`     // Line of the value in the document, starting at 1
+	Column   int      `json:"column"`   // Column of the value in the document, starting at 1
+	Message  string   `json:"message"`  // Description of the problem
+}
+
+func (f Finding) String() string {
+	ptr := f.Pointer
+	if ptr == "" {
+		ptr = "/"
+	}
+	return fmt.Sprintf("%d:%d: %s: %s: %s (%s)", f.Line, f.Column, f.Severity, ptr, f.Message, f.Rule)
+}
+
+// Config selects the rules run by Lint.
+type Config struct {
+	File     string              // Name of the linted file, used in reports
+	Rules    []Rule              // Rules to run, defaults to DefaultRules
+	Severity map[string]Severity // Severity overrides by rule name; SeverityOff disables a rule
+}
+
+// Report is the outcome of linting a document.
+type Report struct {
+	File     string    `json:"file,omitempty"` // Name of the linted file
+	Rules    []Rule    `json:"-"`              // Rules that were run
+	Findings []Finding `json:"findings"`       // Findings ordered by position
+}
+
+// Count returns the number of findings with a severity of at least min.
+func (r *Report) Count(min Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity.AtLeast(min) {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns an error listing the findings with SeverityError, or nil when
+// there are none.
+func (r *Report) Err() error {
+	var msgs []string
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			msgs = append(msgs, f.String())
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	prefix := "lint"
+	if r.File != "" {
+		prefix = r.File
+	}
+	return fmt.Errorf("%s: %d %s:\n%s", prefix, len(msgs), plural(len(msgs), "error", "errors"), strings.Join(msgs, "\n"))
+}
+
+// Lint parses a JSON or YAML document and runs the configured rules on it.
+func Lint(b []byte, cfg Config) (*Report, error) {
+	doc, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	return LintDocument(doc, cfg)
+}
+
+// LintDocument runs the configured rules on a parsed document.
+func LintDocument(doc *Document, cfg Config) (*Report, error) {
+	rules := cfg.Rules
+	if rules == nil {
+		rules = DefaultRules()
+	}
+
+	report := &Report{File: cfg.File, Findings: []Finding{}}
+	seen := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		if seen[r.Name()] {
+			return nil, fmt.Errorf("duplicate lint rule %q", r.Name())
+		}
+		seen[r.Name()] = true
+	}
+	for name := range cfg.Severity {
+		if !seen[name] {
+			return nil, fmt.Errorf("unknown lint rule %q", name)
+		}
+	}
+
+	for _, r := range rules {
+		severity := r.Severity()
+		if s, ok := cfg.Severity[r.Name()]; ok {
+			severity = s
+		}
+		if severity == SeverityOff {
+			continue
+		}
+		report.Rules = append(report.Rules, r)
+		for _, f := range r.Check(doc) {
+			f.Rule, f.Severity = r.Name(), severity
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return report, nil
+}
+
+var errEmpty = errors.New("failed to parse OpenAPI specification: document is empty")
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// node returns the value of key in the mapping node n, or nil.
+func node(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return resolve(n.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func resolve(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
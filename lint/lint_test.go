@@ -0,0 +1,198 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/lint"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const styled = `openapi: 3.0.3
+info:
+  title: Pets
+  version: "1.0"
+  description: Pet store.
+paths:
+  /pet-owners/{ownerId}:
+    get:
+      operationId: getPetOwner
+      tags: [owners]
+      description: Returns an owner.
+      security: [{key: []}]
+      responses:
+        200:
+          $ref: "#/components/responses/Owner"
+components:
+  responses:
+    Owner:
+      description: OK
+      content:
+        application/json:
+          schema: {$ref: "#/components/schemas/Owner"}
+  schemas:
+    Owner:
+      type: object
+      properties:
+        friends: {type: array, items: {$ref: "#/components/schemas/Owner"}}
+  securitySchemes:
+    key: {type: apiKey, name: X-Key, in: header}
+`
+
+func TestDefaultRules(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []lint.Finding
+	}{
+		{name: "when the document follows the rules", doc: styled},
+		{
+			name: "when operations are incomplete",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\", description: Pets.}\npaths:\n  /pets:\n    get:\n      operationId: list_pets\n      responses: {}\n    post:\n      tags: []\n      description: Adds a pet.\n      responses: {}\n",
+			want: []lint.Finding{
+				{Rule: "operation-tags", Severity: lint.SeverityWarning, Pointer: "/paths/~1pets/get", Line: 6, Column: 7, Message: "GET /pets has no tags"},
+				{Rule: "operation-description", Severity: lint.SeverityWarning, Pointer: "/paths/~1pets/get", Line: 6, Column: 7, Message: "GET /pets has no description"},
+				{Rule: "operation-id-camel-case", Severity: lint.SeverityWarning, Pointer: "/paths/~1pets/get/operationId", Line: 6, Column: 20, Message: `operationId "list_pets" is not camelCase`},
+				{Rule: "operation-id", Severity: lint.SeverityWarning, Pointer: "/paths/~1pets/post", Line: 9, Column: 7, Message: "POST /pets has no operationId"},
+				{Rule: "operation-tags", Severity: lint.SeverityWarning, Pointer: "/paths/~1pets/post", Line: 9, Column: 7, Message: "POST /pets has no tags"},
+			},
+		},
+		{
+			name: "when the info has no description",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths: {}\n",
+			want: []lint.Finding{
+				{Rule: "info-description", Severity: lint.SeverityWarning, Pointer: "/info", Line: 2, Column: 7, Message: "info has no description"},
+			},
+		},
+		{
+			name: "when paths are not kebab-case",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\", description: Pets.}\npaths:\n  /petOwners/{id}/pet_names: {}\n",
+			want: []lint.Finding{
+				{Rule: "path-kebab-case", Severity: lint.SeverityWarning, Pointer: "/paths/~1petOwners~1{id}~1pet_names", Line: 4, Column: 3, Message: `path segment "petOwners" is not kebab-case`},
+				{Rule: "path-kebab-case", Severity: lint.SeverityWarning, Pointer: "/paths/~1petOwners~1{id}~1pet_names", Line: 4, Column: 3, Message: `path segment "pet_names" is not kebab-case`},
+			},
+		},
+		{
+			name: "when components are unused",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\", description: Pets.}\npaths: {}\ncomponents:\n  schemas:\n    Tree:\n      properties:\n        children: {$ref: \"#/components/schemas/Tree\"}\n  securitySchemes:\n    key: {type: http, scheme: basic}\n",
+			want: []lint.Finding{
+				{Rule: "no-unused-components", Severity: lint.SeverityWarning, Pointer: "/components/schemas/Tree", Line: 6, Column: 5, Message: `components/schemas "Tree" is not used`},
+				{Rule: "no-unused-components", Severity: lint.SeverityWarning, Pointer: "/components/securitySchemes/key", Line: 10, Column: 5, Message: `security scheme "key" is not used`},
+			},
+		},
+		{
+			name: "when Swagger 2.0 definitions are unused",
+			doc:  "swagger: \"2.0\"\ninfo: {title: Pets, version: \"1\", description: Pets.}\npaths: {}\ndefinitions:\n  Pet: {type: object}\n",
+			want: []lint.Finding{
+				{Rule: "no-unused-components", Severity: lint.SeverityWarning, Pointer: "/definitions/Pet", Line: 5, Column: 3, Message: `definitions "Pet" is not used`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := lint.Lint([]byte(tt.doc), lint.Config{})
+			require.NoError(t, err)
+			if tt.want == nil {
+				tt.want = []lint.Finding{}
+			}
+			assert.Equal(t, tt.want, report.Findings)
+		})
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	doc := []byte("openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /Pets:\n    get: {responses: {}}\n")
+
+	t.Run("when overriding severities", func(t *testing.T) {
+		report, err := lint.Lint(doc, lint.Config{
+			File: "openapi.yaml",
+			Severity: map[string]lint.Severity{
+				"operation-id":          lint.SeverityError,
+				"operation-tags":        lint.SeverityOff,
+				"operation-description": lint.SeverityOff,
+				"info-description":      lint.SeverityInfo,
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "openapi.yaml", report.File)
+		assert.Len(t, report.Rules, 5)
+		assert.Equal(t, 3, report.Count(lint.SeverityInfo))
+		assert.Equal(t, 2, report.Count(lint.SeverityWarning))
+		assert.Equal(t, 1, report.Count(lint.SeverityError))
+		assert.EqualError(t, report.Err(), "openapi.yaml: 1 error:\n5:10: error: /paths/~1Pets/get: GET /Pets has no operationId (operation-id)")
+	})
+	t.Run("when using custom rules", func(t *testing.T) {
+		rule := lint.NewRule("summary", "Operations must have a summary.", lint.SeverityError, func(doc *lint.Document) []lint.Finding {
+			var findings []lint.Finding
+			for _, op := range doc.Operations() {
+				if doc.Get(op.Pointer+"/summary") == nil {
+					findings = append(findings, doc.Finding(op.Node, op.Pointer, "no summary"))
+				}
+			}
+			return findings
+		})
+
+		report, err := lint.Lint(doc, lint.Config{Rules: []lint.Rule{rule}})
+		require.NoError(t, err)
+		assert.Equal(t, []lint.Finding{
+			{Rule: "summary", Severity: lint.SeverityError, Pointer: "/paths/~1Pets/get", Line: 5, Column: 10, Message: "no summary"},
+		}, report.Findings)
+		assert.Error(t, report.Err())
+	})
+	t.Run("when there are no errors", func(t *testing.T) {
+		report, err := lint.Lint(doc, lint.Config{})
+		require.NoError(t, err)
+		assert.NoError(t, report.Err())
+	})
+	t.Run("when a rule is unknown", func(t *testing.T) {
+		_, err := lint.Lint(doc, lint.Config{Severity: map[string]lint.Severity{"missing": lint.SeverityOff}})
+		assert.EqualError(t, err, `unknown lint rule "missing"`)
+	})
+	t.Run("when rules have the same name", func(t *testing.T) {
+		rules := append(lint.DefaultRules(), lint.DefaultRules()[0])
+		_, err := lint.Lint(doc, lint.Config{Rules: rules})
+		assert.EqualError(t, err, `duplicate lint rule "operation-id"`)
+	})
+	t.Run("when the document cannot be parsed", func(t *testing.T) {
+		for _, doc := range []string{"openapi: [3.0", "", "[]"} {
+			_, err := lint.Lint([]byte(doc), lint.Config{})
+			assert.Error(t, err, doc)
+		}
+	})
+}
+
+func TestLintTestdata(t *testing.T) {
+	b, err := testdata.FS.ReadFile("petstore.yaml")
+	require.NoError(t, err)
+
+	report, err := lint.Lint(b, lint.Config{})
+	require.NoError(t, err)
+	assert.NoError(t, report.Err(), "built-in rules only warn")
+}
+
+func TestDocumentGet(t *testing.T) {
+	doc, err := lint.Parse([]byte(styled))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Pets", doc.Get("/info/title").Value)
+	assert.Equal(t, "getPetOwner", doc.Get("/paths/~1pet-owners~1{ownerId}/get/operationId").Value)
+	assert.Equal(t, "owners", doc.Get("/paths/~1pet-owners~1{ownerId}/get/tags/0").Value)
+	assert.Nil(t, doc.Get("/paths/~1pets"))
+	assert.Nil(t, doc.Get("/info/title/x"))
+	assert.Nil(t, doc.Get("/paths/~1pet-owners~1{ownerId}/get/tags/1"))
+	assert.Equal(t, doc.Root, doc.Get(""))
+	assert.Equal(t, "/paths/~1a~0b/get", lint.Pointer("paths", "/a~b", "get"))
+}
+
+func TestParseSeverity(t *testing.T) {
+	s, err := lint.ParseSeverity("Warning")
+	require.NoError(t, err)
+	assert.Equal(t, lint.SeverityWarning, s)
+	_, err = lint.ParseSeverity("fatal")
+	assert.Error(t, err)
+
+	assert.True(t, lint.SeverityError.AtLeast(lint.SeverityWarning))
+	assert.False(t, lint.SeverityInfo.AtLeast(lint.SeverityWarning))
+	assert.False(t, lint.SeverityOff.AtLeast(lint.SeverityOff))
+}
@@ -0,0 +1,178 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format is an output format of reports.
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+)
+
+// Write writes reports to w in format.
+func Write(w io.Writer, format Format, reports ...*Report) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, reports...)
+	case FormatJSON:
+		return WriteJSON(w, reports...)
+	case FormatSARIF:
+		return WriteSARIF(w, reports...)
+	default:
+		return fmt.Errorf("unknown lint output format %q: use text, json or sarif", format)
+	}
+}
+
+// WriteText writes one line per finding, prefixed with the file name:
+//
+//	openapi.yaml:12:5: warning: /paths/~1pets/get: GET /pets has no tags (operation-tags)
+func WriteText(w io.Writer, reports ...*Report) error {
+	for _, r := range reports {
+		for _, f := range r.Findings {
+			prefix := ""
+			if r.File != "" {
+				prefix = r.File + ":"
+			}
+			if _, err := fmt.Fprintf(w, "%s%s\n", prefix, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes reports as a JSON array of {"file", "findings"} objects.
+func WriteJSON(w io.Writer, reports ...*Report) error {
+	if reports == nil {
+		reports = []*Report{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
+
+// sarifLevels maps severities to SARIF result levels.
+var sarifLevels = map[Severity]string{SeverityInfo: "note", SeverityWarning: "warning", SeverityError: "error"}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// WriteSARIF writes reports as a SARIF 2.1.0 log with a single run, as read
+// by code scanning tools.
+func WriteSARIF(w io.Writer, reports ...*Report) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "spec-ui",
+			InformationURI: "https://github.com/oaswrap/spec-ui",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	seen := make(map[string]bool)
+	for _, r := range reports {
+		for _, rule := range r.Rules {
+			if seen[rule.Name()] {
+				continue
+			}
+			seen[rule.Name()] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:                   rule.Name(),
+				ShortDescription:     sarifMessage{Text: rule.Description()},
+				DefaultConfiguration: sarifConfiguration{Level: sarifLevels[rule.Severity()]},
+			})
+		}
+
+		for _, f := range r.Findings {
+			loc := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: r.File},
+					Region:           sarifRegion{StartLine: f.Line, StartColumn: f.Column},
+				},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: f.Pointer}},
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    f.Rule,
+				Level:     sarifLevels[f.Severity],
+				Message:   sarifMessage{Text: f.Message},
+				Locations: []sarifLocation{loc},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
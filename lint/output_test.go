@@ -0,0 +1,105 @@
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReports(t *testing.T) []*lint.Report {
+	t.Helper()
+	pets, err := lint.Lint([]byte("openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths: {}\n"), lint.Config{
+		File:     "pets.yaml",
+		Severity: map[string]lint.Severity{"info-description": lint.SeverityError},
+	})
+	require.NoError(t, err)
+	users, err := lint.Lint([]byte(styled), lint.Config{File: "users.yaml"})
+	require.NoError(t, err)
+	return []*lint.Report{pets, users}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, lint.Write(&buf, lint.FormatText, newReports(t)...))
+	assert.Equal(t, "pets.yaml:2:7: error: /info: info has no description (info-description)\n", buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, lint.Write(&buf, lint.FormatJSON, newReports(t)...))
+	assert.JSONEq(t, `[
+		{"file": "pets.yaml", "findings": [
+			{"rule": "info-description", "severity": "error", "pointer": "/info", "line": 2, "column": 7, "message": "info has no description"}
+		]},
+		{"file": "users.yaml", "findings": []}
+	]`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, lint.WriteJSON(&buf))
+	assert.JSONEq(t, `[]`, buf.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, lint.Write(&buf, lint.FormatSARIF, newReports(t)...))
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID                   string `json:"id"`
+						DefaultConfiguration struct {
+							Level string `json:"level"`
+						} `json:"defaultConfiguration"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string                `json:"ruleId"`
+				Level     string                `json:"level"`
+				Message   struct{ Text string } `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct{ URI string } `json:"artifactLocation"`
+						Region           struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "spec-ui", run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, len(lint.DefaultRules()), "rules are listed once")
+	assert.Equal(t, "warning", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+
+	require.Len(t, run.Results, 1)
+	result := run.Results[0]
+	assert.Equal(t, "info-description", result.RuleID)
+	assert.Equal(t, "error", result.Level)
+	assert.Equal(t, "info has no description", result.Message.Text)
+	loc := result.Locations[0]
+	assert.Equal(t, "pets.yaml", loc.PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 2, loc.PhysicalLocation.Region.StartLine)
+	assert.Equal(t, 7, loc.PhysicalLocation.Region.StartColumn)
+	assert.Equal(t, "/info", loc.LogicalLocations[0].FullyQualifiedName)
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	assert.Error(t, lint.Write(&bytes.Buffer{}, "xml"))
+}
@@ -0,0 +1,198 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	camelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	kebabCase = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// DefaultRules returns the built-in rules:
+//
+//	operation-id            every operation has an operationId
+//	operation-id-camel-case operationIds are camelCase
+//	operation-tags          every operation has at least one tag
+//	operation-description   every operation has a description
+//	info-description        the API has a description
+//	no-unused-components    every component is referenced
+//	path-kebab-case         path segments are kebab-case
+func DefaultRules() []Rule {
+	return []Rule{
+		NewRule("operation-id", "Operations must have an operationId.", SeverityWarning, checkOperationID),
+		NewRule("operation-id-camel-case", "Operation IDs must be camelCase.", SeverityWarning, checkOperationIDCase),
+		NewRule("operation-tags", "Operations must have at least one tag.", SeverityWarning, checkOperationTags),
+		NewRule("operation-description", "Operations must have a description.", SeverityWarning, checkOperationDescription),
+		NewRule("info-description", "The API must have a description.", SeverityWarning, checkInfoDescription),
+		NewRule("no-unused-components", "Components must be referenced.", SeverityWarning, checkUnusedComponents),
+		NewRule("path-kebab-case", "Path segments must be kebab-case.", SeverityWarning, checkPathCase),
+	}
+}
+
+func checkOperationID(doc *Document) []Finding {
+	var findings []Finding
+	for _, op := range doc.Operations() {
+		if isEmpty(node(op.Node, "operationId")) {
+			findings = append(findings, doc.Finding(op.Node, op.Pointer, "%s %s has no operationId", strings.ToUpper(op.Method), op.Path))
+		}
+	}
+	return findings
+}
+
+func checkOperationIDCase(doc *Document) []Finding {
+	var findings []Finding
+	for _, op := range doc.Operations() {
+		id := node(op.Node, "operationId")
+		if isEmpty(id) || camelCase.MatchString(id.Value) {
+			continue
+		}
+		findings = append(findings, doc.Finding(id, op.Pointer+"/operationId", "operationId %q is not camelCase", id.Value))
+	}
+	return findings
+}
+
+func checkOperationTags(doc *Document) []Finding {
+	var findings []Finding
+	for _, op := range doc.Operations() {
+		tags := node(op.Node, "tags")
+		if tags == nil || tags.Kind != yaml.SequenceNode || len(tags.Content) == 0 {
+			findings = append(findings, doc.Finding(op.Node, op.Pointer, "%s %s has no tags", strings.ToUpper(op.Method), op.Path))
+		}
+	}
+	return findings
+}
+
+func checkOperationDescription(doc *Document) []Finding {
+	var findings []Finding
+	for _, op := range doc.Operations() {
+		if isEmpty(node(op.Node, "description")) {
+			findings = append(findings, doc.Finding(op.Node, op.Pointer, "%s %s has no description", strings.ToUpper(op.Method), op.Path))
+		}
+	}
+	return findings
+}
+
+func checkInfoDescription(doc *Document) []Finding {
+	info := node(doc.Root, "info")
+	if info == nil {
+		return []Finding{doc.Finding(doc.Root, "", "the document has no info object")}
+	}
+	if isEmpty(node(info, "description")) {
+		return []Finding{doc.Finding(info, "/info", "info has no description")}
+	}
+	return nil
+}
+
+// Component maps whose entries are referenced with "$ref".
+var (
+	openAPIComponents = []string{
+		"components/schemas", "components/responses", "components/parameters", "components/examples",
+		"components/requestBodies", "components/headers", "components/links", "components/callbacks",
+		"components/pathItems",
+	}
+	swaggerComponents = []string{"definitions", "parameters", "responses"}
+)
+
+func checkUnusedComponents(doc *Document) []Finding {
+	refs := doc.references()
+	used := func(ptr string) bool {
+		for _, r := range refs {
+			inside := r.from == ptr || strings.HasPrefix(r.from, ptr+"/")
+			if !inside && (r.ref == "#"+ptr || strings.HasPrefix(r.ref, "#"+ptr+"/")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	sections := openAPIComponents
+	if doc.Swagger() {
+		sections = swaggerComponents
+	}
+
+	var findings []Finding
+	for _, section := range sections {
+		sectionPtr := Pointer(strings.Split(section, "/")...)
+		m := doc.Get(sectionPtr)
+		if m == nil || m.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			name := m.Content[i]
+			ptr := sectionPtr + Pointer(name.Value)
+			if !used(ptr) {
+				findings = append(findings, doc.Finding(name, ptr, "%s %q is not used", section, name.Value))
+			}
+		}
+	}
+
+	schemes := doc.Get("/components/securitySchemes")
+	schemesPtr := "/components/securitySchemes"
+	if doc.Swagger() {
+		schemes, schemesPtr = node(doc.Root, "securityDefinitions"), "/securityDefinitions"
+	}
+	if schemes != nil && schemes.Kind == yaml.MappingNode {
+		names := securityNames(doc)
+		for i := 0; i+1 < len(schemes.Content); i += 2 {
+			name := schemes.Content[i]
+			if !names[name.Value] {
+				findings = append(findings, doc.Finding(name, schemesPtr+Pointer(name.Value), "security scheme %q is not used", name.Value))
+			}
+		}
+	}
+	return findings
+}
+
+// securityNames returns the security schemes named by security requirements.
+func securityNames(doc *Document) map[string]bool {
+	names := make(map[string]bool)
+	add := func(security *yaml.Node) {
+		if security == nil || security.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, req := range security.Content {
+			req = resolve(req)
+			if req.Kind != yaml.MappingNode {
+				continue
+			}
+			for i := 0; i < len(req.Content); i += 2 {
+				names[req.Content[i].Value] = true
+			}
+		}
+	}
+	add(node(doc.Root, "security"))
+	for _, op := range doc.Operations() {
+		add(node(op.Node, "security"))
+	}
+	return names
+}
+
+func checkPathCase(doc *Document) []Finding {
+	paths := node(doc.Root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return nil
+	}
+	var findings []Finding
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		key := paths.Content[i]
+		if !strings.HasPrefix(key.Value, "/") {
+			continue
+		}
+		for _, segment := range strings.Split(key.Value, "/") {
+			if segment == "" || strings.Contains(segment, "{") || kebabCase.MatchString(segment) {
+				continue
+			}
+			findings = append(findings, doc.Finding(key, Pointer("paths", key.Value), "path segment %q is not kebab-case", segment))
+		}
+	}
+	return findings
+}
+
+// isEmpty reports whether n is missing or an empty scalar.
+func isEmpty(n *yaml.Node) bool {
+	return n == nil || (n.Kind == yaml.ScalarNode && strings.TrimSpace(n.Value) == "")
+}
@@ -0,0 +1,93 @@
+// Package observe defines the observers that record the requests served by a
+// handler and the loads of its specification, see specui.WithObserver.
+//
+// Observers receive plain events, so metrics and tracing libraries are kept
+// out of the core module: ready-made observers for Prometheus and
+// OpenTelemetry live in the observe/prometheus and observe/otel modules.
+package observe
+
+import (
+	"context"
+	"time"
+)
+
+// RouteKind is the kind of route that served a request.
+type RouteKind string
+
+const (
+	Docs      RouteKind = "docs"      // Documentation page
+	Spec      RouteKind = "spec"      // OpenAPI specification
+	Assets    RouteKind = "assets"    // Embedded UI assets
+	Changelog RouteKind = "changelog" // Changelog page
+	Versions  RouteKind = "versions"  // List, documentation pages and specifications of the versions of a store
+)
+
+// Request is a request served by the handler.
+type Request struct {
+	Context  context.Context // Context of the request, e.g. to parent a span
+	Route    RouteKind       // Kind of the route that served the request
+	Provider string          // UI provider, e.g. "swaggerui"
+	Method   string          // HTTP method
+	Path     string          // URL path of the request
+	Status   int             // HTTP status code of the response
+	Bytes    int64           // Number of body bytes written
+	Start    time.Time       // Time the request was received
+	Duration time.Duration   // Time taken to serve the request
+}
+
+// Load is a load of the specification from its source, and its transform. It
+// is reported for every load attempt: the first one, the retries of loads that
+// failed with an error that may not happen again, and the refreshes of sources
+// implementing source.Refresher.
+type Load struct {
+	Provider string        // UI provider, e.g. "swaggerui"
+	Path     string        // URL path of the specification
+	Source   string        // "generator", or the file the specification is read from
+	Bytes    int           // Size of the specification served, zero on failure
+	Start    time.Time     // Time the load started
+	Duration time.Duration // Time taken to read or generate and transform the specification
+	Err      error         // Error of the load, nil on success
+}
+
+// Observer records the events of a handler. Its methods are called
+// synchronously on the serving goroutine, so they should not block.
+type Observer interface {
+	ObserveRequest(e Request)
+	ObserveLoad(e Load)
+}
+
+// Multi returns an observer passing each event to every non-nil observer of
+// obs, in order. It returns nil when there are none.
+func Multi(obs ...Observer) Observer {
+	var m multi
+	for _, o := range obs {
+		switch o := o.(type) {
+		case nil:
+		case multi:
+			m = append(m, o...)
+		default:
+			m = append(m, o)
+		}
+	}
+	switch len(m) {
+	case 0:
+		return nil
+	case 1:
+		return m[0]
+	}
+	return m
+}
+
+type multi []Observer
+
+func (m multi) ObserveRequest(e Request) {
+	for _, o := range m {
+		o.ObserveRequest(e)
+	}
+}
+
+func (m multi) ObserveLoad(e Load) {
+	for _, o := range m {
+		o.ObserveLoad(e)
+	}
+}
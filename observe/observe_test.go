@@ -0,0 +1,46 @@
+package observe_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/stretchr/testify/assert"
+)
+
+type countObserver struct {
+	name  string
+	calls *[]string
+}
+
+func (o countObserver) ObserveRequest(e observe.Request) {
+	*o.calls = append(*o.calls, o.name+" "+e.Path)
+}
+
+func (o countObserver) ObserveLoad(e observe.Load) {
+	*o.calls = append(*o.calls, o.name+" load "+e.Path)
+}
+
+func TestMulti(t *testing.T) {
+	t.Run("when there are no observers", func(t *testing.T) {
+		assert.Nil(t, observe.Multi())
+		assert.Nil(t, observe.Multi(nil, nil))
+	})
+	t.Run("when there is one observer", func(t *testing.T) {
+		o := countObserver{name: "a", calls: new([]string)}
+		assert.Equal(t, o, observe.Multi(nil, o))
+	})
+	t.Run("when there are several observers", func(t *testing.T) {
+		var calls []string
+		a := countObserver{name: "a", calls: &calls}
+		b := countObserver{name: "b", calls: &calls}
+		c := countObserver{name: "c", calls: &calls}
+
+		m := observe.Multi(observe.Multi(a, b), nil, c)
+		m.ObserveRequest(observe.Request{Path: "/docs"})
+		m.ObserveLoad(observe.Load{Path: "/docs/openapi.json"})
+		assert.Equal(t, []string{
+			"a /docs", "b /docs", "c /docs",
+			"a load /docs/openapi.json", "b load /docs/openapi.json", "c load /docs/openapi.json",
+		}, calls)
+	})
+}
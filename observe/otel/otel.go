@@ -0,0 +1,86 @@
+// Package otel records the requests served by a spec-ui handler and the loads
+// of its specification as OpenTelemetry spans:
+//
+//	h := specui.NewHandler(
+//		specui.WithSpecFile("openapi.yaml"),
+//		specui.WithObserver(otelobserver.New(nil)),
+//		swaggerui.WithUI(),
+//	)
+//
+// Request spans are children of the span in the request context, e.g. the
+// server span of otelhttp, and are named after the route: "specui docs",
+// "specui spec", "specui assets", "specui changelog" or "specui versions".
+// Loads of the specification are recorded as "specui spec load" spans.
+package otel
+
+import (
+	"context"
+
+	"github.com/oaswrap/spec-ui/observe"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer.
+const ScopeName = "github.com/oaswrap/spec-ui/observe/otel"
+
+// Observer records the events of a handler as spans. Several handlers can
+// share an observer.
+type Observer struct {
+	tracer trace.Tracer
+}
+
+var _ observe.Observer = (*Observer)(nil)
+
+// New returns an observer creating spans with a tracer of tp, or of the global
+// tracer provider when tp is nil.
+func New(tp trace.TracerProvider) *Observer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &Observer{tracer: tp.Tracer(ScopeName)}
+}
+
+// ObserveRequest records a served request as a span covering the time it took.
+func (o *Observer) ObserveRequest(e observe.Request) {
+	ctx := e.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, span := o.tracer.Start(ctx, "specui "+string(e.Route),
+		trace.WithTimestamp(e.Start),
+		trace.WithAttributes(
+			attribute.String("specui.route", string(e.Route)),
+			attribute.String("specui.provider", e.Provider),
+			attribute.String("http.request.method", e.Method),
+			attribute.String("url.path", e.Path),
+			attribute.Int("http.response.status_code", e.Status),
+			attribute.Int64("http.response.body.size", e.Bytes),
+		),
+	)
+	if e.Status >= 500 {
+		span.SetStatus(codes.Error, "")
+	}
+	span.End(trace.WithTimestamp(e.Start.Add(e.Duration)))
+}
+
+// ObserveLoad records a load of the specification as a root span, since the
+// load is shared by all the requests.
+func (o *Observer) ObserveLoad(e observe.Load) {
+	_, span := o.tracer.Start(context.Background(), "specui spec load",
+		trace.WithTimestamp(e.Start),
+		trace.WithAttributes(
+			attribute.String("specui.provider", e.Provider),
+			attribute.String("specui.source", e.Source),
+			attribute.String("url.path", e.Path),
+			attribute.Int("specui.spec.size", e.Bytes),
+		),
+	)
+	if e.Err != nil {
+		span.RecordError(e.Err)
+		span.SetStatus(codes.Error, e.Err.Error())
+	}
+	span.End(trace.WithTimestamp(e.Start.Add(e.Duration)))
+}
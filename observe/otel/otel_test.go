@@ -0,0 +1,75 @@
+package otel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	otelobserver "github.com/oaswrap/spec-ui/observe/otel"
+	"github.com/oaswrap/spec-ui/scalar"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type failingGenerator struct{}
+
+func (failingGenerator) MarshalJSON() ([]byte, error) { return nil, assert.AnError }
+func (failingGenerator) MarshalYAML() ([]byte, error) { return nil, assert.AnError }
+
+func TestObserver(t *testing.T) {
+	newHandler := func(opts ...specui.Option) (*specui.Handler, *tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+		rec := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+		opts = append(opts, specui.WithObserver(otelobserver.New(tp)), scalar.WithUI())
+		return specui.NewHandler(opts...), rec, tp
+	}
+
+	t.Run("when serving a request within a span", func(t *testing.T) {
+		h, spans, tp := newHandler(specui.WithSpecEmbedFS("petstore.json", &testdata.FS))
+		ctx, parent := tp.Tracer("test").Start(context.Background(), "GET /docs")
+
+		rec := httptest.NewRecorder()
+		h.Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil).WithContext(ctx))
+		parent.End()
+
+		ended := spans.Ended()
+		require.Len(t, ended, 2)
+		span := ended[0]
+		assert.Equal(t, "specui docs", span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Subset(t, span.Attributes(), []attribute.KeyValue{
+			attribute.String("specui.route", "docs"),
+			attribute.String("specui.provider", "scalar"),
+			attribute.String("http.request.method", http.MethodGet),
+			attribute.String("url.path", "/docs"),
+			attribute.Int("http.response.status_code", http.StatusOK),
+			attribute.Int64("http.response.body.size", int64(rec.Body.Len())),
+		})
+		assert.False(t, span.EndTime().Before(span.StartTime()))
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	})
+	t.Run("when the generator fails", func(t *testing.T) {
+		h, spans, _ := newHandler(specui.WithSpecGenerator(failingGenerator{}))
+
+		rec := httptest.NewRecorder()
+		h.Spec().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+
+		ended := spans.Ended()
+		require.Len(t, ended, 2)
+		load, request := ended[0], ended[1]
+		assert.Equal(t, "specui spec load", load.Name())
+		assert.False(t, load.Parent().IsValid(), "loads are root spans")
+		assert.Contains(t, load.Attributes(), attribute.String("specui.source", "generator"))
+		assert.Equal(t, codes.Error, load.Status().Code)
+		require.Len(t, load.Events(), 1, "the error is recorded")
+		assert.Equal(t, "specui spec", request.Name())
+		assert.Equal(t, codes.Error, request.Status().Code)
+	})
+}
@@ -0,0 +1,104 @@
+// Package prometheus records the requests served by a spec-ui handler and
+// the loads of its specification as Prometheus metrics:
+//
+//	obs, err := prometheusobserver.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	h := specui.NewHandler(
+//		specui.WithSpecFile("openapi.yaml"),
+//		specui.WithObserver(obs),
+//		swaggerui.WithUI(),
+//	)
+//
+// The metrics are:
+//
+//	specui_requests_total{route, provider, status}            counter
+//	specui_request_duration_seconds{route, provider}          histogram
+//	specui_response_bytes_total{route, provider}              counter
+//	specui_spec_loads_total{provider, source, result}         counter
+//	specui_spec_load_duration_seconds{provider, source}       histogram
+//
+// route is one of docs, spec, assets, changelog and versions, and result is
+// success or error.
+package prometheus
+
+import (
+	"strconv"
+
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Observer records the events of a handler as Prometheus metrics. Several
+// handlers can share an observer.
+type Observer struct {
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	bytes        *prometheus.CounterVec
+	loads        *prometheus.CounterVec
+	loadDuration *prometheus.HistogramVec
+}
+
+var _ observe.Observer = (*Observer)(nil)
+
+// New returns an observer with its metrics registered with reg, or with
+// prometheus.DefaultRegisterer when reg is nil.
+func New(reg prometheus.Registerer) (*Observer, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	o := &Observer{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "specui",
+			Name:      "requests_total",
+			Help:      "Requests served by the spec-ui handler.",
+		}, []string{"route", "provider", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "specui",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken to serve the requests of the spec-ui handler.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "provider"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "specui",
+			Name:      "response_bytes_total",
+			Help:      "Body bytes written by the spec-ui handler.",
+		}, []string{"route", "provider"}),
+		loads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "specui",
+			Name:      "spec_loads_total",
+			Help:      "Loads of the OpenAPI specification, read from a file or generated.",
+		}, []string{"provider", "source", "result"}),
+		loadDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "specui",
+			Name:      "spec_load_duration_seconds",
+			Help:      "Time taken to load the OpenAPI specification.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"provider", "source"}),
+	}
+	for _, c := range []prometheus.Collector{o.requests, o.duration, o.bytes, o.loads, o.loadDuration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// ObserveRequest records a served request.
+func (o *Observer) ObserveRequest(e observe.Request) {
+	route := string(e.Route)
+	o.requests.WithLabelValues(route, e.Provider, strconv.Itoa(e.Status)).Inc()
+	o.duration.WithLabelValues(route, e.Provider).Observe(e.Duration.Seconds())
+	o.bytes.WithLabelValues(route, e.Provider).Add(float64(e.Bytes))
+}
+
+// ObserveLoad records a load of the specification.
+func (o *Observer) ObserveLoad(e observe.Load) {
+	result := "success"
+	if e.Err != nil {
+		result = "error"
+	}
+	o.loads.WithLabelValues(e.Provider, e.Source, result).Inc()
+	o.loadDuration.WithLabelValues(e.Provider, e.Source).Observe(e.Duration.Seconds())
+}
@@ -0,0 +1,64 @@
+package prometheus_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	specui "github.com/oaswrap/spec-ui"
+	prometheusobserver "github.com/oaswrap/spec-ui/observe/prometheus"
+	"github.com/oaswrap/spec-ui/swaggerui"
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserver(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	obs, err := prometheusobserver.New(reg)
+	require.NoError(t, err)
+	h := specui.NewHandler(
+		specui.WithSpecEmbedFS("petstore.json", &testdata.FS),
+		specui.WithObserver(obs),
+		swaggerui.WithUI(),
+	)
+
+	serve := func(handler http.Handler, path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Body.Len()
+	}
+	docs := serve(h.Docs(), "/docs") + serve(h.Docs(), "/docs")
+	spec := serve(h.Spec(), "/docs/openapi.json")
+
+	err = testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP specui_requests_total Requests served by the spec-ui handler.
+# TYPE specui_requests_total counter
+specui_requests_total{provider="swaggerui",route="docs",status="200"} 2
+specui_requests_total{provider="swaggerui",route="spec",status="200"} 1
+# HELP specui_response_bytes_total Body bytes written by the spec-ui handler.
+# TYPE specui_response_bytes_total counter
+specui_response_bytes_total{provider="swaggerui",route="docs"} `+strconv.Itoa(docs)+`
+specui_response_bytes_total{provider="swaggerui",route="spec"} `+strconv.Itoa(spec)+`
+# HELP specui_spec_loads_total Loads of the OpenAPI specification, read from a file or generated.
+# TYPE specui_spec_loads_total counter
+specui_spec_loads_total{provider="swaggerui",result="success",source="petstore.json"} 1
+`), "specui_requests_total", "specui_response_bytes_total", "specui_spec_loads_total")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "specui_request_duration_seconds"))
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "specui_spec_load_duration_seconds"))
+}
+
+func TestNew(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	_, err := prometheusobserver.New(reg)
+	require.NoError(t, err)
+
+	_, err = prometheusobserver.New(reg)
+	var already prometheus.AlreadyRegisteredError
+	assert.ErrorAs(t, err, &already, "the metrics are registered once per registry")
+}
@@ -0,0 +1,79 @@
+package specui
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/oaswrap/spec-ui/observe"
+)
+
+// ObserveRequest reports a request served without the net/http handlers, e.g.
+// from Content by the fasthttp adapter, to the observer configured with
+// WithObserver. The provider of e is set by the handler. It does nothing when
+// no observer is configured.
+func (h *Handler) ObserveRequest(e observe.Request) {
+	if h.cfg.Observer == nil {
+		return
+	}
+	e.Provider = h.cfg.Provider.String()
+	h.cfg.Observer.ObserveRequest(e)
+}
+
+// observed returns next reporting its requests as routes of kind to the
+// observer, or next itself when no observer is configured.
+func (h *Handler) observed(kind observe.RouteKind, next http.Handler) http.Handler {
+	if h.cfg.Observer == nil || next == nil {
+		return next
+	}
+	return &observedHandler{handler: h, kind: kind, next: next}
+}
+
+type observedHandler struct {
+	handler *Handler
+	kind    observe.RouteKind
+	next    http.Handler
+}
+
+func (o *observedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rw := &observedWriter{ResponseWriter: w, status: http.StatusOK}
+	o.next.ServeHTTP(rw, r)
+	o.handler.ObserveRequest(observe.Request{
+		Context:  r.Context(),
+		Route:    o.kind,
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Status:   rw.status,
+		Bytes:    rw.bytes,
+		Start:    start,
+		Duration: time.Since(start),
+	})
+}
+
+// observedWriter records the status and the body size of a response.
+type observedWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (w *observedWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *observedWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (w *observedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
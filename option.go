@@ -3,8 +3,15 @@ package specui
 import (
 	"embed"
 	"io/fs"
+	"net/http"
+	"time"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/observe"
+	"github.com/oaswrap/spec-ui/redact"
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/oaswrap/spec-ui/transform"
 )
 
 func newConfig(opts ...Option) *config.SpecUI {
@@ -14,15 +21,41 @@ func newConfig(opts ...Option) *config.SpecUI {
 		DocsPath:   "/docs",
 		SpecPath:   "/docs/openapi.json",
 		AssetsPath: "/docs/_assets",
+		Changelog:  config.Changelog{Path: "/docs/changelog"},
+		Versions:   config.Versions{Path: "/docs/v", Refresh: time.Minute},
 	}
 
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if cfg.SpecSource == nil {
+		cfg.SpecSource = deprecatedSpecSource(cfg)
+	}
+	if src, ok := cfg.SpecSource.(store.Source); ok && src.Version == "" && src.Refresh == 0 {
+		// The latest version follows the versions published.
+		src.Refresh = cfg.Versions.Refresh
+		cfg.SpecSource = src
+	}
 
 	return cfg
 }
 
+// deprecatedSpecSource returns the source of the deprecated specification
+// fields of cfg, or nil when none is set.
+func deprecatedSpecSource(cfg *config.SpecUI) config.SpecSource {
+	switch {
+	case cfg.SpecGenerator != nil:
+		return source.Generator{Generator: cfg.SpecGenerator}
+	case cfg.SpecFile == "":
+		return nil
+	case cfg.SpecEmbedFS != nil:
+		return source.FS{FS: cfg.SpecEmbedFS, Path: cfg.SpecFile}
+	case cfg.SpecIOFS != nil:
+		return source.FS{FS: cfg.SpecIOFS, Path: cfg.SpecFile}
+	}
+	return source.File{Path: cfg.SpecFile}
+}
+
 // Option is a function that configures the OpenAPI UI.
 type Option func(*config.SpecUI)
 
@@ -64,32 +97,217 @@ func WithAssetsPath(path string) Option {
 	}
 }
 
-// WithSpecFile sets the path to the specification file.
+// WithSpecFile loads the specification from a file of the OS filesystem, see
+// source.File.
 func WithSpecFile(filepath string) Option {
+	return WithSpecSource(source.File{Path: filepath})
+}
+
+// WithSpecIOFS loads the specification from a file of a filesystem, see
+// source.FS.
+func WithSpecIOFS(filepath string, iofs fs.FS) Option {
+	return WithSpecSource(source.FS{FS: iofs, Path: filepath})
+}
+
+// WithSpecEmbedFS loads the specification from a file of an embedded
+// filesystem, see source.FS.
+func WithSpecEmbedFS(filepath string, fs *embed.FS) Option {
+	return WithSpecSource(source.FS{FS: fs, Path: filepath})
+}
+
+// WithSpecGenerator loads the specification from a generator, marshaled in
+// the format of the spec path, see source.Generator.
+func WithSpecGenerator(cfg config.SpecGenerator) Option {
+	return WithSpecSource(source.Generator{Generator: cfg})
+}
+
+// WithSpecURL loads the specification from an HTTP URL, see source.Remote.
+// Use WithSpecSource with a source.Remote to set a timeout, a refresh
+// interval or request headers.
+func WithSpecURL(url string) Option {
+	return WithSpecSource(source.NewRemote(url))
+}
+
+// WithSpecSource sets the source the specification is loaded from, replacing
+// the one set by any of the other WithSpec options.
+func WithSpecSource(src config.SpecSource) Option {
 	return func(c *config.SpecUI) {
-		c.SpecFile = filepath
+		c.SpecSource = src
 	}
 }
 
-// WithSpecIOFS sets the generic I/O filesystem for the specification.
-func WithSpecIOFS(filepath string, iofs fs.FS) Option {
+// WithSpecStore serves the versions of the specification held by s: the
+// latest one on the spec path, replacing the source set by any of the other
+// WithSpec options, and each version with its own docs page and
+// specification under the versions path, see Handler.VersionsPath. The docs
+// pages offer a selector of the versions. The latest version is loaded again
+// from s every minute, see WithVersionsRefresh, and the pages of a version are
+// served as long as s lists it.
+func WithSpecStore(s config.SpecStore) Option {
 	return func(c *config.SpecUI) {
-		c.SpecFile = filepath
-		c.SpecIOFS = iofs
+		c.SpecSource = store.Source{Store: s}
+		c.Versions.Store = s
 	}
 }
 
-// WithSpecEmbedFS sets the embedded file system for the specification.
-func WithSpecEmbedFS(filepath string, fs *embed.FS) Option {
+// WithVersionsPath sets the path prefix of the version pages of
+// WithSpecStore.
+func WithVersionsPath(path string) Option {
 	return func(c *config.SpecUI) {
-		c.SpecFile = filepath
-		c.SpecEmbedFS = fs
+		c.Versions.Path = path
 	}
 }
 
-// WithSpecGenerator sets up the OpenAPI specification generator.
-func WithSpecGenerator(cfg config.SpecGenerator) Option {
+// WithVersionsRefresh sets how often the latest version of WithSpecStore is
+// loaded again from the store, so that the spec path and the docs page follow
+// the versions published. The default is one minute; zero loads it once.
+func WithVersionsRefresh(interval time.Duration) Option {
+	return func(c *config.SpecUI) {
+		c.Versions.Refresh = interval
+	}
+}
+
+// WithOffline enables strict offline mode: Handler.CheckConfig fails unless
+// assets are embedded and neither the docs page nor the assets served
+// reference other origins.
+func WithOffline() Option {
+	return func(c *config.SpecUI) {
+		c.Offline = true
+	}
+}
+
+// WithInlineSpec embeds the specification in the docs page as a JSON object,
+// so that the UI does not request SpecPath. The specification is loaded when
+// the page is first rendered; the spec handler keeps serving it for downloads.
+func WithInlineSpec() Option {
+	return func(c *config.SpecUI) {
+		c.InlineSpec = true
+	}
+}
+
+// WithValidation validates the OpenAPI specification against the JSON Schema
+// of its version when it is loaded. The mode selects whether problems are
+// logged, fail Handler.CheckConfig, or are shown on the docs page.
+func WithValidation(mode config.Validation) Option {
+	return func(c *config.SpecUI) {
+		c.Validation = mode
+	}
+}
+
+// WithSwaggerConversion converts Swagger 2.0 specifications to OpenAPI 3.0
+// before they are served, for providers that render Swagger 2.0 poorly. Other
+// specifications are served unchanged. Constructs that cannot be converted
+// are reported by Handler.Validate with the validation problems.
+func WithSwaggerConversion() Option {
+	return func(c *config.SpecUI) {
+		c.ConvertSwagger = true
+	}
+}
+
+// WithOpenAPI31Downgrade rewrites OpenAPI 3.1 specifications as OpenAPI 3.0
+// before they are served, for provider versions that render 3.1 schemas
+// incorrectly. When providers are given, specifications are rewritten only
+// for them. Constructs that cannot be rewritten are reported by
+// Handler.Validate with the validation problems.
+func WithOpenAPI31Downgrade(providers ...config.Provider) Option {
+	return func(c *config.SpecUI) {
+		c.DowngradeOpenAPI31 = true
+		c.DowngradeProviders = providers
+	}
+}
+
+// WithRedaction removes internal-only content from the specification before
+// it is served: paths, operations, parameters and properties marked
+// "x-internal: true", the extensions listed in cfg and the example values
+// matching its patterns. Components left unreferenced are pruned. See package
+// redact.
+func WithRedaction(cfg redact.Config) Option {
+	return func(c *config.SpecUI) {
+		c.Redaction = &cfg
+	}
+}
+
+// WithSpecTransform adds transformers that rewrite the specification before
+// it is served, e.g. to add vendor extensions or rewrite server URLs. They run
+// in the order they are added, after the Swagger 2.0 conversion, the OpenAPI
+// 3.1 downgrade and the redaction. Results are cached per specification and variant,
+// see WithSpecVariant.
+func WithSpecTransform(transformers ...transform.Transformer) Option {
+	return func(c *config.SpecUI) {
+		c.SpecTransforms = append(c.SpecTransforms, transformers...)
+	}
+}
+
+// WithSpecVariant sets the function that derives the variant key passed to
+// spec transformers from each specification request, e.g. its host. One
+// transformed specification is cached per variant, so fn should return a
+// small set of values.
+func WithSpecVariant(fn func(r *http.Request) string) Option {
+	return func(c *config.SpecUI) {
+		c.SpecVariant = fn
+	}
+}
+
+// WithLogger sets the logger of load and generation failures, validation
+// results and serve errors, e.g. a *slog.Logger. By default warnings and
+// errors are logged with the standard logger.
+func WithLogger(l config.Logger) Option {
+	return func(c *config.SpecUI) {
+		c.Logger = l
+	}
+}
+
+// WithObserver adds an observer of the requests served by the handler and of
+// the loads of the specification, e.g. to record metrics or traces. Observers
+// added by several calls are called in order.
+func WithObserver(o observe.Observer) Option {
+	return func(c *config.SpecUI) {
+		c.Observer = observe.Multi(c.Observer, o)
+	}
+}
+
+// WithErrorHandler sets the writer of error responses, e.g. when the
+// specification cannot be loaded. By default errors are written as RFC 7807
+// problem details, see problem.Write; fn can call it for the errors it does
+// not handle.
+func WithErrorHandler(fn config.ErrorHandler) Option {
+	return func(c *config.SpecUI) {
+		c.ErrorHandler = fn
+	}
+}
+
+// WithChangelog serves a page at the changelog path listing the changes from
+// the previous specification stored at filepath in fsys, or on disk when fsys
+// is nil. Changes that break clients are listed first.
+func WithChangelog(filepath string, fsys fs.FS) Option {
+	return func(c *config.SpecUI) {
+		c.Changelog.SpecFile = filepath
+		c.Changelog.SpecFS = fsys
+	}
+}
+
+// WithChangelogPath sets the path to the changelog page.
+func WithChangelogPath(path string) Option {
+	return func(c *config.SpecUI) {
+		c.Changelog.Path = path
+	}
+}
+
+// WithCDN sets the URL template used to load provider assets in CDN mode.
+//
+// The {package}, {version} and {file} placeholders are replaced with the
+// provider npm package name, its version and the asset file path, e.g.
+// "https://unpkg.com/{package}@{version}/{file}".
+func WithCDN(template string) Option {
+	return func(c *config.SpecUI) {
+		c.CDN.Template = template
+	}
+}
+
+// WithInsecureCDN allows provider assets to be loaded from URLs that are not
+// absolute https URLs, such as a plain http mirror on the local network.
+func WithInsecureCDN() Option {
 	return func(c *config.SpecUI) {
-		c.SpecGenerator = cfg
+		c.CDN.AllowInsecure = true
 	}
 }
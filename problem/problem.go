@@ -0,0 +1,142 @@
+// Package problem writes the error responses of the handler as RFC 7807
+// problem details, see specui.WithErrorHandler.
+//
+// Write is the default error handler. It answers browsers with an HTML page
+// and other clients, such as the UIs fetching the specification, with an
+// application/problem+json body:
+//
+//	{
+//	  "type": "about:blank",
+//	  "title": "Not Found",
+//	  "status": 404,
+//	  "detail": "OpenAPI specification file is not found",
+//	  "instance": "/docs/openapi.json"
+//	}
+//
+// Validation problems are listed in the "errors" extension member.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/validate"
+)
+
+// Error is an error whose Message is shown to clients in place of the
+// underlying error, which may reveal file paths or implementation details.
+// Error handlers still receive the underlying error with errors.Is and
+// errors.As, e.g. to log it.
+type Error struct {
+	Message string // Message shown to clients
+	Err     error  // Underlying error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Details is an RFC 7807 problem details object.
+type Details struct {
+	Type     string          `json:"type"`             // URI of the problem type, "about:blank" for plain HTTP errors
+	Title    string          `json:"title"`            // Status text of the response
+	Status   int             `json:"status"`           // HTTP status code
+	Detail   string          `json:"detail,omitempty"` // Message safe to show to clients
+	Instance string          `json:"instance,omitempty"`
+	Errors   validate.Errors `json:"errors,omitempty"` // Validation problems of the specification
+}
+
+// New returns the details of the error err in the response to r. The detail
+// is the message of the first Error in the chain of err, or err itself unless
+// it carries a file path, see fs.PathError.
+func New(r *http.Request, status int, err error) *Details {
+	d := &Details{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail(err),
+		Instance: r.URL.Path,
+	}
+	var problems validate.Errors
+	if errors.As(err, &problems) {
+		d.Errors = problems
+	}
+	return d
+}
+
+func detail(err error) string {
+	var e *Error
+	var pathErr *fs.PathError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &e):
+		return e.Message
+	case errors.As(err, &pathErr):
+		return ""
+	}
+	return err.Error()
+}
+
+// Write is the default error handler: it writes the details of err as an HTML
+// page when the client accepts text/html, as browsers opening the docs page
+// do, and as application/problem+json otherwise.
+func Write(w http.ResponseWriter, r *http.Request, status int, err error) {
+	d := New(r, status, err)
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		WriteHTML(w, d)
+		return
+	}
+	WriteJSON(w, d)
+}
+
+// WriteJSON writes d as an application/problem+json response.
+func WriteJSON(w http.ResponseWriter, d *Details) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(d.Status)
+	_ = json.NewEncoder(w).Encode(d)
+}
+
+var htmlTpl = template.Must(template.New("problem").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{ .Status }} {{ .Title }}</title>
+  <style>
+    body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; color: #1f2328; }
+    h1 { font-size: 1.5rem; color: #cf222e; }
+    li { margin: .5rem 0; }
+    code { background: #f6f8fa; padding: .1rem .3rem; border-radius: 4px; }
+  </style>
+</head>
+<body>
+  <h1>{{ .Status }} {{ .Title }}</h1>
+  {{ with .Detail }}<p>{{ . }}</p>{{ end }}
+  {{ with .Errors }}<ul>
+    {{ range . }}<li><code>{{ or .Pointer "/" }}</code>{{ if .Line }} (line {{ .Line }}){{ end }}: {{ .Message }}</li>
+    {{ end }}
+  </ul>{{ end }}
+</body>
+</html>
+`))
+
+// WriteHTML writes d as an HTML page.
+func WriteHTML(w http.ResponseWriter, d *Details) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(d.Status)
+	_ = htmlTpl.Execute(w, d)
+}
@@ -0,0 +1,101 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/problem"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "/srv/api/openapi.yaml", Err: fs.ErrNotExist}
+	problems := validate.Errors{{Pointer: "/info", Line: 1, Message: `missing required property "version"`}}
+
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   problem.Details
+	}{
+		{
+			name:   "when the error has a public message",
+			status: http.StatusNotFound,
+			err:    &problem.Error{Message: "OpenAPI specification file is not found", Err: pathErr},
+			want:   problem.Details{Type: "about:blank", Title: "Not Found", Status: 404, Detail: "OpenAPI specification file is not found", Instance: "/docs/openapi.json"},
+		},
+		{
+			name:   "when the error carries a file path",
+			status: http.StatusInternalServerError,
+			err:    fmt.Errorf("failed to transform OpenAPI specification: %w", pathErr),
+			want:   problem.Details{Type: "about:blank", Title: "Internal Server Error", Status: 500, Instance: "/docs/openapi.json"},
+		},
+		{
+			name:   "when the error is plain",
+			status: http.StatusInternalServerError,
+			err:    errors.New("OpenAPI specification file is not set"),
+			want:   problem.Details{Type: "about:blank", Title: "Internal Server Error", Status: 500, Detail: "OpenAPI specification file is not set", Instance: "/docs/openapi.json"},
+		},
+		{
+			name:   "when the error lists validation problems",
+			status: http.StatusInternalServerError,
+			err:    problems,
+			want:   problem.Details{Type: "about:blank", Title: "Internal Server Error", Status: 500, Detail: problems.Error(), Instance: "/docs/openapi.json", Errors: problems},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := problem.New(httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil), tt.status, tt.err)
+			assert.Equal(t, &tt.want, got)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	err := &problem.Error{Message: "failed to generate OpenAPI schema", Err: fs.ErrPermission}
+
+	assert.EqualError(t, err, "failed to generate OpenAPI schema: permission denied")
+	assert.ErrorIs(t, err, fs.ErrPermission)
+	assert.EqualError(t, &problem.Error{Message: "failed"}, "failed")
+}
+
+func TestWrite(t *testing.T) {
+	write := func(accept string, err error) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		problem.Write(rec, req, http.StatusInternalServerError, err)
+		return rec
+	}
+
+	t.Run("when the client is a browser", func(t *testing.T) {
+		rec := write("text/html,application/xhtml+xml,*/*;q=0.8", &problem.Error{Message: "failed to render <docs> page"})
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.Contains(t, rec.Body.String(), "<h1>500 Internal Server Error</h1>")
+		assert.Contains(t, rec.Body.String(), "<p>failed to render &lt;docs&gt; page</p>")
+	})
+	t.Run("when the client fetches JSON", func(t *testing.T) {
+		rec := write("application/json,*/*", &problem.Error{Message: "failed to render docs page"})
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		var got problem.Details
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, problem.Details{Type: "about:blank", Title: "Internal Server Error", Status: 500, Detail: "failed to render docs page", Instance: "/docs"}, got)
+	})
+	t.Run("when listing validation problems", func(t *testing.T) {
+		rec := write("text/html", validate.Errors{{Pointer: "/info", Line: 1, Message: `missing required property "version"`}})
+
+		assert.Contains(t, rec.Body.String(), `<li><code>/info</code> (line 1): missing required property &#34;version&#34;</li>`)
+	})
+}
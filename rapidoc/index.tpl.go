@@ -6,11 +6,29 @@ import (
 	"strings"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
+// IndexTpl creates the page template, loading the assets from assetBase.
+//
+// Deprecated: The template rendered by the handler links the assets through
+// template functions; IndexTpl returns it rendered with the functions of the
+// handler for assets loaded from the base, for callers parsing it with
+// html/template as is.
 func IndexTpl(assetBase, faviconBase string, cfg *config.RapiDoc) string {
+	static := &config.SpecUI{}
+	tpl, err := asset.Static(indexTpl(faviconBase, cfg), asset.Local(assetBase).FuncMap(), spec.NewInline(static).FuncMap(), versions.FuncMap(static))
+	if err != nil {
+		panic(err)
+	}
+	return tpl
+}
+
+// indexTpl creates the page template.
+func indexTpl(faviconBase string, cfg *config.RapiDoc) string {
 	settings := map[string]string{
-		"spec-url":              `"{{ .OpenAPIURL }}"`,
 		"show-info":             fmt.Sprintf(`"%t"`, !cfg.HideInfo),
 		"show-header":           fmt.Sprintf(`"%t"`, !cfg.HideHeader),
 		"allow-search":          fmt.Sprintf(`"%t"`, !cfg.HideSearch),
@@ -52,17 +70,45 @@ func IndexTpl(assetBase, faviconBase string, cfg *config.RapiDoc) string {
 <head>
 	<title>{{.Title}} - RapiDoc</title>
 	<meta charset="utf-8">
-	<script type="module" src="` + assetBase + `/rapidoc-min.js"></script>
+{{- if inline }}
+	<script type="module">{{ script "rapidoc-min.js" }}</script>
+{{- else }}
+	<script type="module" src="{{ asset "rapidoc-min.js" }}"{{ integrity "rapidoc-min.js" }}></script>
+{{- end }}
 ` + faviconLink + `
+{{- if embedded }}
+{{- if inline }}
+	<style>{{ style "fonts/fonts.css" }}</style>
+{{- else }}
+	<link href="{{ asset "fonts/fonts.css" }}" rel="stylesheet">
+{{- end }}
+{{- end }}
 </head>
 <body>
+{{- versions }}
 <rapi-doc
+	id="rapidoc"
+{{- if not inlineSpec }}
+	spec-url="{{ .OpenAPIURL }}"
+{{- end }}
 ` + strings.Join(settingsStr, ",\n") + `
+{{- if embedded }}
+	load-fonts="false"
+	regular-font="Open Sans"
+	mono-font="Source Code Pro"
+{{- end }}
 >
 {{ if .Logo }}
 	<img slot="nav-logo" src="{{ .Logo }}" />
 {{ end }}
 </rapi-doc>
+{{- if inlineSpec }}
+<script>
+	customElements.whenDefined("rapi-doc").then(function () {
+		document.getElementById("rapidoc").loadSpec({{ spec }});
+	});
+</script>
+{{- end }}
 </body>
 </html>
 `
@@ -9,5 +9,8 @@ import (
 
 func newHandler(cfg *config.SpecUI) http.Handler {
 	cfg.EmbedAssets = true
+	if cfg.AssetsFS == nil {
+		cfg.AssetsFS = assetsFS
+	}
 	return rapidoc.NewHandler(cfg)
 }
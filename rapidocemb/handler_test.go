@@ -1,11 +1,15 @@
 package rapidocemb
 
 import (
+	"io/fs"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/constant"
+	"github.com/oaswrap/spec-ui/internal/providertest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWithUI(t *testing.T) {
@@ -60,3 +64,15 @@ func TestHandlerAndAssets(t *testing.T) {
 
 	assert.Equal(t, 200, faviconRec.Code)
 }
+
+func TestAssets(t *testing.T) {
+	assets, err := fs.Sub(embeddedAssets, "assets")
+	require.NoError(t, err)
+
+	providertest.Assets(t, providertest.Provider{
+		WithUI:    WithUI(),
+		Assets:    assets,
+		Integrity: constant.RapiDocIntegrity,
+		Script:    "rapidoc-min.js",
+	})
+}
@@ -0,0 +1,435 @@
+// Package redact removes internal-only content from OpenAPI documents before
+// they are served, see specui.WithRedaction.
+//
+// Paths, operations, parameters and schema properties marked
+// "x-internal: true" are removed, along with the components that they were
+// the only users of. Configured vendor extensions are stripped wherever they
+// appear, and example values matching configured patterns are scrubbed:
+//
+//	specui.WithRedaction(redact.Config{
+//		Extensions: []string{"x-amazon-apigateway-*", "x-handler"},
+//		Examples:   []*regexp.Regexp{redact.Email, redact.Token},
+//	})
+//
+// Components marked "x-internal: true" are removed when nothing references
+// them anymore; those still referenced are kept and reported as warnings.
+package redact
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/transform"
+	"gopkg.in/yaml.v3"
+)
+
+// InternalKey is the extension that marks internal-only content.
+const InternalKey = "x-internal"
+
+// DefaultReplacement replaces the matches of example patterns when
+// Config.Replacement is empty.
+const DefaultReplacement = "REDACTED"
+
+var (
+	// Email matches email addresses.
+	Email = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// Token matches JSON Web Tokens, prefixed API keys such as "sk_live_…"
+	// and long hexadecimal secrets.
+	Token = regexp.MustCompile(`\b(?:eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+|(?:sk|pk|rk|ghp|gho|xox[abp])[-_][A-Za-z0-9_-]{16,}|[A-Fa-f0-9]{32,})\b`)
+)
+
+// Config selects what is redacted from a document. Content marked
+// "x-internal: true" is always removed.
+type Config struct {
+	Extensions  []string         // Vendor extensions stripped wherever they appear; a trailing "*" matches a prefix
+	Examples    []*regexp.Regexp // Patterns scrubbed from the string values of examples
+	Replacement string           // Text replacing the matches of Examples, DefaultReplacement when empty
+}
+
+// Transformer returns a transform.Transformer that redacts documents as
+// configured in cfg.
+func Transformer(cfg Config) transform.Transformer {
+	if cfg.Replacement == "" {
+		cfg.Replacement = DefaultReplacement
+	}
+	return func(doc *transform.Document, _ string) error {
+		if doc.Root.Kind != yaml.MappingNode {
+			return nil
+		}
+		r := &redactor{cfg: cfg, doc: doc, swagger: get(doc.Root, "swagger") != nil}
+		r.run()
+		return nil
+	}
+}
+
+type redactor struct {
+	cfg        Config
+	doc        *transform.Document
+	swagger    bool                  // Swagger 2.0 document, whose examples are raw values
+	components []*component          // Reusable objects, in document order
+	byRef      map[string]*component // Reusable objects by local reference
+	internal   map[string]bool       // References of the components marked internal
+}
+
+// component is a reusable object of the document, e.g. a schema.
+type component struct {
+	ref     string     // Local reference, e.g. "#/components/schemas/Pet"
+	section *yaml.Node // Mapping holding the component
+	name    string
+	node    *yaml.Node
+}
+
+func (r *redactor) run() {
+	r.collect()
+	before := r.reachable()
+	r.object(r.doc.Root)
+	after := r.reachable()
+
+	for _, c := range r.components {
+		switch {
+		case after[c.ref] && r.internal[c.ref]:
+			r.doc.Warn(c.node, c.ref[1:], "%s is marked %s but is still referenced", c.ref, InternalKey)
+		case !after[c.ref] && (before[c.ref] || r.internal[c.ref]):
+			remove(c.section, c.name)
+		}
+	}
+}
+
+// collect lists the components of the document and those marked internal.
+func (r *redactor) collect() {
+	r.byRef = make(map[string]*component)
+	r.internal = make(map[string]bool)
+	add := func(section *yaml.Node, prefix string) {
+		if section == nil || section.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(section.Content); i += 2 {
+			name := section.Content[i].Value
+			c := &component{ref: prefix + escape(name), section: section, name: name, node: section.Content[i+1]}
+			r.components = append(r.components, c)
+			r.byRef[c.ref] = c
+			if marked(c.node) {
+				r.internal[c.ref] = true
+			}
+		}
+	}
+
+	if r.swagger {
+		for _, kind := range []string{"definitions", "parameters", "responses"} {
+			add(get(r.doc.Root, kind), "#/"+kind+"/")
+		}
+		return
+	}
+	components := get(r.doc.Root, "components")
+	// Security schemes are referenced by name and never pruned.
+	for _, kind := range []string{"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "links", "callbacks", "pathItems"} {
+		add(get(components, kind), "#/components/"+kind+"/")
+	}
+}
+
+// reachable returns the references of the components used outside the
+// component sections, directly or through other components.
+func (r *redactor) reachable() map[string]bool {
+	seen := make(map[string]bool)
+	var queue []string
+	visit := func(n *yaml.Node) {
+		refs(n, func(ref string) {
+			if !seen[ref] {
+				seen[ref] = true
+				queue = append(queue, ref)
+			}
+		})
+	}
+
+	root := r.doc.Root
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch key := root.Content[i].Value; {
+		case key == "components":
+			visit(get(root.Content[i+1], "securitySchemes"))
+		case r.swagger && (key == "definitions" || key == "parameters" || key == "responses"):
+		default:
+			visit(root.Content[i+1])
+		}
+	}
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		if c := r.byRef[ref]; c != nil {
+			visit(c.node)
+		}
+	}
+	return seen
+}
+
+// object redacts a mapping of keywords, such as an operation or a schema.
+func (r *redactor) object(m *yaml.Node) {
+	if m.Kind != yaml.MappingNode {
+		r.value(m)
+		return
+	}
+	for i := 0; i+1 < len(m.Content); {
+		key, v := m.Content[i].Value, m.Content[i+1]
+		if strings.HasPrefix(key, "x-") {
+			if key == InternalKey || r.stripped(key) {
+				m.Content = append(m.Content[:i], m.Content[i+2:]...)
+				continue
+			}
+			// Extension values are kept as written.
+			i += 2
+			continue
+		}
+
+		switch key {
+		case "example", "value":
+			r.scrub(v)
+		case "default", "enum", "const":
+			// Data rather than documentation.
+		case "examples":
+			if v.Kind == yaml.SequenceNode || r.swagger {
+				r.scrub(v)
+			} else {
+				r.names(v)
+			}
+		case "paths", "webhooks", "pathItems":
+			r.pathItems(v)
+		case "callbacks":
+			eachValue(v, r.pathItems)
+		case "properties":
+			r.properties(m, v)
+		case "parameters":
+			if v.Kind == yaml.SequenceNode {
+				r.parameters(v)
+			} else {
+				r.names(v)
+			}
+		case "patternProperties", "definitions", "$defs", "dependentSchemas", "schemas", "responses", "headers",
+			"content", "encoding", "links", "requestBodies", "securitySchemes", "securityDefinitions",
+			"variables", "scopes", "mapping":
+			r.names(v)
+		default:
+			r.value(v)
+		}
+		i += 2
+	}
+}
+
+// names redacts the values of a mapping whose keys are names rather than
+// keywords, such as the properties of a schema.
+func (r *redactor) names(m *yaml.Node) {
+	eachValue(m, r.object)
+}
+
+func (r *redactor) value(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		r.object(n)
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			r.object(item)
+		}
+	}
+}
+
+// pathItems removes the internal path items of a mapping, and those whose
+// operations are all internal.
+func (r *redactor) pathItems(m *yaml.Node) {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(m.Content); {
+		item := m.Content[i+1]
+		if r.internalRef(item) || r.operations(item) {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			continue
+		}
+		r.object(item)
+		i += 2
+	}
+}
+
+// operations removes the internal operations of a path item and reports
+// whether it had operations and none is left.
+func (r *redactor) operations(item *yaml.Node) bool {
+	if item.Kind != yaml.MappingNode {
+		return false
+	}
+	found, left := false, false
+	for i := 0; i+1 < len(item.Content); {
+		if !methods[item.Content[i].Value] {
+			i += 2
+			continue
+		}
+		found = true
+		if r.internalRef(item.Content[i+1]) {
+			item.Content = append(item.Content[:i], item.Content[i+2:]...)
+			continue
+		}
+		left = true
+		i += 2
+	}
+	return found && !left
+}
+
+// parameters removes the internal parameters of a list.
+func (r *redactor) parameters(list *yaml.Node) {
+	kept := list.Content[:0]
+	for _, p := range list.Content {
+		if r.internalRef(p) {
+			continue
+		}
+		r.object(p)
+		kept = append(kept, p)
+	}
+	list.Content = kept
+}
+
+// properties removes the internal properties of schema s and their names
+// from its required list.
+func (r *redactor) properties(s, props *yaml.Node) {
+	if props.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(props.Content); {
+		name, p := props.Content[i].Value, props.Content[i+1]
+		if !r.internalRef(p) {
+			r.object(p)
+			i += 2
+			continue
+		}
+		props.Content = append(props.Content[:i], props.Content[i+2:]...)
+		if required := get(s, "required"); required != nil && required.Kind == yaml.SequenceNode {
+			kept := required.Content[:0]
+			for _, n := range required.Content {
+				if n.Value != name {
+					kept = append(kept, n)
+				}
+			}
+			required.Content = kept
+			if len(kept) == 0 {
+				// OpenAPI 3.0 requires required lists to be non-empty.
+				remove(s, "required")
+			}
+		}
+	}
+}
+
+// internalRef reports whether n is marked internal or references a component
+// that is.
+func (r *redactor) internalRef(n *yaml.Node) bool {
+	if marked(n) {
+		return true
+	}
+	ref := get(n, "$ref")
+	return ref != nil && r.internal[local(ref.Value)]
+}
+
+// stripped reports whether the extension key is configured to be stripped.
+func (r *redactor) stripped(key string) bool {
+	for _, e := range r.cfg.Extensions {
+		if e == key || strings.HasSuffix(e, "*") && strings.HasPrefix(key, strings.TrimSuffix(e, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrub replaces the matches of the example patterns in the strings of n.
+func (r *redactor) scrub(n *yaml.Node) {
+	if len(r.cfg.Examples) == 0 {
+		return
+	}
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Tag != "!!str" {
+			return
+		}
+		for _, p := range r.cfg.Examples {
+			n.Value = p.ReplaceAllString(n.Value, r.cfg.Replacement)
+		}
+	case yaml.MappingNode:
+		eachValue(n, r.scrub)
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			r.scrub(item)
+		}
+	}
+}
+
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+// marked reports whether n is a mapping marked "x-internal: true".
+func marked(n *yaml.Node) bool {
+	v := get(n, InternalKey)
+	var internal bool
+	return v != nil && v.Decode(&internal) == nil && internal
+}
+
+// refs calls fn with the component of each local reference under n,
+// including the discriminator mappings that reference schemas by value.
+func refs(n *yaml.Node, fn func(ref string)) {
+	if n == nil {
+		return
+	}
+	if n.Kind == yaml.ScalarNode {
+		if ref := local(n.Value); ref != "" {
+			fn(ref)
+		}
+		return
+	}
+	for _, c := range n.Content {
+		refs(c, fn)
+	}
+}
+
+// local returns the reference of the component that ref points into, e.g.
+// "#/components/schemas/Pet" for "#/components/schemas/Pet/properties/id",
+// or "" when ref is not a local reference.
+func local(ref string) string {
+	if !strings.HasPrefix(ref, "#/") {
+		return ""
+	}
+	parts := strings.SplitN(ref, "/", 5)
+	switch {
+	case len(parts) >= 4 && parts[1] == "components":
+		return strings.Join(parts[:4], "/")
+	case len(parts) >= 3 && parts[1] != "components":
+		return strings.Join(parts[:3], "/")
+	}
+	return ""
+}
+
+func get(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func remove(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func eachValue(m *yaml.Node, fn func(v *yaml.Node)) {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(m.Content); i += 2 {
+		fn(m.Content[i])
+	}
+}
+
+func escape(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
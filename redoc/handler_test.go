@@ -1,12 +1,16 @@
 package redoc_test
 
 import (
+	"bytes"
+	"html/template"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/oaswrap/spec-ui/config"
 	"github.com/oaswrap/spec-ui/redoc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandler(t *testing.T) {
@@ -25,3 +29,33 @@ func TestHandler(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "My API")
 	assert.Contains(t, rec.Body.String(), "ReDoc")
 }
+
+func BenchmarkHandler(b *testing.B) {
+	handler := redoc.NewHandler(&config.SpecUI{
+		Title:    "My API",
+		DocsPath: "/docs",
+		SpecPath: "/docs/openapi.json",
+		ReDoc:    &config.ReDoc{},
+	})
+	for _, encoding := range []string{"identity", "gzip"} {
+		b.Run(encoding, func(b *testing.B) {
+			req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		})
+	}
+}
+
+func TestIndexTpl(t *testing.T) {
+	cfg := &config.SpecUI{Title: "My API", SpecPath: "/docs/openapi.json", ReDoc: &config.ReDoc{}}
+	tpl, err := template.New("index").Parse(redoc.IndexTpl("https://cdn.example.com/redoc", &config.ReDoc{}))
+	require.NoError(t, err, "the deprecated template parses without the template functions")
+
+	var buf bytes.Buffer
+	require.NoError(t, tpl.Execute(&buf, redoc.NewHandler(cfg)))
+	assert.Contains(t, buf.String(), `https://cdn.example.com/redoc/redoc.standalone.js`)
+	assert.NotContains(t, buf.String(), "integrity=")
+}
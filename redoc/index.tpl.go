@@ -6,9 +6,28 @@ import (
 	"strings"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
+// IndexTpl creates the page template, loading the assets from assetBase.
+//
+// Deprecated: The template rendered by the handler links the assets through
+// template functions; IndexTpl returns it rendered with the functions of the
+// handler for assets loaded from the base, for callers parsing it with
+// html/template as is.
 func IndexTpl(assetBase string, cfg *config.ReDoc) string {
+	static := &config.SpecUI{}
+	tpl, err := asset.Static(indexTpl(cfg), asset.Local(assetBase).FuncMap(), spec.NewInline(static).FuncMap(), versions.FuncMap(static))
+	if err != nil {
+		panic(err)
+	}
+	return tpl
+}
+
+// indexTpl creates the page template.
+func indexTpl(cfg *config.ReDoc) string {
 	settings := map[string]string{
 		"expandResponses":     "'200,400'",
 		"hideDownloadButtons": fmt.Sprintf("%t", cfg.HideDownloadButtons),
@@ -30,7 +49,15 @@ func IndexTpl(assetBase string, cfg *config.ReDoc) string {
 	<title>{{.Title}} - ReDoc</title>
 	<meta charset="utf-8"/>
 	<meta name="viewport" content="width=device-width, initial-scale=1">
+{{- if embedded }}
+{{- if inline }}
+	<style>{{ style "fonts/fonts.css" }}</style>
+{{- else }}
+	<link href="{{ asset "fonts/fonts.css" }}" rel="stylesheet">
+{{- end }}
+{{- else }}
 	<link href="https://fonts.googleapis.com/css?family=Montserrat:300,400,700|Roboto:300,400,700" rel="stylesheet">
+{{- end }}
 	<style>
 		body {
 			margin: 0;
@@ -39,15 +66,20 @@ func IndexTpl(assetBase string, cfg *config.ReDoc) string {
 	</style>
 </head>
 <body>
+{{- versions }}
 <div id="redoc-container"></div>
-<script src="` + assetBase + `/redoc.standalone.js"> </script>
+{{- if inline }}
+<script>{{ script "redoc.standalone.js" }}</script>
+{{- else }}
+<script src="{{ asset "redoc.standalone.js" }}"{{ integrity "redoc.standalone.js" }}> </script>
+{{- end }}
 <script>
 	window.onload = function () {
 		var url = "{{ .OpenAPIURL }}";
 		if (!url.startsWith("https://") && !url.startsWith("http://")) {
 			if (url.startsWith(".")) {
-			var path = window.location.pathname;
-			path = path.endsWith("/") ? path : path + "/";
+				var path = window.location.pathname;
+				path = path.endsWith("/") ? path : path + "/";
 				url = window.location.protocol + "//" + window.location.host + path + url;
 			} else {
 				url = window.location.protocol + "//" + window.location.host + url;
@@ -56,7 +88,16 @@ func IndexTpl(assetBase string, cfg *config.ReDoc) string {
 		const options = {
 ` + strings.Join(settingsStr, ",\n") + `
 		}
-		Redoc.init(url, options, document.getElementById('redoc-container'))
+{{- if embedded }}
+		options.theme = {
+			typography: {
+				fontFamily: '"Open Sans", sans-serif',
+				headings: { fontFamily: '"Open Sans", sans-serif' },
+				code: { fontFamily: '"Source Code Pro", monospace' }
+			}
+		}
+{{- end }}
+		Redoc.init({{ if inlineSpec }}{{ spec }}{{ else }}url{{ end }}, options, document.getElementById('redoc-container'))
 	}
 </script>
 </body>
@@ -4,18 +4,42 @@ import (
 	"embed"
 	"io/fs"
 	"net/http"
+	"regexp"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
 )
 
 //go:embed assets
 var embeddedAssets embed.FS
 
-func newAssetsHandler(cfg *config.SpecUI) http.Handler {
+// externalURL matches the third-party URLs referenced by the bundle for the
+// Redocly logo. They are replaced with empty data URLs so that embed mode
+// makes no external requests.
+var externalURL = regexp.MustCompile(`https://cdn\.redoc\.ly/[^"']+`)
+
+// assetsFS holds the embedded assets, fingerprinted at init so that pages can
+// reference long-cached URLs.
+var assetsFS = newAssetsFS()
+
+func newAssetsFS() *asset.FS {
 	sub, err := fs.Sub(embeddedAssets, "assets")
 	if err != nil {
 		panic(err)
 	}
+	sub, err = asset.Rewrite(sub, "redoc.standalone.js", externalURL, "data:,")
+	if err != nil {
+		panic(err)
+	}
 
-	return http.StripPrefix(cfg.AssetsPath, http.FileServer(http.FS(sub)))
+	fsys, err := asset.Fingerprint(sub)
+	if err != nil {
+		panic(err)
+	}
+
+	return fsys
+}
+
+func newAssetsHandler(cfg *config.SpecUI) http.Handler {
+	return assetsFS.Handler(cfg.AssetsPath)
 }
@@ -0,0 +1,49 @@
+package specui
+
+import (
+	"net/http"
+
+	"github.com/oaswrap/spec-ui/observe"
+)
+
+// Route is an HTTP route served by the handler, for mounting the handler on
+// routers with their own registration functions.
+type Route struct {
+	Path    string            // Path of the route, or prefix of the paths it serves when Prefix is true
+	Prefix  bool              // True when the route serves every path under Path, e.g. the embedded assets
+	Kind    observe.RouteKind // Kind of the route, as reported to the observer
+	Handler http.Handler      // Handler of the GET requests to the route
+}
+
+// Routes returns the routes to mount on a router: the documentation, the
+// specification, the embedded assets when enabled, the changelog page when
+// a previous specification is configured and the versions when a store is
+// configured, see VersionsPath.
+func (h *Handler) Routes() []Route {
+	routes := h.routes()
+	for i, r := range routes {
+		routes[i].Handler = h.observed(r.Kind, r.Handler)
+	}
+	return routes
+}
+
+// routes returns the routes with handlers that are not observed.
+func (h *Handler) routes() []Route {
+	routes := []Route{
+		{Path: h.DocsPath(), Kind: observe.Docs, Handler: h.docs()},
+		{Path: h.SpecPath(), Kind: observe.Spec, Handler: h.specHandler()},
+	}
+	if h.AssetsEnabled() {
+		routes = append(routes, Route{Path: h.AssetsPath(), Prefix: true, Kind: observe.Assets, Handler: h.assetsHandler()})
+	}
+	if h.ChangelogEnabled() {
+		routes = append(routes, Route{Path: h.ChangelogPath(), Kind: observe.Changelog, Handler: h.changelogHandler()})
+	}
+	if h.VersionsEnabled() {
+		routes = append(routes,
+			Route{Path: h.VersionsPath(), Kind: observe.Versions, Handler: h.versionsHandler()},
+			Route{Path: h.VersionsPath(), Prefix: true, Kind: observe.Versions, Handler: h.versionsHandler()},
+		)
+	}
+	return routes
+}
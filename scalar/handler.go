@@ -5,13 +5,18 @@ import (
 	"net/http"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
 	"github.com/oaswrap/spec-ui/internal/constant"
+	"github.com/oaswrap/spec-ui/internal/page"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
 type Handler struct {
 	Data
 
-	tpl *template.Template
+	tpl  *template.Template
+	page *page.Renderer
 }
 
 type Data struct {
@@ -27,27 +32,34 @@ func NewHandler(cfg *config.SpecUI) *Handler {
 			OpenAPIURL: cfg.SpecPath,
 		},
 	}
-	var err error
 
-	assetsBase := constant.ScalarAssetBase
+	assets, err := asset.New(cfg, asset.Scalar, cfg.Scalar.CDN)
+	if err != nil {
+		panic(err)
+	}
+
 	faviconBase := constant.ScalarFaviconBase
 	if cfg.EmbedAssets {
-		assetsBase = cfg.AssetsPath
 		faviconBase = cfg.AssetsPath
 	}
+	if cfg.InlineAssets {
+		faviconBase = ""
+	}
 
-	h.tpl, err = template.New("index").Parse(IndexTpl(assetsBase, faviconBase, cfg.Scalar))
+	inline := spec.NewInline(cfg)
+	h.tpl, err = template.New("index").Funcs(assets.FuncMap()).Funcs(inline.FuncMap()).Funcs(versions.FuncMap(cfg)).Parse(indexTpl(faviconBase, cfg.Scalar))
 	if err != nil {
 		panic(err)
 	}
 
+	// The page does not depend on the request, so it is rendered ahead of
+	// requests, and again when the inlined specification changes or after a
+	// failure, which is reported with an error status.
+	h.page = page.Render(cfg, h.tpl, h, inline.ETag)
+
 	return h
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "text/html")
-
-	if err := h.tpl.Execute(w, h); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	h.page.ServeHTTP(w, r)
 }
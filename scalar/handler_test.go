@@ -1,12 +1,16 @@
 package scalar_test
 
 import (
+	"bytes"
+	"html/template"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/oaswrap/spec-ui/config"
 	"github.com/oaswrap/spec-ui/scalar"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandler(t *testing.T) {
@@ -34,3 +38,33 @@ func TestHandler(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), `documentDownloadType: "both"`)
 	assert.Contains(t, rec.Body.String(), `theme: "moon"`)
 }
+
+func BenchmarkHandler(b *testing.B) {
+	handler := scalar.NewHandler(&config.SpecUI{
+		Title:    "My API",
+		DocsPath: "/docs",
+		SpecPath: "/docs/openapi.json",
+		Scalar:   &config.Scalar{},
+	})
+	for _, encoding := range []string{"identity", "gzip"} {
+		b.Run(encoding, func(b *testing.B) {
+			req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		})
+	}
+}
+
+func TestIndexTpl(t *testing.T) {
+	cfg := &config.SpecUI{Title: "My API", SpecPath: "/docs/openapi.json", Scalar: &config.Scalar{}}
+	tpl, err := template.New("index").Parse(scalar.IndexTpl("https://cdn.example.com/scalar", "", &config.Scalar{}))
+	require.NoError(t, err, "the deprecated template parses without the template functions")
+
+	var buf bytes.Buffer
+	require.NoError(t, tpl.Execute(&buf, scalar.NewHandler(cfg)))
+	assert.Contains(t, buf.String(), `https://cdn.example.com/scalar/browser/standalone.min.js`)
+	assert.NotContains(t, buf.String(), "integrity=")
+}
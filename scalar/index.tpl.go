@@ -6,9 +6,28 @@ import (
 	"strings"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
+// IndexTpl creates the page template, loading the assets from assetBase.
+//
+// Deprecated: The template rendered by the handler links the assets through
+// template functions; IndexTpl returns it rendered with the functions of the
+// handler for assets loaded from the base, for callers parsing it with
+// html/template as is.
 func IndexTpl(assetBase, faviconBase string, cfg *config.Scalar) string {
+	static := &config.SpecUI{}
+	tpl, err := asset.Static(indexTpl(faviconBase, cfg), asset.Local(assetBase).FuncMap(), spec.NewInline(static).FuncMap(), versions.FuncMap(static))
+	if err != nil {
+		panic(err)
+	}
+	return tpl
+}
+
+// indexTpl creates the page template.
+func indexTpl(faviconBase string, cfg *config.Scalar) string {
 	settings := map[string]string{
 		"url":                   "url",
 		"hideModels":            fmt.Sprintf("%t", cfg.HideModels),
@@ -51,12 +70,34 @@ func IndexTpl(assetBase, faviconBase string, cfg *config.Scalar) string {
 	<title>{{.Title}} - Scalar</title>
 	<meta charset="utf-8" />
 	<meta name="viewport" content="width=device-width, initial-scale=1" />
-	<link href="` + assetBase + `/style.min.css" rel="stylesheet">
+{{- if inline }}
+	<style>{{ style "style.css" }}</style>
+{{- else }}
+	<link href="{{ asset "style.css" }}" rel="stylesheet"{{ integrity "style.css" }}>
+{{- end }}
 ` + faviconLink + `
+{{- if embedded }}
+{{- if inline }}
+	<style>{{ style "fonts/fonts.css" }}</style>
+{{- else }}
+	<link href="{{ asset "fonts/fonts.css" }}" rel="stylesheet">
+{{- end }}
+	<style>
+		:root {
+			--scalar-font: "Open Sans", sans-serif;
+			--scalar-font-code: "Source Code Pro", monospace;
+		}
+	</style>
+{{- end }}
 </head>
 <body>
+{{- versions }}
 <div id="app"></div>
-<script src="` + assetBase + `/browser/standalone.min.js"></script>
+{{- if inline }}
+<script>{{ script "browser/standalone.min.js" }}</script>
+{{- else }}
+<script src="{{ asset "browser/standalone.min.js" }}"{{ integrity "browser/standalone.min.js" }}></script>
+{{- end }}
 <script>
 	window.onload = function () {
 		var url = "{{ .OpenAPIURL }}";
@@ -69,9 +110,17 @@ func IndexTpl(assetBase, faviconBase string, cfg *config.Scalar) string {
 				url = window.location.protocol + "//" + window.location.host + url;
 			}
 		}
-		Scalar.createApiReference('#app', {
+		const config = {
 ` + strings.Join(settingsStr, ",\n") + `
-		})
+		}
+{{- if embedded }}
+		config.withDefaultFonts = false
+{{- end }}
+{{- if inlineSpec }}
+		delete config.url
+		config.content = {{ spec }}
+{{- end }}
+		Scalar.createApiReference('#app', config)
 	}
 </script>
 </body>
@@ -42,7 +42,7 @@ func TestNewHandlerEmbedAssets(t *testing.T) {
 	handler.ServeHTTP(rec, req)
 
 	assert.Equal(t, 200, rec.Code)
-	assert.Contains(t, rec.Body.String(), "/docs/_assets/style.min.css")
+	assert.Contains(t, rec.Body.String(), "/docs/_assets/style.css")
 }
 
 func TestIndexTplHideDeveloperTools(t *testing.T) {
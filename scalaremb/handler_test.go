@@ -1,11 +1,15 @@
 package scalaremb
 
 import (
+	"io/fs"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/constant"
+	"github.com/oaswrap/spec-ui/internal/providertest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWithUI(t *testing.T) {
@@ -47,7 +51,7 @@ func TestHandlerAndAssets(t *testing.T) {
 	assets := newAssetsHandler(cfg)
 	assert.NotNil(t, assets)
 
-	assetsReq := httptest.NewRequest("GET", "/docs/_assets/style.min.css", nil)
+	assetsReq := httptest.NewRequest("GET", "/docs/_assets/style.css", nil)
 	assetsRec := httptest.NewRecorder()
 	assets.ServeHTTP(assetsRec, assetsReq)
 
@@ -60,3 +64,15 @@ func TestHandlerAndAssets(t *testing.T) {
 
 	assert.Equal(t, 200, faviconRec.Code)
 }
+
+func TestAssets(t *testing.T) {
+	assets, err := fs.Sub(embeddedAssets, "assets")
+	require.NoError(t, err)
+
+	providertest.Assets(t, providertest.Provider{
+		WithUI:    WithUI(),
+		Assets:    assets,
+		Integrity: constant.ScalarIntegrity,
+		Script:    "browser/standalone.min.js",
+	})
+}
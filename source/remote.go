@@ -0,0 +1,132 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/oaswrap/spec-ui/config"
+)
+
+// DefaultTimeout is the timeout of a fetch of a Remote source without one.
+const DefaultTimeout = 10 * time.Second
+
+// Remote loads the specification from an HTTP URL. It revalidates the last
+// copy fetched with its ETag, and keeps serving that copy when a fetch fails,
+// so that an outage of the server does not take the docs down once the
+// specification was fetched. The fields must not be changed after the first
+// Load.
+type Remote struct {
+	URL     string        // URL of the specification
+	Client  *http.Client  // Client of the requests, http.DefaultClient when nil
+	Timeout time.Duration // Timeout of a fetch, DefaultTimeout when zero
+	Refresh time.Duration // How often the handler fetches the specification again, never when zero
+	Header  http.Header   // Extra request headers, e.g. Authorization
+
+	mu      sync.Mutex
+	body    []byte // Last copy fetched
+	etag    string
+	format  string
+	version string
+	err     error // Error of the last fetch
+}
+
+var (
+	_ config.SpecSource = (*Remote)(nil)
+	_ Refresher         = (*Remote)(nil)
+)
+
+// NewRemote returns a source fetching the specification at rawURL.
+func NewRemote(rawURL string) *Remote {
+	return &Remote{URL: rawURL}
+}
+
+// Load fetches the specification. It returns the last copy fetched when the
+// server answers 304 Not Modified, or when the fetch fails after an earlier
+// one succeeded; Err then reports the failure.
+func (s *Remote) Load(ctx context.Context) ([]byte, string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := s.fetch(ctx)
+	s.err = err
+	switch {
+	case err == nil && b != nil:
+		s.body = b
+		s.format, s.version = Detect(b)
+	case err != nil && s.body == nil:
+		return nil, "", "", err
+	}
+	return s.body, s.format, s.version, nil
+}
+
+// fetch requests the specification, returning a nil body when it did not
+// change since the last copy.
+func (s *Remote) fetch(ctx context.Context) ([]byte, error) {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range s.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json, application/yaml;q=0.9, */*;q=0.8")
+	if s.body != nil && s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && s.body != nil:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GET %s: %s", s.String(), resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	s.etag = resp.Header.Get("ETag")
+	return b, nil
+}
+
+// Err returns the error of the last fetch, or nil when it succeeded.
+func (s *Remote) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// RefreshInterval returns Refresh.
+func (s *Remote) RefreshInterval() time.Duration {
+	return s.Refresh
+}
+
+// String returns the URL, with the password of its user info redacted.
+func (s *Remote) String() string {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return s.URL
+	}
+	return u.Redacted()
+}
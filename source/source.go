@@ -0,0 +1,140 @@
+// Package source provides the sources the OpenAPI specification is loaded
+// from, see specui.WithSpecSource.
+//
+// The WithSpecFile, WithSpecIOFS, WithSpecEmbedFS, WithSpecGenerator and
+// WithSpecURL options configure the File, FS, Generator and Remote sources of
+// this package. Any other loader can be plugged in with Func or by
+// implementing config.SpecSource.
+package source
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/oaswrap/spec-ui/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Specification formats.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Refresher is implemented by sources whose specification changes over time,
+// such as Remote. The handler loads the specification again when it is older
+// than the refresh interval; a zero interval means never.
+type Refresher interface {
+	RefreshInterval() time.Duration
+}
+
+type formatKey struct{}
+
+// WithFormat returns a copy of ctx asking sources that can produce either
+// format, such as Generator, for the given one. The handler asks for the
+// format of its spec path.
+func WithFormat(ctx context.Context, format string) context.Context {
+	return context.WithValue(ctx, formatKey{}, format)
+}
+
+// FormatFrom returns the format asked for with WithFormat, or "" when none is.
+func FormatFrom(ctx context.Context) string {
+	format, _ := ctx.Value(formatKey{}).(string)
+	return format
+}
+
+// Detect returns the format of b, "json" or "yaml", and the value of its
+// "openapi" field, or of its "swagger" field for a Swagger 2.0 document. The
+// version is empty when b cannot be parsed or has neither field.
+func Detect(b []byte) (format, version string) {
+	format = FormatYAML
+	if t := bytes.TrimLeft(b, " \t\r\n\ufeff"); len(t) > 0 && (t[0] == '{' || t[0] == '[') {
+		format = FormatJSON
+	}
+	var doc struct {
+		OpenAPI string `yaml:"openapi"`
+		Swagger string `yaml:"swagger"`
+	}
+	if yaml.Unmarshal(b, &doc) == nil {
+		version = doc.OpenAPI
+		if version == "" {
+			version = doc.Swagger
+		}
+	}
+	return format, version
+}
+
+// File loads the specification from a file of the OS filesystem.
+type File struct {
+	Path string // Path of the file
+}
+
+var _ config.SpecSource = File{}
+
+func (s File) Load(context.Context) ([]byte, string, string, error) {
+	return detected(os.ReadFile(s.Path))
+}
+
+func (s File) String() string {
+	return s.Path
+}
+
+// FS loads the specification from a file of a filesystem, such as an
+// embed.FS.
+type FS struct {
+	FS   fs.FS  // Filesystem holding the file
+	Path string // Path of the file in FS
+}
+
+var _ config.SpecSource = FS{}
+
+func (s FS) Load(context.Context) ([]byte, string, string, error) {
+	return detected(fs.ReadFile(s.FS, s.Path))
+}
+
+func (s FS) String() string {
+	return s.Path
+}
+
+// Generator loads the specification from a generator, marshaled in the
+// format asked for with WithFormat, JSON by default.
+type Generator struct {
+	Generator config.SpecGenerator
+}
+
+var _ config.SpecSource = Generator{}
+
+func (s Generator) Load(ctx context.Context) ([]byte, string, string, error) {
+	if FormatFrom(ctx) == FormatYAML {
+		return detected(s.Generator.MarshalYAML())
+	}
+	return detected(s.Generator.MarshalJSON())
+}
+
+func (s Generator) String() string {
+	return "generator"
+}
+
+// Func adapts a function to a source.
+type Func func(ctx context.Context) (b []byte, format, version string, err error)
+
+var _ config.SpecSource = Func(nil)
+
+func (f Func) Load(ctx context.Context) ([]byte, string, string, error) {
+	return f(ctx)
+}
+
+func (f Func) String() string {
+	return "func"
+}
+
+func detected(b []byte, err error) ([]byte, string, string, error) {
+	if err != nil {
+		return nil, "", "", err
+	}
+	format, version := Detect(b)
+	return b, format, version, nil
+}
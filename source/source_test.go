@@ -0,0 +1,102 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		format  string
+		version string
+	}{
+		{name: "when the document is JSON", body: `{"openapi": "3.1.0"}`, format: source.FormatJSON, version: "3.1.0"},
+		{name: "when the document is YAML", body: "openapi: 3.0.3\ninfo: {}\n", format: source.FormatYAML, version: "3.0.3"},
+		{name: "when the document is Swagger 2.0", body: "\n  {\"swagger\": \"2.0\"}", format: source.FormatJSON, version: "2.0"},
+		{name: "when the document has no version", body: "info: {}\n", format: source.FormatYAML},
+		{name: "when the document cannot be parsed", body: "{", format: source.FormatJSON},
+		{name: "when the document is empty", format: source.FormatYAML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, version := source.Detect([]byte(tt.body))
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	t.Run("when the file exists", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "openapi.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("openapi: 3.1.0\n"), 0o600))
+
+		src := source.File{Path: path}
+		b, format, version, err := src.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "openapi: 3.1.0\n", string(b))
+		assert.Equal(t, source.FormatYAML, format)
+		assert.Equal(t, "3.1.0", version)
+		assert.Equal(t, path, src.String())
+	})
+	t.Run("when the file is missing", func(t *testing.T) {
+		_, _, _, err := source.File{Path: filepath.Join(t.TempDir(), "missing.yaml")}.Load(context.Background())
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestFS(t *testing.T) {
+	fsys := fstest.MapFS{"api/openapi.json": {Data: []byte(`{"openapi":"3.0.0"}`)}}
+
+	b, format, version, err := source.FS{FS: fsys, Path: "api/openapi.json"}.Load(context.Background())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"openapi":"3.0.0"}`, string(b))
+	assert.Equal(t, source.FormatJSON, format)
+	assert.Equal(t, "3.0.0", version)
+}
+
+type generator struct{}
+
+func (generator) MarshalJSON() ([]byte, error) { return []byte(`{"openapi":"3.1.0"}`), nil }
+func (generator) MarshalYAML() ([]byte, error) { return []byte("openapi: 3.1.0\n"), nil }
+
+func TestGenerator(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		format string
+	}{
+		{name: "when no format is asked for", ctx: context.Background(), format: source.FormatJSON},
+		{name: "when JSON is asked for", ctx: source.WithFormat(context.Background(), source.FormatJSON), format: source.FormatJSON},
+		{name: "when YAML is asked for", ctx: source.WithFormat(context.Background(), source.FormatYAML), format: source.FormatYAML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, format, version, err := source.Generator{Generator: generator{}}.Load(tt.ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, "3.1.0", version)
+		})
+	}
+}
+
+func TestFunc(t *testing.T) {
+	errLoad := errors.New("load failed")
+	src := source.Func(func(ctx context.Context) ([]byte, string, string, error) {
+		return nil, "", "", errLoad
+	})
+
+	_, _, _, err := src.Load(context.Background())
+	assert.ErrorIs(t, err, errLoad)
+	assert.Equal(t, "func", src.String())
+}
@@ -6,7 +6,11 @@ import (
 	"net/http"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
 	"github.com/oaswrap/spec-ui/internal/constant"
+	"github.com/oaswrap/spec-ui/internal/page"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
 // Handler handles swagger UI request.
@@ -15,7 +19,8 @@ type Handler struct {
 
 	ConfigJson template.JS
 
-	tpl *template.Template
+	tpl  *template.Template
+	page *page.Renderer
 }
 
 type Data struct {
@@ -53,25 +58,33 @@ func NewHandler(cfg *config.SpecUI) *Handler {
 
 	h.ConfigJson = template.JS(j) //nolint:gosec // Data is well formed.
 
-	assetsBase := constant.StoplightElementsAssetsBase
+	assets, err := asset.New(cfg, asset.StoplightElements, cfg.StoplightElements.CDN)
+	if err != nil {
+		panic(err)
+	}
+
 	faviconBase := constant.StoplightElementFaviconBase
 	if cfg.EmbedAssets {
-		assetsBase = cfg.AssetsPath
 		faviconBase = cfg.AssetsPath
 	}
+	if cfg.InlineAssets {
+		faviconBase = ""
+	}
 
-	h.tpl, err = template.New("index").Parse(IndexTpl(assetsBase, faviconBase, cfg))
+	inline := spec.NewInline(cfg)
+	h.tpl, err = template.New("index").Funcs(assets.FuncMap()).Funcs(inline.FuncMap()).Funcs(versions.FuncMap(cfg)).Parse(indexTpl(faviconBase, cfg))
 	if err != nil {
 		panic(err)
 	}
 
+	// The page does not depend on the request, so it is rendered ahead of
+	// requests, and again when the inlined specification changes or after a
+	// failure, which is reported with an error status.
+	h.page = page.Render(cfg, h.tpl, h, inline.ETag)
+
 	return h
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "text/html")
-
-	if err := h.tpl.Execute(w, h); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	h.page.ServeHTTP(w, r)
 }
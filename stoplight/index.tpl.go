@@ -6,9 +6,28 @@ import (
 	"strings"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
+// IndexTpl creates the page template, loading the assets from assetBase.
+//
+// Deprecated: The template rendered by the handler links the assets through
+// template functions; IndexTpl returns it rendered with the functions of the
+// handler for assets loaded from the base, for callers parsing it with
+// html/template as is.
 func IndexTpl(assetBase, faviconBase string, specCfg *config.SpecUI) string {
+	static := &config.SpecUI{}
+	tpl, err := asset.Static(indexTpl(faviconBase, specCfg), asset.Local(assetBase).FuncMap(), spec.NewInline(static).FuncMap(), versions.FuncMap(static))
+	if err != nil {
+		panic(err)
+	}
+	return tpl
+}
+
+// indexTpl creates the page template.
+func indexTpl(faviconBase string, specCfg *config.SpecUI) string {
 	settings := map[string]string{}
 	cfg := specCfg.StoplightElements
 	addSetting := func(key, val string) {
@@ -46,7 +65,11 @@ func IndexTpl(assetBase, faviconBase string, specCfg *config.SpecUI) string {
     <meta charset="utf-8">
     <meta name="viewport" content="width=device-width, initial-scale=1, shrink-to-fit=no">
     <title>{{ .Title }} - Stoplight Elements</title>
-    <link rel="stylesheet" href="` + assetBase + `/styles.min.css">
+{{- if inline }}
+    <style>{{ style "styles.min.css" }}</style>
+{{- else }}
+    <link rel="stylesheet" href="{{ asset "styles.min.css" }}"{{ integrity "styles.min.css" }}>
+{{- end }}
 ` + faviconLink + `
     <style>
         html, body {
@@ -56,11 +79,16 @@ func IndexTpl(assetBase, faviconBase string, specCfg *config.SpecUI) string {
     </style>
 </head>
 <body>
+{{- versions }}
 <elements-api
     id="docs"
 ` + strings.Join(settingsStr, ",\n") + `
 ></elements-api>
-<script src="` + assetBase + `/web-components.min.js"></script>
+{{- if inline }}
+<script>{{ script "web-components.min.js" }}</script>
+{{- else }}
+<script src="{{ asset "web-components.min.js" }}"{{ integrity "web-components.min.js" }}></script>
+{{- end }}
 <script>
     window.onload = function () {
         (async () => {
@@ -77,7 +105,11 @@ func IndexTpl(assetBase, faviconBase string, specCfg *config.SpecUI) string {
             }
 
             const docs = document.getElementById('docs');
+{{- if inlineSpec }}
+            const text = {{ spec }};
+{{- else }}
             const text = await fetch(url).then(res => res.text())
+{{- end }}
 
             docs.apiDescriptionDocument = text;
             docs.hideTryIt = cfg.hideTryIt;
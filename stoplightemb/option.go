@@ -13,6 +13,7 @@ func WithUI(cfg ...config.StoplightElements) specui.Option {
 	return func(c *config.SpecUI) {
 		c.Provider = config.ProviderStoplightElements
 		c.EmbedAssets = true
+		c.AssetsFS = assetsFS
 		c.DocsHandlerFactory = func(c *config.SpecUI) http.Handler {
 			return newHandler(c)
 		}
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/config"
+)
+
+// extensions are the file extensions of the versions of a Dir, in the order
+// they are looked up.
+var extensions = []string{".json", ".yaml", ".yml"}
+
+// Dir is a store reading the versions from the files of a directory, named
+// after them, e.g. 1.0.0.yaml and 2.0.0.json. The latest version is the
+// highest, comparing the numbers of the versions, so that "1.10.0" comes after
+// "1.9.0" and "2.0.0-beta" before "2.0.0". Files are read on each call, so
+// versions can be published by adding files.
+type Dir struct {
+	Path string // Path of the directory, the current or root directory when empty
+	FS   fs.FS  // Filesystem holding Path, the OS filesystem when nil
+}
+
+var _ config.SpecStore = Dir{}
+
+// List returns the versions, the highest first.
+func (d Dir) List(context.Context) ([]string, error) {
+	entries, err := d.readDir()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(entries))
+	versions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ext := path.Ext(e.Name())
+		version := strings.TrimSuffix(e.Name(), ext)
+		if e.IsDir() || !isSpecExt(ext) || !ValidVersion(version) || seen[version] {
+			continue
+		}
+		seen[version] = true
+		versions = append(versions, version)
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compare(versions[i], versions[j]) > 0
+	})
+	return versions, nil
+}
+
+// Get returns the specification of version, read from its file.
+func (d Dir) Get(_ context.Context, version string) ([]byte, error) {
+	if !ValidVersion(version) {
+		return nil, fmt.Errorf("%s: %w", version, ErrNotFound)
+	}
+	for _, ext := range extensions {
+		b, err := d.readFile(version + ext)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		return b, err
+	}
+	return nil, fmt.Errorf("%s: %w", version, ErrNotFound)
+}
+
+// Latest returns the highest version.
+func (d Dir) Latest(ctx context.Context) (string, error) {
+	versions, err := d.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", ErrNotFound
+	}
+	return versions[0], nil
+}
+
+func (d Dir) readDir() ([]fs.DirEntry, error) {
+	dir := d.Path
+	if dir == "" {
+		dir = "."
+	}
+	if d.FS == nil {
+		return os.ReadDir(dir)
+	}
+	return fs.ReadDir(d.FS, dir)
+}
+
+func (d Dir) readFile(name string) ([]byte, error) {
+	if d.FS == nil {
+		return os.ReadFile(filepath.Join(d.Path, name))
+	}
+	return fs.ReadFile(d.FS, path.Join(d.Path, name))
+}
+
+func isSpecExt(ext string) bool {
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package store_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDir(t *testing.T) {
+	ctx := context.Background()
+	fsys := fstest.MapFS{
+		"specs/1.9.0.yaml":         {Data: []byte("openapi: 3.0.3\n")},
+		"specs/1.10.0.json":        {Data: []byte(`{"openapi":"3.1.0"}`)},
+		"specs/2.0.0-beta.yml":     {Data: []byte("openapi: 3.1.0\n")},
+		"specs/v0.9.yaml":          {Data: []byte("openapi: 3.0.0\n")},
+		"specs/README.md":          {Data: []byte("# Specs")},
+		"specs/archive/3.0.0.json": {Data: []byte(`{}`)},
+	}
+	s := store.Dir{FS: fsys, Path: "specs"}
+
+	t.Run("when listing the versions", func(t *testing.T) {
+		versions, err := s.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2.0.0-beta", "1.10.0", "1.9.0", "v0.9"}, versions)
+		latest, err := s.Latest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-beta", latest)
+	})
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{name: "when the version is a JSON file", version: "1.10.0", want: `{"openapi":"3.1.0"}`},
+		{name: "when the version is a YAML file", version: "1.9.0", want: "openapi: 3.0.3\n"},
+		{name: "when the version is a YML file", version: "2.0.0-beta", want: "openapi: 3.1.0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := s.Get(ctx, tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+		})
+	}
+
+	t.Run("when the version is missing", func(t *testing.T) {
+		for _, version := range []string{"3.0.0", "README", "../specs/1.9.0", ""} {
+			_, err := s.Get(ctx, version)
+			assert.ErrorIs(t, err, store.ErrNotFound, version)
+		}
+	})
+
+	t.Run("when the directory is on the OS filesystem", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "1.0.0.json"), []byte(`{"openapi":"3.1.0"}`), 0o600))
+
+		s := store.Dir{Path: dir}
+		latest, err := s.Latest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest)
+		b, err := s.Get(ctx, "1.0.0")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"openapi":"3.1.0"}`, string(b))
+	})
+
+	t.Run("when the directory is missing", func(t *testing.T) {
+		_, err := store.Dir{Path: filepath.Join(t.TempDir(), "missing")}.List(ctx)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/oaswrap/spec-ui/config"
+)
+
+// Memory is a store holding the versions in memory. The latest version is
+// the last one added. The zero value is an empty store ready to use.
+type Memory struct {
+	mu       sync.RWMutex
+	versions []string // Versions in the order they were added
+	specs    map[string][]byte
+}
+
+var _ config.SpecStore = (*Memory)(nil)
+
+// Put adds the specification b as version, or replaces the specification of
+// an existing version without changing its position. It fails when version is
+// not valid, see ValidVersion.
+func (m *Memory) Put(version string, b []byte) error {
+	if !ValidVersion(version) {
+		return fmt.Errorf("invalid version %q", version)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.specs == nil {
+		m.specs = make(map[string][]byte)
+	}
+	if _, ok := m.specs[version]; !ok {
+		m.versions = append(m.versions, version)
+	}
+	m.specs[version] = b
+	return nil
+}
+
+// List returns the versions, the last one added first.
+func (m *Memory) List(context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	versions := make([]string, 0, len(m.versions))
+	for i := len(m.versions) - 1; i >= 0; i-- {
+		versions = append(versions, m.versions[i])
+	}
+	return versions, nil
+}
+
+// Get returns the specification of version.
+func (m *Memory) Get(_ context.Context, version string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	b, ok := m.specs[version]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", version, ErrNotFound)
+	}
+	return b, nil
+}
+
+// Latest returns the last version added.
+func (m *Memory) Latest(context.Context) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.versions) == 0 {
+		return "", ErrNotFound
+	}
+	return m.versions[len(m.versions)-1], nil
+}
@@ -0,0 +1,46 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when the store is empty", func(t *testing.T) {
+		s := &store.Memory{}
+		versions, err := s.List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+		_, err = s.Latest(ctx)
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+
+	t.Run("when versions are added", func(t *testing.T) {
+		s := &store.Memory{}
+		require.NoError(t, s.Put("1.0.0", []byte("v1")))
+		require.NoError(t, s.Put("2.0.0", []byte("v2")))
+		require.NoError(t, s.Put("1.0.0", []byte("v1 fixed")))
+
+		versions, err := s.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2.0.0", "1.0.0"}, versions, "replacing a version keeps its position")
+		latest, err := s.Latest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", latest)
+		b, err := s.Get(ctx, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "v1 fixed", string(b))
+		_, err = s.Get(ctx, "3.0.0")
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+
+	t.Run("when the version is invalid", func(t *testing.T) {
+		assert.Error(t, (&store.Memory{}).Put("../1.0.0", []byte("v1")))
+	})
+}
@@ -0,0 +1,129 @@
+// Package store provides stores of the published versions of the OpenAPI
+// specification, see specui.WithSpecStore.
+//
+// Memory holds the versions in memory, e.g. as loaded from a database at
+// startup, and Dir reads them from the files of a directory. Other stores,
+// such as a table keyed by version, implement config.SpecStore.
+package store
+
+import (
+	"context"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/source"
+)
+
+// ErrNotFound is returned by stores for versions they do not hold. It matches
+// fs.ErrNotExist with errors.Is.
+var ErrNotFound error = notFound{}
+
+type notFound struct{}
+
+func (notFound) Error() string {
+	return "version not found"
+}
+
+func (notFound) Is(target error) bool {
+	return target == fs.ErrNotExist
+}
+
+// ValidVersion reports whether v can name a version: it is served in URL
+// paths and file names, so it must be made of letters, digits, '.', '-', '_'
+// and '+', and must not be "." or "..".
+func ValidVersion(v string) bool {
+	if v == "" || v == "." || v == ".." {
+		return false
+	}
+	for _, c := range v {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '.', c == '-', c == '_', c == '+':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Source loads a version of the specification from a store.
+type Source struct {
+	Store   config.SpecStore // Store holding the version
+	Version string           // Version to load, the latest when empty
+	Refresh time.Duration    // How often the handler loads the latest version again, never when zero
+}
+
+var (
+	_ config.SpecSource = Source{}
+	_ source.Refresher  = Source{}
+)
+
+func (s Source) Load(ctx context.Context) ([]byte, string, string, error) {
+	version := s.Version
+	if version == "" {
+		var err error
+		if version, err = s.Store.Latest(ctx); err != nil {
+			return nil, "", "", err
+		}
+	}
+	b, err := s.Store.Get(ctx, version)
+	if err != nil {
+		return nil, "", "", err
+	}
+	format, openapi := source.Detect(b)
+	return b, format, openapi, nil
+}
+
+// RefreshInterval returns Refresh.
+func (s Source) RefreshInterval() time.Duration {
+	return s.Refresh
+}
+
+func (s Source) String() string {
+	if s.Version == "" {
+		return "store"
+	}
+	return "store@" + s.Version
+}
+
+// compare orders versions such as "v1.2.0" and "1.10.0" by their
+// dot-separated numbers, a pre-release such as "2.0.0-beta" coming before the
+// release. Parts that are not numbers are compared as strings.
+func compare(a, b string) int {
+	a, preA, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(a, "v"), "V"), "-")
+	b, preB, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(b, "v"), "V"), "-")
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if c := comparePart(partsA[i], partsB[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(partsA) != len(partsB):
+		return len(partsA) - len(partsB)
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return strings.Compare(preA, preB)
+}
+
+func comparePart(a, b string) int {
+	x, errA := strconv.ParseUint(a, 10, 64)
+	y, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA != nil || errB != nil:
+		return strings.Compare(a, b)
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
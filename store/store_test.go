@@ -0,0 +1,71 @@
+package store_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/oaswrap/spec-ui/source"
+	"github.com/oaswrap/spec-ui/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.0.0", true},
+		{"v2.1.0-beta.1+build_7", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"1.0/../2.0", false},
+		{"1.0 final", false},
+		{"1.0%2F2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, store.ValidVersion(tt.version))
+		})
+	}
+}
+
+func TestErrNotFound(t *testing.T) {
+	assert.ErrorIs(t, store.ErrNotFound, fs.ErrNotExist)
+}
+
+func TestSource(t *testing.T) {
+	s := &store.Memory{}
+	require.NoError(t, s.Put("1.0.0", []byte("openapi: 3.0.3\n")))
+	require.NoError(t, s.Put("2.0.0", []byte(`{"openapi":"3.1.0"}`)))
+
+	t.Run("when the version is set", func(t *testing.T) {
+		src := store.Source{Store: s, Version: "1.0.0"}
+		b, format, version, err := src.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "openapi: 3.0.3\n", string(b))
+		assert.Equal(t, source.FormatYAML, format)
+		assert.Equal(t, "3.0.3", version)
+		assert.Equal(t, "store@1.0.0", src.String())
+	})
+	t.Run("when the version is not set", func(t *testing.T) {
+		src := store.Source{Store: s, Refresh: time.Minute}
+		_, format, version, err := src.Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, source.FormatJSON, format)
+		assert.Equal(t, "3.1.0", version)
+		assert.Equal(t, "store", src.String())
+		assert.Equal(t, time.Minute, src.RefreshInterval())
+	})
+	t.Run("when the version is missing", func(t *testing.T) {
+		_, _, _, err := store.Source{Store: s, Version: "3.0.0"}.Load(context.Background())
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+	t.Run("when the store is empty", func(t *testing.T) {
+		_, _, _, err := store.Source{Store: &store.Memory{}}.Load(context.Background())
+		assert.ErrorIs(t, err, store.ErrNotFound)
+	})
+}
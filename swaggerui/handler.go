@@ -6,7 +6,11 @@ import (
 	"net/http"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
 	"github.com/oaswrap/spec-ui/internal/constant"
+	"github.com/oaswrap/spec-ui/internal/page"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
 // Handler handles swagger UI request.
@@ -15,7 +19,8 @@ type Handler struct {
 
 	ConfigJson template.JS
 
-	tpl *template.Template
+	tpl  *template.Template
+	page *page.Renderer
 }
 
 type Data struct {
@@ -45,26 +50,34 @@ func NewHandler(config *config.SpecUI) *Handler {
 
 	h.ConfigJson = template.JS(j) //nolint:gosec // Data is well formed.
 
-	assetsBase := constant.SwaggerUIAssetsBase
+	assets, err := asset.New(config, asset.SwaggerUI, config.SwaggerUI.CDN)
+	if err != nil {
+		panic(err)
+	}
+
 	faviconBase := constant.SwaggerUIFaviconBase
 	if config.EmbedAssets {
-		assetsBase = config.AssetsPath
 		faviconBase = config.AssetsPath
 	}
+	if config.InlineAssets {
+		faviconBase = ""
+	}
 
-	h.tpl, err = template.New("index").Parse(IndexTpl(assetsBase, faviconBase, config.SwaggerUI))
+	inline := spec.NewInline(config)
+	h.tpl, err = template.New("index").Funcs(assets.FuncMap()).Funcs(inline.FuncMap()).Funcs(versions.FuncMap(config)).Parse(indexTpl(faviconBase, config.SwaggerUI))
 	if err != nil {
 		panic(err)
 	}
 
+	// The page does not depend on the request, so it is rendered ahead of
+	// requests, and again when the inlined specification changes or after a
+	// failure, which is reported with an error status.
+	h.page = page.Render(config, h.tpl, h, inline.ETag)
+
 	return h
 }
 
 // ServeHTTP implements http.Handler interface to handle swagger UI request.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "text/html")
-
-	if err := h.tpl.Execute(w, h); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	h.page.ServeHTTP(w, r)
 }
@@ -1,12 +1,16 @@
 package swaggerui_test
 
 import (
+	"bytes"
+	"html/template"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/oaswrap/spec-ui/config"
 	"github.com/oaswrap/spec-ui/swaggerui"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandler(t *testing.T) {
@@ -30,3 +34,33 @@ func TestHandler(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "My API")
 	assert.Contains(t, rec.Body.String(), "Swagger UI")
 }
+
+func BenchmarkHandler(b *testing.B) {
+	handler := swaggerui.NewHandler(&config.SpecUI{
+		Title:     "My API",
+		DocsPath:  "/docs",
+		SpecPath:  "/docs/openapi.json",
+		SwaggerUI: &config.SwaggerUI{},
+	})
+	for _, encoding := range []string{"identity", "gzip"} {
+		b.Run(encoding, func(b *testing.B) {
+			req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		})
+	}
+}
+
+func TestIndexTpl(t *testing.T) {
+	cfg := &config.SpecUI{Title: "My API", SpecPath: "/docs/openapi.json", SwaggerUI: &config.SwaggerUI{}}
+	tpl, err := template.New("index").Parse(swaggerui.IndexTpl("https://cdn.example.com/swagger-ui", "", &config.SwaggerUI{}))
+	require.NoError(t, err, "the deprecated template parses without the template functions")
+
+	var buf bytes.Buffer
+	require.NoError(t, tpl.Execute(&buf, swaggerui.NewHandler(cfg)))
+	assert.Contains(t, buf.String(), `https://cdn.example.com/swagger-ui/swagger-ui-bundle.js`)
+	assert.NotContains(t, buf.String(), "integrity=")
+}
@@ -6,12 +6,30 @@ import (
 	"strings"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/internal/versions"
 )
 
-// IndexTpl creates page template.
+// IndexTpl creates the page template, loading the assets from assetsBase.
 //
-//nolint:funlen // The template is long.
+// Deprecated: The template rendered by the handler links the assets through
+// template functions; IndexTpl returns it rendered with the functions of the
+// handler for assets loaded from the base, for callers parsing it with
+// html/template as is.
 func IndexTpl(assetsBase, faviconBase string, cfg *config.SwaggerUI) string {
+	static := &config.SpecUI{}
+	tpl, err := asset.Static(indexTpl(faviconBase, cfg), asset.Local(assetsBase).FuncMap(), spec.NewInline(static).FuncMap(), versions.FuncMap(static))
+	if err != nil {
+		panic(err)
+	}
+	return tpl
+}
+
+// indexTpl creates the page template.
+//
+//nolint:funlen // The template is long.
+func indexTpl(faviconBase string, cfg *config.SwaggerUI) string {
 	settings := map[string]string{
 		"url":         "url",
 		"dom_id":      "'#swagger-ui'",
@@ -54,7 +72,11 @@ func IndexTpl(assetsBase, faviconBase string, cfg *config.SwaggerUI) string {
 <head>
     <meta charset="UTF-8">
     <title>{{ .Title }} - Swagger UI</title>
-    <link rel="stylesheet" type="text/css" href="` + assetsBase + `/swagger-ui.min.css">
+{{- if inline }}
+    <style>{{ style "swagger-ui.css" }}</style>
+{{- else }}
+    <link rel="stylesheet" type="text/css" href="{{ asset "swagger-ui.css" }}"{{ integrity "swagger-ui.css" }}>
+{{- end }}
 ` + faviconLinks + `
     <style>
         html {
@@ -76,9 +98,15 @@ func IndexTpl(assetsBase, faviconBase string, cfg *config.SwaggerUI) string {
     </style>
 </head>
 <body>
+{{- versions }}
 <div id="swagger-ui"></div>
-<script src="` + assetsBase + `/swagger-ui-bundle.js"></script>
-<script src="` + assetsBase + `/swagger-ui-standalone-preset.js"></script>
+{{- if inline }}
+<script>{{ script "swagger-ui-bundle.js" }}</script>
+<script>{{ script "swagger-ui-standalone-preset.js" }}</script>
+{{- else }}
+<script src="{{ asset "swagger-ui-bundle.js" }}"{{ integrity "swagger-ui-bundle.js" }}></script>
+<script src="{{ asset "swagger-ui-standalone-preset.js" }}"{{ integrity "swagger-ui-standalone-preset.js" }}></script>
+{{- end }}
 <script>
     window.onload = function () {
         const cfg = {{ .ConfigJson }};
@@ -97,6 +125,10 @@ func IndexTpl(assetsBase, faviconBase string, cfg *config.SwaggerUI) string {
         var settings = {
 ` + strings.Join(settingsStr, ",\n") + `
         };
+{{- if inlineSpec }}
+        delete settings.url;
+        settings.spec = {{ spec }};
+{{- end }}
 
         if (cfg.hideCurl) {
             settings.plugins.push(() => {return {wrapComponents: {curl: () => () => null}}});
@@ -44,5 +44,5 @@ func TestNewHandlerEmbedAssets(t *testing.T) {
 	handler.ServeHTTP(rec, req)
 
 	assert.Equal(t, 200, rec.Code)
-	assert.Contains(t, rec.Body.String(), "/docs/_assets/swagger-ui.min.css")
+	assert.Contains(t, rec.Body.String(), "/docs/_assets/swagger-ui.css")
 }
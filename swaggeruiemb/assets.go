@@ -6,16 +6,30 @@ import (
 	"net/http"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/asset"
 )
 
 //go:embed assets
 var embeddedAssets embed.FS
 
-func newAssetsHandler(cfg *config.SpecUI) http.Handler {
+// assetsFS holds the embedded assets, fingerprinted at init so that pages can
+// reference long-cached URLs.
+var assetsFS = newAssetsFS()
+
+func newAssetsFS() *asset.FS {
 	sub, err := fs.Sub(embeddedAssets, "assets")
 	if err != nil {
 		panic(err)
 	}
 
-	return http.StripPrefix(cfg.AssetsPath, http.FileServer(http.FS(sub)))
+	fsys, err := asset.Fingerprint(sub)
+	if err != nil {
+		panic(err)
+	}
+
+	return fsys
+}
+
+func newAssetsHandler(cfg *config.SpecUI) http.Handler {
+	return assetsFS.Handler(cfg.AssetsPath)
 }
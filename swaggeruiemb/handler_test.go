@@ -1,11 +1,15 @@
 package swaggeruiemb
 
 import (
+	"io/fs"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/constant"
+	"github.com/oaswrap/spec-ui/internal/providertest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWithUI(t *testing.T) {
@@ -49,10 +53,22 @@ func TestHandlerAndAssets(t *testing.T) {
 	assets := newAssetsHandler(cfg)
 	assert.NotNil(t, assets)
 
-	assetsReq := httptest.NewRequest("GET", "/docs/_assets/swagger-ui.min.css", nil)
+	assetsReq := httptest.NewRequest("GET", "/docs/_assets/swagger-ui.css", nil)
 	assetsRec := httptest.NewRecorder()
 	assets.ServeHTTP(assetsRec, assetsReq)
 
 	assert.Equal(t, 200, assetsRec.Code)
 	assert.NotEmpty(t, assetsRec.Body.String())
 }
+
+func TestAssets(t *testing.T) {
+	assets, err := fs.Sub(embeddedAssets, "assets")
+	require.NoError(t, err)
+
+	providertest.Assets(t, providertest.Provider{
+		WithUI:    WithUI(),
+		Assets:    assets,
+		Integrity: constant.SwaggerUIIntegrity,
+		Script:    "swagger-ui-bundle.js",
+	})
+}
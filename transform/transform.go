@@ -0,0 +1,118 @@
+// Package transform defines the transformers that rewrite OpenAPI
+// specifications before they are served, see specui.WithSpecTransform.
+//
+// Transformers operate on a parsed document rather than on raw bytes: the
+// document is parsed once, passed to each transformer in order, and encoded
+// back in the format it was read in. Key order, and the position of every
+// value that is kept, survive the round trip.
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec-ui/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// Transformer rewrites doc in place. variant is the key of the request the
+// document is served for, see specui.WithSpecVariant; it is empty when no
+// variant function is configured and when the document is used outside a
+// request, e.g. for validation or export. The result must depend only on the
+// document and variant, since it is cached for both.
+type Transformer func(doc *Document, variant string) error
+
+// Document is a parsed OpenAPI document.
+type Document struct {
+	Root     *yaml.Node      // Root mapping node, which transformers may edit or replace
+	Warnings validate.Errors // Constructs transformers could not handle, reported with validation problems
+}
+
+// Version returns the value of the "openapi" field of the document, or of
+// the "swagger" field of a Swagger 2.0 document.
+func (d *Document) Version() string {
+	if v := d.Get("/openapi"); v != nil {
+		return v.Value
+	}
+	if v := d.Get("/swagger"); v != nil {
+		return v.Value
+	}
+	return ""
+}
+
+// Get returns the value at the JSON pointer ptr, or nil when there is none.
+func (d *Document) Get(ptr string) *yaml.Node {
+	if ptr == "" {
+		return d.Root
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil
+	}
+	n := d.Root
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		n = child(n, token)
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// Set sets the value at the JSON pointer ptr to v. The parent of the value
+// must be a mapping; a key that does not exist is appended to it.
+func (d *Document) Set(ptr string, v *yaml.Node) error {
+	i := strings.LastIndexByte(ptr, '/')
+	if i < 0 {
+		return fmt.Errorf("invalid JSON pointer %q", ptr)
+	}
+	parent := d.Get(ptr[:i])
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: parent is not an object", ptr)
+	}
+	key := strings.NewReplacer("~1", "/", "~0", "~").Replace(ptr[i+1:])
+	for j := 0; j+1 < len(parent.Content); j += 2 {
+		if parent.Content[j].Value == key {
+			parent.Content[j+1] = v
+			return nil
+		}
+	}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, v)
+	return nil
+}
+
+// Warn records a warning located at n, the value at the JSON pointer ptr.
+// n may be nil when the construct has no position.
+func (d *Document) Warn(n *yaml.Node, ptr, format string, args ...any) {
+	e := validate.Error{Pointer: ptr, Message: fmt.Sprintf(format, args...)}
+	if n != nil {
+		e.Line, e.Column = n.Line, n.Column
+	}
+	d.Warnings = append(d.Warnings, e)
+}
+
+// child returns the value of key in a mapping or the item at index key in a
+// sequence.
+func child(n *yaml.Node, key string) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == key {
+				return n.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(key)
+		if err == nil && i >= 0 && i < len(n.Content) {
+			return n.Content[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/transform"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parse(t *testing.T, s string) *transform.Document {
+	t.Helper()
+	var n yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(s), &n))
+	return &transform.Document{Root: n.Content[0]}
+}
+
+func str(v string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+}
+
+func TestDocumentVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"when the document is OpenAPI 3", "openapi: 3.1.0\n", "3.1.0"},
+		{"when the document is Swagger 2.0", "swagger: \"2.0\"\n", "2.0"},
+		{"when the document has no version", "info: {}\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parse(t, tt.doc).Version())
+		})
+	}
+}
+
+func TestDocumentGet(t *testing.T) {
+	doc := parse(t, "paths:\n  /pets/{id}:\n    get:\n      tags: [pets, store]\n  a~b: {}\n")
+
+	tests := []struct {
+		name string
+		ptr  string
+		want string
+	}{
+		{"when the pointer escapes a slash", "/paths/~1pets~1{id}/get/tags/1", "store"},
+		{"when the pointer escapes a tilde", "/paths/a~0b", "{}"},
+		{"when the key does not exist", "/paths/~1users", ""},
+		{"when the index is out of range", "/paths/~1pets~1{id}/get/tags/2", ""},
+		{"when the pointer is not absolute", "paths", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := doc.Get(tt.ptr)
+			if tt.want == "" {
+				assert.Nil(t, n)
+				return
+			}
+			require.NotNil(t, n)
+			if n.Kind == yaml.MappingNode {
+				assert.Empty(t, n.Content)
+			} else {
+				assert.Equal(t, tt.want, n.Value)
+			}
+		})
+	}
+	assert.Same(t, doc.Root, doc.Get(""), "the empty pointer is the root")
+}
+
+func TestDocumentSet(t *testing.T) {
+	t.Run("when replacing a value", func(t *testing.T) {
+		doc := parse(t, "info:\n  title: Pets\n  version: \"1\"\n")
+		require.NoError(t, doc.Set("/info/title", str("Store")))
+		assert.Equal(t, "Store", doc.Get("/info/title").Value)
+		assert.Len(t, doc.Get("/info").Content, 4)
+	})
+	t.Run("when adding a key", func(t *testing.T) {
+		doc := parse(t, "info:\n  title: Pets\n")
+		require.NoError(t, doc.Set("/info/x-logo~1url", str("/logo.png")))
+		assert.Equal(t, "/logo.png", doc.Get("/info/x-logo~1url").Value)
+	})
+	t.Run("when the parent is not an object", func(t *testing.T) {
+		doc := parse(t, "tags: [pets]\n")
+		assert.EqualError(t, doc.Set("/tags/0", str("store")), "/tags/0: parent is not an object")
+		assert.EqualError(t, doc.Set("/info/title", str("Pets")), "/info/title: parent is not an object")
+	})
+	t.Run("when the pointer is invalid", func(t *testing.T) {
+		doc := parse(t, "{}\n")
+		assert.EqualError(t, doc.Set("info", str("Pets")), `invalid JSON pointer "info"`)
+	})
+}
+
+func TestDocumentWarn(t *testing.T) {
+	doc := parse(t, "info:\n  title: Pets\n")
+	doc.Warn(doc.Get("/info/title"), "/info/title", "title %q is too short", "Pets")
+	doc.Warn(nil, "/servers", "no servers")
+	assert.Equal(t, validate.Errors{
+		{Pointer: "/info/title", Line: 2, Column: 10, Message: `title "Pets" is too short`},
+		{Pointer: "/servers", Message: "no servers"},
+	}, doc.Warnings)
+}
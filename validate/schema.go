@@ -0,0 +1,492 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// resources maps the URL of each embedded schema to its file.
+var resources = map[string]string{
+	"http://json-schema.org/draft-04/schema":               "draft-04.json",
+	"http://swagger.io/v2/schema.json":                     "swagger-2.0.json",
+	"https://spec.openapis.org/oas/3.0/schema/2021-09-28":  "openapi-3.0.json",
+	"https://spec.openapis.org/oas/3.1/schema/2022-10-07":  "openapi-3.1.json",
+	"https://spec.openapis.org/oas/3.1/dialect/2024-11-10": "openapi-3.1-dialect.json",
+	"https://spec.openapis.org/oas/3.1/meta/2024-11-10":    "openapi-3.1-meta.json",
+	openAPI31Base: "openapi-3.1-base.json",
+}
+
+// openAPI31Base is the URL of the schema validating OpenAPI 3.1 documents
+// with their schema objects.
+const openAPI31Base = "https://github.com/oaswrap/spec-ui/validate/schemas/openapi-3.1-base.json"
+
+// entries maps each version to the URL of the schema its documents are
+// validated against.
+var entries = map[Version]string{
+	Swagger20: "http://swagger.io/v2/schema.json",
+	OpenAPI30: "https://spec.openapis.org/oas/3.0/schema/2021-09-28",
+	OpenAPI31: openAPI31Base,
+}
+
+// schema is a compiled schema, with the documents it was compiled from to
+// describe the problems found.
+type schema struct {
+	compiled *jsonschema.Schema
+	docs     map[string]interface{} // Schema documents by URL
+}
+
+// compile compiles the schema of version from the embedded schemas, which
+// refer to each other by URL; nothing is fetched.
+func compile(version Version) (*schema, error) {
+	c := jsonschema.NewCompiler()
+	c.AssertFormat = true
+	c.Formats = map[string]func(interface{}) bool{"regex": isRegex}
+	c.LoadURL = func(u string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("schema %s is not embedded", u)
+	}
+	s := &schema{docs: make(map[string]interface{}, len(resources))}
+	for id, name := range resources {
+		b, err := schemaFS.ReadFile("schemas/" + name)
+		if err != nil {
+			return nil, err
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(b, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		s.docs[id] = doc
+		if _, ok := doc["$vocabulary"]; ok {
+			// The vocabularies of a schema only apply to the schemas it is
+			// the metaschema of; none here. The compiler rejects those it
+			// does not know, such as the OpenAPI base vocabulary, though.
+			plain := make(map[string]interface{}, len(doc))
+			for k, v := range doc {
+				plain[k] = v
+			}
+			delete(plain, "$vocabulary")
+			if b, err = json.Marshal(plain); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.AddResource(id, bytes.NewReader(b)); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if s.compiled, err = c.Compile(entries[version]); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// isRegex reports whether v is a pattern. The schemas expect ECMA-262
+// patterns, whose lookarounds and backreferences are not supported by
+// package regexp, so patterns only fail when they are invalid in both.
+func isRegex(v interface{}) bool {
+	s, ok := v.(string)
+	if !ok {
+		return true
+	}
+	_, err := regexp.Compile(s)
+	var serr *syntax.Error
+	if errors.As(err, &serr) && (serr.Code == syntax.ErrInvalidPerlOp || serr.Code == syntax.ErrInvalidEscape) {
+		return true
+	}
+	return err == nil
+}
+
+// instance is a YAML document converted to the values of a JSON document,
+// with the nodes they were converted from.
+type instance struct {
+	value interface{}
+	nodes map[string]*yaml.Node // Value nodes by JSON pointer
+	keys  map[string]*yaml.Node // Key nodes by JSON pointer of their value
+}
+
+func newInstance(root *yaml.Node) *instance {
+	in := &instance{nodes: map[string]*yaml.Node{}, keys: map[string]*yaml.Node{}}
+	in.value = in.convert(root, "")
+	return in
+}
+
+func (in *instance) convert(n *yaml.Node, ptr string) interface{} {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	in.nodes[ptr] = n
+	switch n.Kind {
+	case yaml.MappingNode:
+		obj := make(map[string]interface{}, len(n.Content)/2)
+		in.convertMapping(n, ptr, obj)
+		return obj
+	case yaml.SequenceNode:
+		arr := make([]interface{}, len(n.Content))
+		for i, item := range n.Content {
+			arr[i] = in.convert(item, ptr+"/"+strconv.Itoa(i))
+		}
+		return arr
+	case yaml.ScalarNode:
+		return scalar(n)
+	}
+	return nil
+}
+
+// convertMapping adds the entries of the mapping n to obj, including those
+// of the mappings merged with "<<", which do not override the entries of n.
+func (in *instance) convertMapping(n *yaml.Node, ptr string, obj map[string]interface{}) {
+	var merged []*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			merged = append(merged, value)
+			continue
+		}
+		child := ptr + "/" + escapePointer(key.Value)
+		in.keys[child] = key
+		obj[key.Value] = in.convert(value, child)
+	}
+	for _, m := range merged {
+		for m.Kind == yaml.AliasNode {
+			m = m.Alias
+		}
+		sources := []*yaml.Node{m}
+		if m.Kind == yaml.SequenceNode {
+			sources = m.Content
+		}
+		for _, src := range sources {
+			for src.Kind == yaml.AliasNode {
+				src = src.Alias
+			}
+			if src.Kind != yaml.MappingNode {
+				continue
+			}
+			extra := make(map[string]interface{})
+			in.convertMapping(src, ptr, extra)
+			for k, v := range extra {
+				if _, ok := obj[k]; !ok {
+					obj[k] = v
+				}
+			}
+		}
+	}
+}
+
+// scalar returns the JSON value of the scalar node n.
+func scalar(n *yaml.Node) interface{} {
+	switch typeOf(n) {
+	case "null":
+		return nil
+	case "boolean":
+		var b bool
+		_ = n.Decode(&b)
+		return b
+	case "integer", "number":
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return n.Value
+		}
+		switch v := v.(type) {
+		case int:
+			return json.Number(strconv.Itoa(v))
+		case int64:
+			return json.Number(strconv.FormatInt(v, 10))
+		case uint64:
+			return json.Number(strconv.FormatUint(v, 10))
+		case float64:
+			return v
+		}
+	}
+	return n.Value
+}
+
+// node returns the node at the JSON pointer ptr, or the nearest node above it.
+func (in *instance) node(ptr string) *yaml.Node {
+	for {
+		if n, ok := in.nodes[ptr]; ok {
+			return n
+		}
+		i := strings.LastIndexByte(ptr, '/')
+		if i < 0 {
+			return nil
+		}
+		ptr = ptr[:i]
+	}
+}
+
+// errors converts the validation error err into the problems it reports.
+func (s *schema) errors(in *instance, err *jsonschema.ValidationError) []Error {
+	var errs []Error
+	for _, leaf := range leaves(err) {
+		errs = append(errs, s.describe(in, leaf)...)
+	}
+	return errs
+}
+
+// leaves returns the errors at the leaves of err. Of the alternatives of a
+// failing oneOf or anyOf, only the one closest to the value is kept: the
+// others mostly report that the value is something else.
+func leaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	switch keyword(err.KeywordLocation) {
+	case "oneOf", "anyOf":
+		var best []*jsonschema.ValidationError
+		for _, cause := range err.Causes {
+			l := leaves(cause)
+			if best == nil || closer(l, best) {
+				best = l
+			}
+		}
+		return best
+	}
+	var out []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		out = append(out, leaves(cause)...)
+	}
+	return out
+}
+
+// closer reports whether the alternative failing with the errors a is closer
+// to the value than the one failing with b. An alternative is further when
+// it only lacks a $ref, as a reference object the value is not meant to be,
+// or when a field has another value than the single one it allows, like the
+// "in" of a parameter for another location. Otherwise the alternative with
+// errors deeper in the value, then with fewer errors, is closer; ties keep
+// the first alternative.
+func closer(a, b []*jsonschema.ValidationError) bool {
+	if ra, rb := onlyLacksRef(a), onlyLacksRef(b); ra != rb {
+		return rb
+	}
+	if da, db := mismatches(a), mismatches(b); da != db {
+		return da < db
+	}
+	if da, db := depth(a), depth(b); da != db {
+		return da > db
+	}
+	return len(a) < len(b)
+}
+
+func onlyLacksRef(errs []*jsonschema.ValidationError) bool {
+	return len(errs) == 1 && keyword(errs[0].KeywordLocation) == "required" && errs[0].Message == "missing properties: '$ref'"
+}
+
+// mismatches counts the errors of values other than the single one allowed.
+func mismatches(errs []*jsonschema.ValidationError) int {
+	n := 0
+	for _, e := range errs {
+		switch keyword(e.KeywordLocation) {
+		case "const":
+			n++
+		case "enum":
+			if !strings.HasPrefix(e.Message, "value must be one of") {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func depth(errs []*jsonschema.ValidationError) int {
+	d := 0
+	for _, e := range errs {
+		if n := strings.Count(e.InstanceLocation, "/"); n > d {
+			d = n
+		}
+	}
+	return d
+}
+
+// keyword returns the keyword at the end of a keyword location.
+func keyword(location string) string {
+	return location[strings.LastIndexByte(location, '/')+1:]
+}
+
+var quoted = regexp.MustCompile(`'((?:[^'\\]|\\.)*)'`)
+
+// names returns the names quoted in a message of the validator.
+func names(msg string) []string {
+	var out []string
+	for _, m := range quoted.FindAllStringSubmatch(msg, -1) {
+		s := strings.ReplaceAll(m[1], `\'`, `'`)
+		if name, err := strconv.Unquote(`"` + strings.ReplaceAll(s, `"`, `\"`) + `"`); err == nil {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// describe converts a leaf validation error into problems, worded after the
+// keyword that failed.
+func (s *schema) describe(in *instance, e *jsonschema.ValidationError) []Error {
+	ptr := unescapeLocation(e.InstanceLocation)
+	n := in.node(ptr)
+	if n == nil {
+		return []Error{{Pointer: ptr, Message: e.Message}}
+	}
+	switch kw := keyword(e.KeywordLocation); {
+	case kw == "required":
+		var errs []Error
+		for _, name := range names(e.Message) {
+			errs = append(errs, newError(n, ptr, fmt.Sprintf("missing required property %q", name)))
+		}
+		return errs
+	case kw == "additionalProperties" && strings.HasPrefix(e.Message, "additionalProperties "):
+		var errs []Error
+		for _, name := range names(e.Message) {
+			key := in.keys[ptr+"/"+escapePointer(name)]
+			if key == nil {
+				key = n
+			}
+			errs = append(errs, newError(key, ptr, fmt.Sprintf("unexpected property %q", name)))
+		}
+		return errs
+	case (kw == "unevaluatedProperties" || kw == "additionalProperties") && e.Message == "not allowed":
+		parent, name := ptr[:strings.LastIndexByte(ptr, '/')], ptr[strings.LastIndexByte(ptr, '/')+1:]
+		name = strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+		key := in.keys[ptr]
+		if key == nil {
+			key = n
+		}
+		return []Error{newError(key, parent, fmt.Sprintf("unexpected property %q", name))}
+	case kw == "type":
+		want := strings.TrimPrefix(e.Message, "expected ")
+		if i := strings.Index(want, ", but got"); i >= 0 {
+			want = want[:i]
+		}
+		return []Error{newError(n, ptr, fmt.Sprintf("expected %s, got %s", want, typeOf(n)))}
+	case kw == "enum" || kw == "const":
+		return []Error{newError(n, ptr, strings.TrimPrefix(e.Message, "value "))}
+	case kw == "format":
+		if f := names(e.Message); len(f) > 0 {
+			return []Error{newError(n, ptr, fmt.Sprintf("%q is not a valid %s", n.Value, f[len(f)-1]))}
+		}
+	case kw == "pattern":
+		return []Error{newError(n, ptr, fmt.Sprintf("%q %s", n.Value, e.Message))}
+	case kw == "not":
+		msg := "matches a disallowed schema"
+		if d := s.description(strings.TrimSuffix(e.AbsoluteKeywordLocation, "/not")); d != "" {
+			msg += ": " + d
+		}
+		return []Error{newError(n, ptr, msg)}
+	case kw == "oneOf":
+		return []Error{newError(n, ptr, "matches several alternatives, expected exactly one")}
+	case e.Message == "not allowed":
+		return []Error{newError(n, ptr, "is not allowed")}
+	}
+	return []Error{newError(n, ptr, e.Message)}
+}
+
+// description returns the description of the schema at the absolute
+// location loc, or "".
+func (s *schema) description(loc string) string {
+	id, ptr, _ := strings.Cut(loc, "#")
+	v := s.docs[id]
+	for _, token := range strings.Split(ptr, "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch c := v.(type) {
+		case map[string]interface{}:
+			v = c[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return ""
+			}
+			v = c[i]
+		default:
+			return ""
+		}
+	}
+	if obj, ok := v.(map[string]interface{}); ok {
+		d, _ := obj["description"].(string)
+		return d
+	}
+	return ""
+}
+
+// typeOf returns the JSON type of a node.
+func typeOf(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!null":
+			return "null"
+		case "!!bool":
+			return "boolean"
+		case "!!int":
+			return "integer"
+		case "!!float":
+			var f float64
+			if err := n.Decode(&f); err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) {
+				return "integer"
+			}
+			return "number"
+		}
+		return "string"
+	}
+	return "null"
+}
+
+func isString(n *yaml.Node) bool {
+	return typeOf(n) == "string"
+}
+
+// unescapeLocation returns the JSON pointer of an instance location of the
+// validator, whose reference tokens are also escaped for URLs.
+func unescapeLocation(loc string) string {
+	tokens := strings.Split(loc, "/")
+	for i, token := range tokens {
+		if t, err := url.PathUnescape(token); err == nil {
+			tokens[i] = t
+		}
+	}
+	return strings.Join(tokens, "/")
+}
+
+// escapePointer escapes a JSON pointer reference token.
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func newError(n *yaml.Node, ptr, msg string) Error {
+	return Error{Pointer: ptr, Line: n.Line, Column: n.Column, Message: msg}
+}
+
+// sortErrors orders errors by position in the document and removes duplicates
+// reported through several schema branches.
+func sortErrors(errs []Error) []Error {
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		if errs[i].Column != errs[j].Column {
+			return errs[i].Column < errs[j].Column
+		}
+		return errs[i].Message < errs[j].Message
+	})
+	out := errs[:0]
+	for i, e := range errs {
+		if i > 0 && e == errs[i-1] {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+// Package validate checks OpenAPI documents against the OpenAPI Initiative
+// JSON Schemas for Swagger 2.0, OpenAPI 3.0 and 3.1, with a validator
+// implementing JSON Schema draft-04 and 2020-12
+// (github.com/santhosh-tekuri/jsonschema). Formats, such as the URLs and
+// emails of contacts, are asserted.
+//
+// The official schemas are embedded verbatim: the Swagger 2.0 schema with
+// the draft-04 metaschema it refers to, the OpenAPI 3.0 schema of
+// 2021-09-28, and the OpenAPI 3.1 schema of 2022-10-07 with the OpenAPI
+// JSON Schema dialect and vocabulary of 2024-11-10. The 3.1 schema only
+// checks that schema objects are objects or booleans; openapi-3.1-base.json,
+// which is not an official schema, checks them against the dialect instead,
+// as the official schema-base does.
+package validate
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Error describes a value in a document that does not conform to its schema.
+type Error struct {
+	Pointer string `json:"pointer"` // JSON pointer to the value, e.g. "/paths/~1pets/get"
+	Line    int    `json:"line"`    // Line of the value in the document, starting at 1
+	Column  int    `json:"column"`  // Column of the value in the document, starting at 1
+	Message string `json:"message"` // Description of the problem
+}
+
+func (e Error) Error() string {
+	ptr := e.Pointer
+	if ptr == "" {
+		ptr = "/"
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%s (line %d): %s", ptr, e.Line, e.Message)
+	}
+	return ptr + ": " + e.Message
+}
+
+// Errors is the list of problems found in a document.
+type Errors []Error
+
+func (e Errors) Error() string {
+	switch len(e) {
+	case 0:
+		return "OpenAPI specification is valid"
+	case 1:
+		return "invalid OpenAPI specification: " + e[0].Error()
+	}
+	return fmt.Sprintf("invalid OpenAPI specification: %s (and %d more)", e[0].Error(), len(e)-1)
+}
+
+// Version identifies the specification version a document is written for.
+type Version string
+
+const (
+	Swagger20 Version = "2.0"
+	OpenAPI30 Version = "3.0"
+	OpenAPI31 Version = "3.1"
+)
+
+var versionPattern = regexp.MustCompile(`^(\d+\.\d+)(\.|$)`)
+
+var (
+	schemasMu sync.Mutex
+	schemas   = map[Version]*schema{}
+)
+
+// Document validates an OpenAPI document in JSON or YAML. It returns the
+// problems found, or an error when the document cannot be parsed.
+func Document(b []byte) (Errors, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, errors.New("failed to parse OpenAPI specification: document is empty")
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return Errors{newError(root, "", "expected object, got "+typeOf(root))}, nil
+	}
+
+	version, problem := detectVersion(root)
+	if problem != nil {
+		return Errors{*problem}, nil
+	}
+	s, err := load(version)
+	if err != nil {
+		return nil, err
+	}
+	in := newInstance(root)
+	var invalid *jsonschema.ValidationError
+	switch err := s.compiled.Validate(in.value); {
+	case err == nil:
+		return nil, nil
+	case !errors.As(err, &invalid):
+		return nil, fmt.Errorf("failed to validate OpenAPI specification: %w", err)
+	}
+	return sortErrors(s.errors(in, invalid)), nil
+}
+
+// detectVersion reads the "openapi" or "swagger" field of a document.
+func detectVersion(root *yaml.Node) (Version, *Error) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value != "openapi" && key.Value != "swagger" {
+			continue
+		}
+		ptr := "/" + key.Value
+		if value.Kind != yaml.ScalarNode || !isString(value) {
+			e := newError(value, ptr, "expected string, got "+typeOf(value))
+			return "", &e
+		}
+		m := versionPattern.FindStringSubmatch(value.Value)
+		switch {
+		case key.Value == "swagger" && value.Value == string(Swagger20):
+			return Swagger20, nil
+		case key.Value == "openapi" && m != nil && (m[1] == string(OpenAPI30) || m[1] == string(OpenAPI31)):
+			return Version(m[1]), nil
+		}
+		e := newError(value, ptr, fmt.Sprintf("unsupported version %q", value.Value))
+		return "", &e
+	}
+	e := newError(root, "", `missing required property "openapi"`)
+	return "", &e
+}
+
+func load(version Version) (*schema, error) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+	if s, ok := schemas[version]; ok {
+		return s, nil
+	}
+	s, err := compile(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile the OpenAPI %s schema: %w", version, err)
+	}
+	schemas[version] = s
+	return s, nil
+}
@@ -0,0 +1,225 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec-ui/testdata"
+	"github.com/oaswrap/spec-ui/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []validate.Error
+	}{
+		{
+			name: "when an OpenAPI 3.0 document is valid",
+			doc: `openapi: 3.0.3
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    get:
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+        default: {$ref: "#/components/responses/Error"}
+components:
+  schemas:
+    Pet: {type: object, additionalProperties: false, properties: {name: {type: string, nullable: true}}}
+  responses:
+    Error: {description: Error}
+  securitySchemes:
+    key: {type: apiKey, name: X-Key, in: header}
+x-internal: true
+`,
+		},
+		{
+			name: "when an OpenAPI 3.1 document is valid",
+			doc: `{
+  "openapi": "3.1.0",
+  "info": {"title": "Pets", "version": "1.0", "license": {"name": "MIT", "identifier": "MIT"}},
+  "webhooks": {"newPet": {"post": {"requestBody": {"content": {"application/json": {"schema": true}}}}}},
+  "components": {"schemas": {"Pet": {"type": ["object", "null"]}}}
+}`,
+		},
+		{
+			name: "when a Swagger 2.0 document is valid",
+			doc: `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+basePath: /v1
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, type: integer}
+        - {name: body, in: body, schema: {$ref: "#/definitions/Pet"}}
+      responses:
+        200: {description: OK, schema: {type: array, items: {$ref: "#/definitions/Pet"}}}
+definitions:
+  Pet: {type: object, required: [name], properties: {name: {type: string}}}
+securityDefinitions:
+  oauth: {type: oauth2, flow: implicit, authorizationUrl: "https://example.com/auth", scopes: {}}
+`,
+		},
+		{
+			name: "when required properties are missing",
+			doc:  "openapi: 3.0.3\ninfo:\n  title: Pets\n",
+			want: []validate.Error{
+				{Pointer: "", Line: 1, Column: 1, Message: `missing required property "paths"`},
+				{Pointer: "/info", Line: 3, Column: 3, Message: `missing required property "version"`},
+			},
+		},
+		{
+			name: "when values have the wrong type",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: 1}\npaths:\n  /pets:\n    get:\n      deprecated: yes\n      responses: {200: {description: OK}}\n",
+			want: []validate.Error{
+				{Pointer: "/info/version", Line: 2, Column: 30, Message: "expected string, got integer"},
+				{Pointer: "/paths/~1pets/get/deprecated", Line: 6, Column: 19, Message: "expected boolean, got string"},
+			},
+		},
+		{
+			name: "when properties are unknown",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths: {}\ncomponent: {}\n",
+			want: []validate.Error{
+				{Pointer: "", Line: 4, Column: 1, Message: `unexpected property "component"`},
+			},
+		},
+		{
+			name: "when a path parameter is not required",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets/{id}:\n    parameters:\n      - {name: id, in: path, style: form}\n",
+			want: []validate.Error{
+				{Pointer: "/paths/~1pets~1{id}/parameters/0", Line: 6, Column: 9, Message: `missing required property "required"`},
+				{Pointer: "/paths/~1pets~1{id}/parameters/0", Line: 6, Column: 9, Message: `missing required property "schema"`},
+				{Pointer: "/paths/~1pets~1{id}/parameters/0/style", Line: 6, Column: 37, Message: `must be one of "matrix", "label", "simple"`},
+			},
+		},
+		{
+			name: "when a reference is invalid",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    get:\n      responses:\n        default: {$ref: 1}\n",
+			want: []validate.Error{
+				{Pointer: "/paths/~1pets/get/responses/default/$ref", Line: 7, Column: 25, Message: "expected string, got integer"},
+			},
+		},
+		{
+			name: "when a response code is invalid",
+			doc:  "openapi: 3.1.0\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    get:\n      responses:\n        600: {description: OK}\n",
+			want: []validate.Error{
+				{Pointer: "/paths/~1pets/get/responses", Line: 7, Column: 9, Message: `missing required property "default"`},
+				{Pointer: "/paths/~1pets/get/responses", Line: 7, Column: 9, Message: `unexpected property "600"`},
+			},
+		},
+		{
+			name: "when an OpenAPI 3.1 document has nothing to describe",
+			doc:  "openapi: 3.1.0\ninfo: {title: Pets, version: \"1\"}\n",
+			want: []validate.Error{
+				{Pointer: "", Line: 1, Column: 1, Message: `missing required property "paths"`},
+			},
+		},
+		{
+			name: "when a Swagger 2.0 parameter has no type",
+			doc:  "swagger: \"2.0\"\ninfo: {title: Pets, version: \"1\"}\npaths:\n  /pets:\n    get:\n      parameters: [{name: limit, in: query}]\n      responses: {200: {description: OK}}\n",
+			want: []validate.Error{
+				{Pointer: "/paths/~1pets/get/parameters/0", Line: 6, Column: 20, Message: `missing required property "type"`},
+			},
+		},
+		{
+			name: "when a value has the wrong format",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\", contact: {email: pets}}\npaths: {}\n",
+			want: []validate.Error{
+				{Pointer: "/info/contact/email", Line: 2, Column: 52, Message: `"pets" is not a valid email`},
+			},
+		},
+		{
+			name: "when fields exclude each other",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths: {}\ncomponents:\n  parameters:\n    q: {name: q, in: query, schema: {type: string}, example: a, examples: {a: {value: a}}}\n",
+			want: []validate.Error{
+				{Pointer: "/components/parameters/q", Line: 6, Column: 8, Message: "matches a disallowed schema: Example and examples are mutually exclusive"},
+			},
+		},
+		{
+			name: "when a number is out of bounds",
+			doc:  "openapi: 3.0.3\ninfo: {title: Pets, version: \"1\"}\npaths: {}\ncomponents:\n  schemas:\n    Name: {type: string, maxLength: -1, pattern: \"^(?!admin)\"}\n",
+			want: []validate.Error{
+				{Pointer: "/components/schemas/Name/maxLength", Line: 6, Column: 37, Message: "must be >= 0 but found -1"},
+			},
+		},
+		{
+			name: "when an OpenAPI 3.1 schema object is invalid",
+			doc:  "openapi: 3.1.0\ninfo: {title: Pets, version: \"1\"}\npaths: {}\ncomponents:\n  schemas:\n    Pet: {type: strin, discriminator: {}}\n",
+			want: []validate.Error{
+				{Pointer: "/components/schemas/Pet/type", Line: 6, Column: 17, Message: `must be one of "array", "boolean", "integer", "null", "number", "object", "string"`},
+				{Pointer: "/components/schemas/Pet/discriminator", Line: 6, Column: 39, Message: `missing required property "propertyName"`},
+			},
+		},
+		{
+			name: "when the version is not supported",
+			doc:  "openapi: 4.0.0\n",
+			want: []validate.Error{
+				{Pointer: "/openapi", Line: 1, Column: 10, Message: `unsupported version "4.0.0"`},
+			},
+		},
+		{
+			name: "when the version is missing",
+			doc:  `{"info": {}}`,
+			want: []validate.Error{
+				{Pointer: "", Line: 1, Column: 1, Message: `missing required property "openapi"`},
+			},
+		},
+		{
+			name: "when the document is not an object",
+			doc:  "[]",
+			want: []validate.Error{
+				{Pointer: "", Line: 1, Column: 1, Message: "expected object, got array"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := validate.Document([]byte(tt.doc))
+			require.NoError(t, err)
+			assert.Equal(t, validate.Errors(tt.want), errs)
+		})
+	}
+}
+
+func TestDocumentTestdata(t *testing.T) {
+	for _, file := range []string{"petstore.yaml", "petstore.json"} {
+		t.Run("when validating "+file, func(t *testing.T) {
+			b, err := testdata.FS.ReadFile(file)
+			require.NoError(t, err)
+			errs, err := validate.Document(b)
+			require.NoError(t, err)
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestDocumentErrors(t *testing.T) {
+	t.Run("when the document cannot be parsed", func(t *testing.T) {
+		_, err := validate.Document([]byte("openapi: [3.0"))
+		assert.Error(t, err)
+	})
+	t.Run("when the document is empty", func(t *testing.T) {
+		_, err := validate.Document(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestErrors(t *testing.T) {
+	errs := validate.Errors{
+		{Pointer: "/info", Line: 3, Column: 3, Message: `missing required property "version"`},
+		{Pointer: "", Message: `missing required property "paths"`},
+	}
+	assert.Equal(t, `/info (line 3): missing required property "version"`, errs[0].Error())
+	assert.Equal(t, `/: missing required property "paths"`, errs[1].Error())
+	assert.Equal(t, `invalid OpenAPI specification: /info (line 3): missing required property "version" (and 1 more)`, errs.Error())
+	assert.Equal(t, `invalid OpenAPI specification: /info (line 3): missing required property "version"`, errs[:1].Error())
+}
@@ -0,0 +1,73 @@
+package specui
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/oaswrap/spec-ui/config"
+	"github.com/oaswrap/spec-ui/internal/errorhandler"
+	"github.com/oaswrap/spec-ui/internal/logger"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/problem"
+	"github.com/oaswrap/spec-ui/validate"
+)
+
+var validationTpl = template.Must(template.New("validation").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{ .Title }}</title>
+  <style>
+    body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; color: #1f2328; }
+    h1 { font-size: 1.5rem; color: #cf222e; }
+    li { margin: .5rem 0; }
+    code { background: #f6f8fa; padding: .1rem .3rem; border-radius: 4px; }
+  </style>
+</head>
+<body>
+  <h1>Invalid OpenAPI specification</h1>
+  <p>The specification does not conform to its schema:</p>
+  <ul>
+    {{ range .Problems }}<li><code>{{ or .Pointer "/" }}</code>{{ if .Line }} (line {{ .Line }}){{ end }}: {{ .Message }}</li>
+    {{ end }}
+  </ul>
+</body>
+</html>
+`))
+
+// validationPage serves a page listing the validation problems of the
+// specification in place of the docs UI.
+type validationPage struct {
+	docs http.Handler
+	spec *spec.Handler
+	cfg  *config.SpecUI
+}
+
+func (p *validationPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	problems, err := p.spec.Validate()
+	switch {
+	case err != nil:
+		// The specification could not be loaded or parsed.
+		p.spec.ServeError(w, r, err)
+		return
+	case len(problems) == 0:
+		p.docs.ServeHTTP(w, r)
+		return
+	}
+
+	var buf bytes.Buffer
+	if terr := validationTpl.Execute(&buf, struct {
+		Title    string
+		Problems validate.Errors
+	}{p.cfg.Title, problems}); terr != nil {
+		logger.Get(p.cfg).Error("failed to render validation page", "path", r.URL.Path, "error", terr)
+		errorhandler.Get(p.cfg)(w, r, http.StatusInternalServerError, &problem.Error{Message: "failed to render validation page", Err: terr})
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write(buf.Bytes())
+}
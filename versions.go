@@ -0,0 +1,163 @@
+package specui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/oaswrap/spec-ui/internal/errorhandler"
+	"github.com/oaswrap/spec-ui/internal/logger"
+	"github.com/oaswrap/spec-ui/internal/spec"
+	"github.com/oaswrap/spec-ui/problem"
+	"github.com/oaswrap/spec-ui/store"
+)
+
+// versionsHandler serves the versions of the specification held by the store
+// configured with WithSpecStore: their list at the versions path, and the docs
+// page and the specification of each version under it.
+type versionsHandler struct {
+	handler  *Handler
+	mu       sync.Mutex
+	versions map[string]*versionHandler // Handlers of the versions requested so far
+}
+
+// versionHandler serves the docs page and the specification of a version.
+type versionHandler struct {
+	docs http.Handler
+	spec *spec.Handler
+}
+
+func (v *versionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cfg := v.handler.cfg
+	rest := strings.TrimPrefix(r.URL.Path, cfg.Versions.Path)
+	switch {
+	case rest == "" || rest == "/":
+		v.serveList(w, r)
+		return
+	case rest == r.URL.Path || rest[0] != '/':
+		v.notFound(w, r, nil)
+		return
+	}
+
+	version, file, _ := strings.Cut(rest[1:], "/")
+	if !store.ValidVersion(version) || file != "" && file != path.Base(cfg.SpecPath) {
+		v.notFound(w, r, nil)
+		return
+	}
+	vh, err := v.version(r.Context(), version)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		v.notFound(w, r, err)
+		return
+	case err != nil:
+		v.unavailable(w, r, err)
+		return
+	}
+	if file == "" {
+		vh.docs.ServeHTTP(w, r)
+		return
+	}
+	vh.spec.ServeHTTP(w, r)
+}
+
+// version returns the handler of version. The store is asked for its
+// versions on each call: handlers are created for the versions it lists only,
+// since clients choose the paths, and dropped once it no longer lists them.
+func (v *versionsHandler) version(ctx context.Context, version string) (*versionHandler, error) {
+	versions, err := v.handler.cfg.Versions.Store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !contains(versions, version) {
+		delete(v.versions, version)
+		return nil, fmt.Errorf("%s: %w", version, store.ErrNotFound)
+	}
+	if vh := v.versions[version]; vh != nil {
+		return vh, nil
+	}
+	if v.versions == nil {
+		v.versions = make(map[string]*versionHandler)
+	}
+	vh := v.handler.newVersion(version)
+	v.versions[version] = vh
+	return vh, nil
+}
+
+// serveList writes the versions as a JSON object, read by the version
+// selector of the docs pages:
+//
+//	{"latest": "2.0.0", "versions": ["2.0.0", "1.0.0"]}
+func (v *versionsHandler) serveList(w http.ResponseWriter, r *http.Request) {
+	s := v.handler.cfg.Versions.Store
+	versions, err := s.List(r.Context())
+	var latest string
+	if err == nil {
+		latest, err = s.Latest(r.Context())
+		if errors.Is(err, store.ErrNotFound) {
+			err = nil
+		}
+	}
+	if err != nil {
+		v.unavailable(w, r, err)
+		return
+	}
+	if versions == nil {
+		versions = []string{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	_ = json.NewEncoder(w).Encode(struct {
+		Latest   string   `json:"latest"`
+		Versions []string `json:"versions"`
+	}{latest, versions})
+}
+
+func (v *versionsHandler) notFound(w http.ResponseWriter, r *http.Request, err error) {
+	errorhandler.Get(v.handler.cfg)(w, r, http.StatusNotFound, &problem.Error{Message: "OpenAPI specification version is not found", Err: err})
+}
+
+func (v *versionsHandler) unavailable(w http.ResponseWriter, r *http.Request, err error) {
+	logger.Get(v.handler.cfg).Error("failed to list OpenAPI specification versions", "path", r.URL.Path, "error", err)
+	errorhandler.Get(v.handler.cfg)(w, r, http.StatusServiceUnavailable, &problem.Error{Message: "OpenAPI specification versions are temporarily unavailable", Err: err})
+}
+
+// newVersion returns the handler of the docs page and the specification of
+// version, configured like those of the latest version but served under the
+// versions path.
+func (h *Handler) newVersion(version string) *versionHandler {
+	if h.cfg.DocsHandlerFactory == nil {
+		panic(errNoProvider)
+	}
+	cfg := *h.cfg
+	cfg.DocsPath = h.cfg.Versions.Path + "/" + version
+	cfg.SpecPath = cfg.DocsPath + "/" + path.Base(h.cfg.SpecPath)
+	cfg.SpecSource = store.Source{Store: h.cfg.Versions.Store, Version: version}
+	cfg.Versions.Current = version
+	cfg.Versions.LatestDocsPath = h.cfg.DocsPath
+
+	s := spec.NewHandler(&cfg)
+	cfg.SpecLoader = s.Current
+	return &versionHandler{docs: newDocs(&cfg, s), spec: s}
+}
+
+// isVersionsPath reports whether p is the versions path or a path under it.
+func (h *Handler) isVersionsPath(p string) bool {
+	return h.VersionsEnabled() && (p == h.cfg.Versions.Path || strings.HasPrefix(p, h.cfg.Versions.Path+"/"))
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}